@@ -175,8 +175,10 @@ func (abstractWindowView *AbstractWindowView) renderEmptyView(win RenderWindow,
 
 func (abstractWindowView *AbstractWindowView) runReportingTask(message string, operation func(chan bool)) {
 	abstractWindowView.channels.ReportStatus(message)
+	pendingBackgroundWork.Start()
 
 	go func() {
+		defer pendingBackgroundWork.Complete()
 		quit := make(chan bool)
 
 		operation(quit)
@@ -242,8 +244,8 @@ func (abstractWindowView *AbstractWindowView) logViewPos(stage string) {
 func moveUpRow(abstractWindowView *AbstractWindowView, action Action) (err error) {
 	viewPos := abstractWindowView.child.viewPos()
 
-	if viewPos.MoveLineUp() {
-		log.Debugf("Moving cursor up one %v", abstractWindowView.rowDescriptor)
+	if repeatMovement(action, viewPos.MoveLineUp) {
+		log.Debugf("Moving cursor up %v", abstractWindowView.rowDescriptor)
 		err = abstractWindowView.notifyChildRowSelected(viewPos.ActiveRowIndex())
 		abstractWindowView.channels.UpdateDisplay()
 	}
@@ -255,8 +257,8 @@ func moveDownRow(abstractWindowView *AbstractWindowView, action Action) (err err
 	rows := abstractWindowView.child.rows()
 	viewPos := abstractWindowView.child.viewPos()
 
-	if viewPos.MoveLineDown(rows) {
-		log.Debugf("Moving cursor down one %v", abstractWindowView.rowDescriptor)
+	if repeatMovement(action, func() bool { return viewPos.MoveLineDown(rows) }) {
+		log.Debugf("Moving cursor down %v", abstractWindowView.rowDescriptor)
 		err = abstractWindowView.notifyChildRowSelected(viewPos.ActiveRowIndex())
 		abstractWindowView.channels.UpdateDisplay()
 	}
@@ -267,8 +269,10 @@ func moveDownRow(abstractWindowView *AbstractWindowView, action Action) (err err
 func moveUpPage(abstractWindowView *AbstractWindowView, action Action) (err error) {
 	viewPos := abstractWindowView.child.viewPos()
 
-	if viewPos.MovePageUp(abstractWindowView.child.viewDimension().rows - abstractWindowView.borderWidth) {
-		log.Debugf("Moving cursor up one page of %vs", abstractWindowView.rowDescriptor)
+	pageRows := abstractWindowView.child.viewDimension().rows - abstractWindowView.borderWidth
+
+	if repeatMovement(action, func() bool { return viewPos.MovePageUp(pageRows) }) {
+		log.Debugf("Moving cursor up page of %vs", abstractWindowView.rowDescriptor)
 		err = abstractWindowView.notifyChildRowSelected(viewPos.ActiveRowIndex())
 		abstractWindowView.channels.UpdateDisplay()
 	}
@@ -280,8 +284,10 @@ func moveDownPage(abstractWindowView *AbstractWindowView, action Action) (err er
 	rows := abstractWindowView.child.rows()
 	viewPos := abstractWindowView.child.viewPos()
 
-	if viewPos.MovePageDown(abstractWindowView.child.viewDimension().rows-abstractWindowView.borderWidth, rows) {
-		log.Debugf("Moving cursor down one page of %vs", abstractWindowView.rowDescriptor)
+	pageRows := abstractWindowView.child.viewDimension().rows - abstractWindowView.borderWidth
+
+	if repeatMovement(action, func() bool { return viewPos.MovePageDown(pageRows, rows) }) {
+		log.Debugf("Moving cursor down page of %vs", abstractWindowView.rowDescriptor)
 		err = abstractWindowView.notifyChildRowSelected(viewPos.ActiveRowIndex())
 		abstractWindowView.channels.UpdateDisplay()
 	}
@@ -292,7 +298,9 @@ func moveDownPage(abstractWindowView *AbstractWindowView, action Action) (err er
 func moveUpHalfPage(abstractWindowView *AbstractWindowView, action Action) (err error) {
 	viewPos := abstractWindowView.child.viewPos()
 
-	if viewPos.MovePageUp(abstractWindowView.child.viewDimension().rows/2 - abstractWindowView.borderWidth) {
+	pageRows := abstractWindowView.child.viewDimension().rows/2 - abstractWindowView.borderWidth
+
+	if repeatMovement(action, func() bool { return viewPos.MovePageUp(pageRows) }) {
 		log.Debugf("Moving cursor up half page of %vs", abstractWindowView.rowDescriptor)
 		err = abstractWindowView.notifyChildRowSelected(viewPos.ActiveRowIndex())
 		abstractWindowView.channels.UpdateDisplay()
@@ -305,7 +313,9 @@ func moveDownHalfPage(abstractWindowView *AbstractWindowView, action Action) (er
 	rows := abstractWindowView.child.rows()
 	viewPos := abstractWindowView.child.viewPos()
 
-	if viewPos.MovePageDown(abstractWindowView.child.viewDimension().rows/2-abstractWindowView.borderWidth, rows) {
+	pageRows := abstractWindowView.child.viewDimension().rows/2 - abstractWindowView.borderWidth
+
+	if repeatMovement(action, func() bool { return viewPos.MovePageDown(pageRows, rows) }) {
 		log.Debugf("Moving cursor down half page of %vs", abstractWindowView.rowDescriptor)
 		err = abstractWindowView.notifyChildRowSelected(viewPos.ActiveRowIndex())
 		abstractWindowView.channels.UpdateDisplay()
@@ -317,7 +327,12 @@ func moveDownHalfPage(abstractWindowView *AbstractWindowView, action Action) (er
 func scrollRight(abstractWindowView *AbstractWindowView, action Action) (err error) {
 	log.Debug("Scrolling right")
 	viewPos := abstractWindowView.child.viewPos()
-	viewPos.MovePageRight(abstractWindowView.child.viewDimension().cols)
+	cols := abstractWindowView.child.viewDimension().cols
+
+	for count, _ := GetActionCount(action); count > 0; count-- {
+		viewPos.MovePageRight(cols)
+	}
+
 	abstractWindowView.channels.UpdateDisplay()
 
 	return
@@ -326,7 +341,9 @@ func scrollRight(abstractWindowView *AbstractWindowView, action Action) (err err
 func scrollLeft(abstractWindowView *AbstractWindowView, action Action) (err error) {
 	viewPos := abstractWindowView.child.viewPos()
 
-	if viewPos.MovePageLeft(abstractWindowView.child.viewDimension().cols) {
+	cols := abstractWindowView.child.viewDimension().cols
+
+	if repeatMovement(action, func() bool { return viewPos.MovePageLeft(cols) }) {
 		log.Debug("Scrolling left")
 		abstractWindowView.channels.UpdateDisplay()
 	}
@@ -335,6 +352,10 @@ func scrollLeft(abstractWindowView *AbstractWindowView, action Action) (err erro
 }
 
 func moveToFirstRow(abstractWindowView *AbstractWindowView, action Action) (err error) {
+	if lineNumber, countProvided := GetActionCount(action); countProvided {
+		return moveToLineNumber(abstractWindowView, lineNumber)
+	}
+
 	viewPos := abstractWindowView.child.viewPos()
 
 	if viewPos.MoveToFirstLine() {
@@ -347,6 +368,10 @@ func moveToFirstRow(abstractWindowView *AbstractWindowView, action Action) (err
 }
 
 func moveToLastRow(abstractWindowView *AbstractWindowView, action Action) (err error) {
+	if lineNumber, countProvided := GetActionCount(action); countProvided {
+		return moveToLineNumber(abstractWindowView, lineNumber)
+	}
+
 	rows := abstractWindowView.child.rows()
 	viewPos := abstractWindowView.child.viewPos()
 
@@ -359,6 +384,39 @@ func moveToLastRow(abstractWindowView *AbstractWindowView, action Action) (err e
 	return
 }
 
+// moveToLineNumber selects the row with the provided line number.
+// The last row is selected if the line number is greater than the number of rows
+func moveToLineNumber(abstractWindowView *AbstractWindowView, lineNumber uint) (err error) {
+	rows := abstractWindowView.child.rows()
+	if rows == 0 {
+		return
+	}
+
+	viewPos := abstractWindowView.child.viewPos()
+	rowIndex := MinUInt(lineNumber, rows) - 1
+
+	if viewPos.ActiveRowIndex() != rowIndex {
+		log.Debugf("Moving cursor to %v at line %v", abstractWindowView.rowDescriptor, rowIndex+1)
+		viewPos.SetActiveRowIndex(rowIndex)
+		err = abstractWindowView.notifyChildRowSelected(rowIndex)
+		abstractWindowView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+// repeatMovement performs the movement the number of times specified by the action count.
+// Repetition stops once the movement no longer changes the view position
+func repeatMovement(action Action, move func() bool) (moved bool) {
+	count, _ := GetActionCount(action)
+
+	for ; count > 0 && move(); count-- {
+		moved = true
+	}
+
+	return
+}
+
 func centerView(abstractWindowView *AbstractWindowView, action Action) (err error) {
 	viewPos := abstractWindowView.child.viewPos()
 
@@ -415,6 +415,17 @@ func TestActionNextLineIsHandledAndUpdatesResultWhenMoveLineDownReturnsTrue(t *t
 	assertChildViewAndDisplayUpdated(t, mocks)
 }
 
+func TestActionNextLineIsRepeatedByCountUntilMoveLineDownReturnsFalse(t *testing.T) {
+	abstractWindowView, mocks := setupAbstractWindowView()
+	mocks.viewPos.On("MoveLineDown", uint(24)).Return(true).Times(3)
+	mocks.viewPos.On("MoveLineDown", uint(24)).Return(false)
+
+	abstractWindowView.HandleAction(Action{ActionType: ActionNextLine, Args: []interface{}{ActionCountArgs{count: 5}}})
+
+	mocks.viewPos.AssertNumberOfCalls(t, "MoveLineDown", 4)
+	assertChildViewAndDisplayUpdated(t, mocks)
+}
+
 func TestActionPrevPageIsHandledAndNoUpdatesResultWhenMovePageUpReturnsFalse(t *testing.T) {
 	abstractWindowView, mocks := setupAbstractWindowView()
 	mocks.viewPos.On("MovePageUp", uint(22)).Return(false)
@@ -565,6 +576,31 @@ func TestActionLastLineIsHandledAndUpdatesResultWhenMoveToLastLineReturnsTrue(t
 	assertChildViewAndDisplayUpdated(t, mocks)
 }
 
+func TestActionLastLineWithCountMovesToLineNumber(t *testing.T) {
+	abstractWindowView, mocks := setupAbstractWindowView()
+	mocks.viewPos.On("SetActiveRowIndex", uint(9)).Return()
+	mocks.child.On("onRowSelected", uint(9)).Return(nil)
+
+	abstractWindowView.HandleAction(Action{ActionType: ActionLastLine, Args: []interface{}{ActionCountArgs{count: 10}}})
+
+	mocks.viewPos.AssertCalled(t, "SetActiveRowIndex", uint(9))
+	mocks.viewPos.AssertNotCalled(t, "MoveToLastLine", uint(24))
+	mocks.child.AssertCalled(t, "onRowSelected", uint(9))
+	mocks.channels.AssertCalled(t, "UpdateDisplay")
+}
+
+func TestActionFirstLineWithCountGreaterThanRowsMovesToLastLine(t *testing.T) {
+	abstractWindowView, mocks := setupAbstractWindowView()
+	mocks.viewPos.On("SetActiveRowIndex", uint(23)).Return()
+	mocks.child.On("onRowSelected", uint(23)).Return(nil)
+
+	abstractWindowView.HandleAction(Action{ActionType: ActionFirstLine, Args: []interface{}{ActionCountArgs{count: 100}}})
+
+	mocks.viewPos.AssertCalled(t, "SetActiveRowIndex", uint(23))
+	mocks.viewPos.AssertNotCalled(t, "MoveToFirstLine")
+	mocks.child.AssertCalled(t, "onRowSelected", uint(23))
+}
+
 func TestActionCenterViewIsHandledAndNoUpdatesResultWhenCenterActiveRowReturnsFalse(t *testing.T) {
 	abstractWindowView, mocks := setupAbstractWindowView()
 	mocks.viewPos.On("CenterActiveRow", uint(22)).Return(false)
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// BackgroundWork tracks work which has been queued or started but has not yet completed.
+// Work is started before it is handed to another go routine and completed once it
+// has been processed, so any work it causes is started before it completes
+type BackgroundWork struct {
+	pending int
+	idleCh  chan bool
+	lock    sync.Mutex
+}
+
+var pendingBackgroundWork = NewBackgroundWork()
+
+// NewBackgroundWork creates a new instance with no pending work
+func NewBackgroundWork() *BackgroundWork {
+	idleCh := make(chan bool)
+	close(idleCh)
+
+	return &BackgroundWork{
+		idleCh: idleCh,
+	}
+}
+
+// Start records that work has been queued or started
+func (backgroundWork *BackgroundWork) Start() {
+	backgroundWork.lock.Lock()
+	defer backgroundWork.lock.Unlock()
+
+	if backgroundWork.pending == 0 {
+		backgroundWork.idleCh = make(chan bool)
+	}
+
+	backgroundWork.pending++
+}
+
+// Complete records that previously started work has completed
+func (backgroundWork *BackgroundWork) Complete() {
+	backgroundWork.lock.Lock()
+	defer backgroundWork.lock.Unlock()
+
+	if backgroundWork.pending == 0 {
+		log.Errorf("Background work completed which was not started")
+		return
+	}
+
+	backgroundWork.pending--
+
+	if backgroundWork.pending == 0 {
+		close(backgroundWork.idleCh)
+	}
+}
+
+// Go runs the provided function on a separate go routine as background work
+func (backgroundWork *BackgroundWork) Go(work func()) {
+	backgroundWork.Start()
+
+	go func() {
+		defer backgroundWork.Complete()
+		work()
+	}()
+}
+
+// Idle returns a channel which is closed once there is no pending work
+func (backgroundWork *BackgroundWork) Idle() <-chan bool {
+	backgroundWork.lock.Lock()
+	defer backgroundWork.lock.Unlock()
+
+	return backgroundWork.idleCh
+}
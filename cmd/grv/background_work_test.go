@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+)
+
+func isIdle(backgroundWork *BackgroundWork) bool {
+	select {
+	case <-backgroundWork.Idle():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestBackgroundWorkIsIdleOnceAllStartedWorkHasCompleted(t *testing.T) {
+	backgroundWork := NewBackgroundWork()
+
+	if !isIdle(backgroundWork) {
+		t.Fatalf("Expected no pending work initially")
+	}
+
+	backgroundWork.Start()
+	idleCh := backgroundWork.Idle()
+	backgroundWork.Start()
+	backgroundWork.Complete()
+
+	if isIdle(backgroundWork) {
+		t.Errorf("Expected work to be pending after one of two pieces of work completed")
+	}
+
+	backgroundWork.Complete()
+
+	select {
+	case <-idleCh:
+	default:
+		t.Errorf("Expected channel returned while work was pending to be closed once all work completed")
+	}
+}
+
+func TestBackgroundWorkStartedByOtherWorkDelaysIdle(t *testing.T) {
+	backgroundWork := NewBackgroundWork()
+	completedCh := make(chan bool)
+
+	backgroundWork.Go(func() {
+		backgroundWork.Go(func() {
+			completedCh <- true
+		})
+	})
+
+	idleCh := backgroundWork.Idle()
+
+	select {
+	case <-idleCh:
+		t.Fatalf("Expected work to be pending until nested work has completed")
+	case <-completedCh:
+	}
+
+	<-idleCh
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	bmCommandSeparator = ';'
+)
+
+// NewBatchGRV creates an instance of GRV which runs without a terminal.
+// Rendered views are written to the provided writer
+func NewBatchGRV(readOnly bool, writer io.Writer, viewDimension ViewDimension) *GRV {
+	grv := newGRV(readOnly, func(channels Channels, config Config) UI {
+		return NewBatchUI(writer, viewDimension)
+	})
+
+	grv.batch = true
+
+	return grv
+}
+
+// RunBatch processes each of the provided commands in turn and then renders the views.
+// After each command is processed all resulting actions, events and data loading
+// are allowed to complete before the next command is processed.
+// Any errors reported while processing the commands are returned
+func (grv *GRV) RunBatch(commands []string) (errs []error) {
+	var waitGroup sync.WaitGroup
+	channels := grv.channels
+
+	waitGroup.Add(1)
+	go grv.runHandlerLoop(&waitGroup, channels.exitCh, channels.inputKeyCh, channels.actionCh, channels.errorCh, channels.eventCh)
+
+	grv.waitForIdle()
+	errs = append(errs, grv.receivedErrors()...)
+
+	for _, command := range commands {
+		if channels.Channels().Exit() {
+			break
+		}
+
+		log.Infof("Processing batch command: %v", command)
+
+		errs = append(errs, grv.config.Evaluate(command)...)
+		grv.waitForIdle()
+		errs = append(errs, grv.receivedErrors()...)
+	}
+
+	if !channels.Channels().Exit() {
+		if err := grv.renderBatch(); err != nil {
+			errs = append(errs, err)
+		}
+
+		grv.End()
+	}
+
+	waitGroup.Wait()
+
+	return
+}
+
+// renderBatch renders the views once all data they request while rendering has loaded.
+// Some data, such as the commit graph and signature verifications, is only requested
+// when first rendered, so the views are rendered before the output is generated
+func (grv *GRV) renderBatch() (err error) {
+	viewDimension := grv.ui.ViewDimension()
+
+	if _, err = grv.view.Render(viewDimension); err != nil {
+		return
+	}
+
+	grv.waitForIdle()
+
+	wins, err := grv.view.Render(viewDimension)
+	if err != nil {
+		return
+	}
+
+	return grv.ui.Update(wins)
+}
+
+// waitForIdle blocks until all queued input, actions and events have been processed and
+// all background work they started, such as loading commits, applying filters and loading diffs,
+// has completed
+func (grv *GRV) waitForIdle() {
+	select {
+	case <-pendingBackgroundWork.Idle():
+	case <-grv.channels.exitCh:
+	}
+}
+
+func (grv *GRV) receivedErrors() (errs []error) {
+	for {
+		select {
+		case err := <-grv.channels.errorCh:
+			errs = append(errs, err)
+		default:
+			return
+		}
+	}
+}
+
+// SplitBatchCommands splits the provided string into separate commands.
+// Commands are separated by newlines or semicolons. Semicolons within
+// double quotes or preceded by a backslash do not separate commands
+func SplitBatchCommands(input string) (commands []string) {
+	var buffer bytes.Buffer
+	inQuotes := false
+	escape := false
+
+	appendCommand := func() {
+		if command := strings.TrimSpace(buffer.String()); command != "" {
+			commands = append(commands, command)
+		}
+
+		buffer.Reset()
+	}
+
+	for _, char := range input {
+		switch {
+		case escape:
+			if char != bmCommandSeparator || inQuotes {
+				buffer.WriteRune('\\')
+			}
+
+			buffer.WriteRune(char)
+			escape = false
+		case char == '\\':
+			escape = true
+		case char == '"':
+			inQuotes = !inQuotes
+			buffer.WriteRune(char)
+		case char == '\n', char == bmCommandSeparator && !inQuotes:
+			appendCommand()
+		default:
+			buffer.WriteRune(char)
+		}
+	}
+
+	if escape {
+		buffer.WriteRune('\\')
+	}
+
+	appendCommand()
+
+	return
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	gc "github.com/rgburke/goncurses"
+)
+
+func TestBatchCommandsAreSplit(t *testing.T) {
+	var tests = []struct {
+		input            string
+		expectedCommands []string
+	}{
+		{
+			input:            "",
+			expectedCommands: nil,
+		},
+		{
+			input:            "addview CommitView master; filter authorname = \"John\"; export -",
+			expectedCommands: []string{"addview CommitView master", "filter authorname = \"John\"", "export -"},
+		},
+		{
+			input:            "addtab Test\n\naddview RefView;;",
+			expectedCommands: []string{"addtab Test", "addview RefView"},
+		},
+		{
+			input:            "filter summary GLOB \"*a;b*\"; export -",
+			expectedCommands: []string{"filter summary GLOB \"*a;b*\"", "export -"},
+		},
+		{
+			input:            "!echo a\\; echo b; q",
+			expectedCommands: []string{"!echo a; echo b", "q"},
+		},
+		{
+			input:            "set prompt-history-size 100\\",
+			expectedCommands: []string{"set prompt-history-size 100\\"},
+		},
+	}
+
+	for _, test := range tests {
+		commands := SplitBatchCommands(test.input)
+
+		if !reflect.DeepEqual(test.expectedCommands, commands) {
+			t.Errorf("Split commands do not match expected commands for input %q. Expected: %q, Actual: %q", test.input, test.expectedCommands, commands)
+		}
+	}
+}
+
+func setBatchTestWindowText(win *Window, rowIndex uint, text string) {
+	for colIndex, char := range text {
+		cell := win.lines[rowIndex].cells[colIndex]
+		cell.codePoints.Reset()
+		cell.codePoints.WriteRune(char)
+	}
+}
+
+func TestBatchUIComposesWindowsAsText(t *testing.T) {
+	mainWin := NewWindow("main", nil)
+	mainWin.Resize(ViewDimension{rows: 3, cols: 8})
+	mainWin.Clear()
+	setBatchTestWindowText(mainWin, 0, "abc")
+	setBatchTestWindowText(mainWin, 2, "xyz")
+	mainWin.lines[1].cells[0].style.acsChar = gc.ACS_VLINE
+	mainWin.lines[1].cells[1].style.acsChar = gc.ACS_HLINE
+	mainWin.lines[1].cells[2].style.acsChar = gc.ACS_ULCORNER
+
+	popupWin := NewWindow("popup", nil)
+	popupWin.Resize(ViewDimension{rows: 1, cols: 3})
+	popupWin.Clear()
+	popupWin.SetPosition(2, 6)
+	setBatchTestWindowText(popupWin, 0, "123")
+
+	var output bytes.Buffer
+	batchUI := NewBatchUI(&output, ViewDimension{rows: 4, cols: 8})
+
+	if err := batchUI.Update([]*Window{mainWin, popupWin}); err != nil {
+		t.Fatalf("Update failed with error: %v", err)
+	}
+
+	expectedOutput := "abc\n|-+\nxyz   12\n\n"
+	if output.String() != expectedOutput {
+		t.Errorf("Rendered output does not match expected output. Expected: %q, Actual: %q", expectedOutput, output.String())
+	}
+}
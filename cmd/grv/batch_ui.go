@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	// BuiDefaultRows is the number of rows rendered in batch mode when none is specified
+	BuiDefaultRows = 50
+	// BuiDefaultCols is the number of columns rendered in batch mode when none is specified
+	BuiDefaultCols = 160
+)
+
+var batchUIAcsChars = map[AcsChar]string{
+	AcsUlcorner: "+",
+	AcsLlcorner: "+",
+	AcsUrcorner: "+",
+	AcsLrcorner: "+",
+	AcsLtee:     "+",
+	AcsRtee:     "+",
+	AcsBtee:     "+",
+	AcsTtee:     "+",
+	AcsPlus:     "+",
+	AcsHline:    "-",
+	AcsVline:    "|",
+	AcsS1:       "-",
+	AcsS3:       "-",
+	AcsS7:       "-",
+	AcsS9:       "_",
+	AcsDiamond:  "*",
+	AcsBullet:   "*",
+	AcsCkboard:  "#",
+	AcsBoard:    "#",
+	AcsBlock:    "#",
+	AcsLantern:  "#",
+	AcsDegree:   "'",
+	AcsPlminus:  "#",
+	AcsLarrow:   "<",
+	AcsRarrow:   ">",
+	AcsDarrow:   "v",
+	AcsUarrow:   "^",
+	AcsLequal:   "<",
+	AcsGequal:   ">",
+	AcsPi:       "*",
+	AcsNequal:   "!",
+	AcsSterling: "f",
+}
+
+// BatchUI implements the UI interface without a terminal.
+// Windows are composed into a grid of text which is written to the provided writer
+type BatchUI struct {
+	writer        io.Writer
+	viewDimension ViewDimension
+	lock          sync.Mutex
+}
+
+// NewBatchUI creates a new instance which renders windows with the provided dimensions
+func NewBatchUI(writer io.Writer, viewDimension ViewDimension) *BatchUI {
+	return &BatchUI{
+		writer:        writer,
+		viewDimension: viewDimension,
+	}
+}
+
+// Initialise does nothing as there is no terminal to set up
+func (batchUI *BatchUI) Initialise() error {
+	return nil
+}
+
+// Free does nothing as no resources are held
+func (batchUI *BatchUI) Free() {}
+
+// Resize does nothing as the dimensions of the output are fixed
+func (batchUI *BatchUI) Resize() error {
+	return nil
+}
+
+// ViewDimension returns the dimensions of the rendered output
+func (batchUI *BatchUI) ViewDimension() ViewDimension {
+	return batchUI.viewDimension
+}
+
+// Update writes the text content of the provided windows.
+// Windows are drawn in order so later windows are drawn over earlier ones
+func (batchUI *BatchUI) Update(wins []*Window) (err error) {
+	batchUI.lock.Lock()
+	defer batchUI.lock.Unlock()
+
+	rows := batchUI.viewDimension.rows
+	cols := batchUI.viewDimension.cols
+
+	screen := make([][]string, rows)
+	for rowIndex := range screen {
+		screen[rowIndex] = make([]string, cols)
+		for colIndex := range screen[rowIndex] {
+			screen[rowIndex][colIndex] = " "
+		}
+	}
+
+	for _, win := range wins {
+		startRow, startCol := win.Position()
+
+		for lineIndex, line := range win.lines {
+			row := startRow + uint(lineIndex)
+			if row >= rows {
+				break
+			}
+
+			for cellIndex, cell := range line.cells {
+				col := startCol + uint(cellIndex)
+				if col >= cols {
+					break
+				}
+
+				screen[row][col] = batchUICellText(cell)
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	for _, row := range screen {
+		buffer.WriteString(strings.TrimRight(strings.Join(row, ""), " "))
+		buffer.WriteString("\n")
+	}
+
+	_, err = batchUI.writer.Write(buffer.Bytes())
+
+	return
+}
+
+func batchUICellText(cell *cell) string {
+	if cell.style.acsChar != 0 {
+		if text, ok := batchUIAcsChars[AcsChar(cell.style.acsChar)]; ok {
+			return text
+		}
+
+		return "?"
+	}
+
+	return cell.codePoints.String()
+}
+
+// Suspend does nothing as there is no terminal to restore
+func (batchUI *BatchUI) Suspend() {}
+
+// Resume does nothing as there is no terminal to restore
+func (batchUI *BatchUI) Resume() error {
+	return nil
+}
+
+// GetInput never returns any input
+func (batchUI *BatchUI) GetInput(force bool) (Key, error) {
+	return UINoKey, nil
+}
+
+// CancelGetInput does nothing as GetInput never blocks
+func (batchUI *BatchUI) CancelGetInput() error {
+	return nil
+}
+
+// GetMouseEvent never returns any mouse events
+func (batchUI *BatchUI) GetMouseEvent() (mouseEvent MouseEvent, exists bool) {
+	return
+}
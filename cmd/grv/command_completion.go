@@ -0,0 +1,285 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	slice "github.com/bradfitz/slice"
+)
+
+const ccVariableReferencePrefix = "${"
+
+// Views which can be created using the addview and split commands
+var creatableViewNames = []string{
+	cfCommitView,
+	cfDiffView,
+	cfGitStatusView,
+	cfGRVVariableView,
+	cfRefView,
+	cfRemoteView,
+}
+
+// CommandCompleter generates completions for partially entered commands
+type CommandCompleter interface {
+	Refresh()
+	Complete(input string) []string
+}
+
+// Config variable validators which accept a fixed set of values implement this interface
+// to allow those values to be completed
+type configVariableValueLister interface {
+	validValues() []string
+}
+
+// configCompletionState is a copy of the configuration values which are completed.
+// Completions are generated on the readline thread, so the configuration itself is not accessed
+type configCompletionState struct {
+	configVariableValues map[string][]string
+	themeNames           []string
+	keymapNames          []string
+	customCommandNames   []string
+	userVariableNames    []string
+}
+
+// ConfigCommandCompleter completes commands using the configuration state captured
+// by the last refresh and the current repository state
+type ConfigCommandCompleter struct {
+	config          *Configuration
+	repoData        RepoData
+	completionState *configCompletionState
+	lock            sync.Mutex
+}
+
+// NewConfigCommandCompleter creates a new instance
+func NewConfigCommandCompleter(config *Configuration, repoData RepoData) *ConfigCommandCompleter {
+	return &ConfigCommandCompleter{
+		config:          config,
+		repoData:        repoData,
+		completionState: &configCompletionState{},
+	}
+}
+
+// Refresh captures the configuration values which are completed.
+// This should be called on the go routine the configuration is modified on
+func (configCommandCompleter *ConfigCommandCompleter) Refresh() {
+	config := configCommandCompleter.config
+
+	completionState := &configCompletionState{
+		configVariableValues: map[string][]string{},
+	}
+
+	for configVariable, variable := range config.configVariables {
+		var values []string
+		if valueLister, ok := variable.validator.(configVariableValueLister); ok {
+			values = valueLister.validValues()
+		}
+
+		completionState.configVariableValues[string(configVariable)] = values
+	}
+
+	for themeName := range config.themes {
+		completionState.themeNames = append(completionState.themeNames, themeName)
+	}
+
+	for keymapName := range config.keymaps {
+		completionState.keymapNames = append(completionState.keymapNames, keymapName)
+	}
+
+	for commandName := range config.customCommands {
+		completionState.customCommandNames = append(completionState.customCommandNames, commandName)
+	}
+
+	for variableName := range config.userVariables {
+		completionState.userVariableNames = append(completionState.userVariableNames, variableName)
+	}
+
+	configCommandCompleter.lock.Lock()
+	defer configCommandCompleter.lock.Unlock()
+
+	configCommandCompleter.completionState = completionState
+}
+
+// Complete returns all completions for the last word of the provided input.
+// Each completion replaces the entire last word
+func (configCommandCompleter *ConfigCommandCompleter) Complete(input string) (completions []string) {
+	configCommandCompleter.lock.Lock()
+	completionState := configCommandCompleter.completionState
+	configCommandCompleter.lock.Unlock()
+
+	words := strings.Fields(input)
+	word := ""
+
+	if len(words) > 0 && !unicode.IsSpace(rune(input[len(input)-1])) {
+		word = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	var candidates []string
+	prefix := ""
+
+	if referenceIndex := strings.LastIndex(word, ccVariableReferencePrefix); referenceIndex != -1 &&
+		!strings.Contains(word[referenceIndex:], "}") {
+		prefix = word[:referenceIndex+len(ccVariableReferencePrefix)]
+		word = word[referenceIndex+len(ccVariableReferencePrefix):]
+
+		for _, variableName := range completionState.variableNames() {
+			candidates = append(candidates, variableName+"}")
+		}
+	} else if len(words) == 0 {
+		candidates = commandNames()
+	} else {
+		candidates = configCommandCompleter.argumentCandidates(completionState, words[0], words[1:])
+	}
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			completions = append(completions, prefix+candidate)
+		}
+	}
+
+	return sortUnique(completions)
+}
+
+func (configCommandCompleter *ConfigCommandCompleter) argumentCandidates(completionState *configCompletionState, command string, args []string) (candidates []string) {
+	argIndex := len(args)
+	previousArg := ""
+	if argIndex > 0 {
+		previousArg = args[argIndex-1]
+	}
+
+	switch command {
+	case setCommand:
+		if argIndex == 0 {
+			candidates = completionState.configVariableNames()
+		} else if argIndex == 1 {
+			candidates = completionState.configVariableValues[args[0]]
+		}
+	case themeCommand:
+		switch previousArg {
+		case "--name":
+			candidates = completionState.themeNames
+		case "--component":
+			candidates = ThemeComponentNames()
+		}
+	case exportthemeCommand:
+		if argIndex == 0 {
+			candidates = completionState.themeNames
+		}
+	case keymapCommand:
+		switch previousArg {
+		case "--base":
+			candidates = completionState.keymapNames
+		case "--view":
+			candidates = allViewNames()
+		case "--to":
+			candidates = actionNames()
+		}
+	case mapCommand:
+		if argIndex == 0 {
+			candidates = allViewNames()
+		} else if argIndex == 2 {
+			candidates = actionNames()
+		}
+	case unmapCommand, macromapCommand:
+		if argIndex == 0 {
+			candidates = allViewNames()
+		}
+	case addviewCommand, splitCommand, vsplitCommand, hsplitCommand:
+		if argIndex == 0 {
+			candidates = creatableViewNames
+		} else if argIndex == 1 && (args[0] == cfCommitView || args[0] == cfDiffView) {
+			candidates = configCommandCompleter.refNames()
+		}
+	case gitCommand, gitInteractiveCommand:
+		if argIndex > 0 {
+			candidates = configCommandCompleter.refNames()
+		}
+	case compareCommand:
+		if argIndex < 2 {
+			candidates = configCommandCompleter.refNames()
+		}
+	case helpCommand:
+		if argIndex == 0 {
+			candidates = commandNames()
+		}
+	case undefCommand:
+		if argIndex == 0 {
+			candidates = completionState.customCommandNames
+		}
+	}
+
+	return
+}
+
+func commandNames() (names []string) {
+	for commandName := range commandDescriptors {
+		names = append(names, commandName)
+	}
+
+	return
+}
+
+func allViewNames() (names []string) {
+	for viewName := range viewIDNames {
+		names = append(names, viewName)
+	}
+
+	return
+}
+
+func actionNames() (names []string) {
+	for actionKey := range actionKeys {
+		names = append(names, actionKey)
+	}
+
+	return
+}
+
+func (completionState *configCompletionState) configVariableNames() (names []string) {
+	for configVariable := range completionState.configVariableValues {
+		names = append(names, configVariable)
+	}
+
+	return
+}
+
+func (completionState *configCompletionState) variableNames() (names []string) {
+	for _, variableDescriptor := range variableDescriptors {
+		names = append(names, variableDescriptor.name)
+	}
+
+	return append(names, completionState.userVariableNames...)
+}
+
+func (configCommandCompleter *ConfigCommandCompleter) refNames() (names []string) {
+	localBranches, remoteBranches, _ := configCommandCompleter.repoData.Branches()
+
+	for _, branches := range [][]Branch{localBranches, remoteBranches} {
+		for _, branch := range branches {
+			names = append(names, branch.Shorthand())
+		}
+	}
+
+	tags, _ := configCommandCompleter.repoData.Tags()
+
+	for _, tag := range tags {
+		names = append(names, tag.Shorthand())
+	}
+
+	return
+}
+
+func sortUnique(values []string) (uniqueValues []string) {
+	slice.Sort(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+
+	for index, value := range values {
+		if index == 0 || value != values[index-1] {
+			uniqueValues = append(uniqueValues, value)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+type completionRepoData struct {
+	RepoData
+	localBranches  []Branch
+	remoteBranches []Branch
+	tags           []*Tag
+}
+
+func (completionRepoData *completionRepoData) Branches() (localBranches, remoteBranches []Branch, loading bool) {
+	return completionRepoData.localBranches, completionRepoData.remoteBranches, false
+}
+
+func (completionRepoData *completionRepoData) Tags() (tags []*Tag, loading bool) {
+	return completionRepoData.tags, false
+}
+
+func newTestConfigCommandCompleter() *ConfigCommandCompleter {
+	config := NewConfiguration(&MockKeyBindings{}, &MockChannels{}, &MockGRVVariableSetter{}, &MockInputConsumer{})
+	config.userVariables["homedir"] = "/home/grv"
+
+	repoData := &completionRepoData{
+		localBranches: []Branch{
+			&LocalBranch{abstractBranch: &abstractBranch{shorthand: "master"}},
+			&LocalBranch{abstractBranch: &abstractBranch{shorthand: "feature"}},
+		},
+		remoteBranches: []Branch{
+			&RemoteBranch{abstractBranch: &abstractBranch{shorthand: "origin/master"}},
+		},
+		tags: []*Tag{
+			{shorthand: "v1.0"},
+		},
+	}
+
+	completer := NewConfigCommandCompleter(config, repoData)
+	completer.Refresh()
+
+	return completer
+}
+
+func TestInputIsCompletedBasedOnContext(t *testing.T) {
+	completer := newTestConfigCommandCompleter()
+
+	completionTests := []struct {
+		input               string
+		expectedCompletions []string
+	}{
+		{
+			input:               "s",
+			expectedCompletions: []string{"set", "sleep", "source", "split"},
+		},
+		{
+			input:               "set mouse",
+			expectedCompletions: []string{"mouse", "mouse-scroll-rows"},
+		},
+		{
+			input:               "set theme ",
+			expectedCompletions: []string{"classic", "high-contrast", "monochrome", "solarized", "solarized-light"},
+		},
+		{
+			input:               "set mouse f",
+			expectedCompletions: []string{"false"},
+		},
+		{
+			input:               "set diff-display ",
+			expectedCompletions: []string{"fancy", "git"},
+		},
+		{
+			input:               "addview Re",
+			expectedCompletions: []string{"RefView", "RemoteView"},
+		},
+		{
+			input:               "vsplit CommitView ",
+			expectedCompletions: []string{"feature", "master", "origin/master", "v1.0"},
+		},
+		{
+			input:               "git log m",
+			expectedCompletions: []string{"master"},
+		},
+		{
+			input:               "compare master o",
+			expectedCompletions: []string{"origin/master"},
+		},
+		{
+			input:               "map Dif",
+			expectedCompletions: []string{"DiffView"},
+		},
+		{
+			input:               "map All <C-x> <grv-next-l",
+			expectedCompletions: []string{"<grv-next-line>"},
+		},
+		{
+			input:               "keymap --name mykeys --base v",
+			expectedCompletions: []string{"vi"},
+		},
+		{
+			input:               "git log ${repo-",
+			expectedCompletions: []string{"${repo-path}", "${repo-workdir}"},
+		},
+		{
+			input:               "source ${home",
+			expectedCompletions: []string{"${homedir}"},
+		},
+		{
+			input:               "addtab ",
+			expectedCompletions: nil,
+		},
+	}
+
+	for _, completionTest := range completionTests {
+		completions := completer.Complete(completionTest.input)
+
+		if !reflect.DeepEqual(completionTest.expectedCompletions, completions) {
+			t.Errorf("Completions do not match expected values for input %q. Expected: %v, Actual: %v",
+				completionTest.input, completionTest.expectedCompletions, completions)
+		}
+	}
+}
+
+func TestCompletionsOnlyReflectConfigurationChangesAfterRefresh(t *testing.T) {
+	completer := newTestConfigCommandCompleter()
+	completer.config.userVariables["homepage"] = "/home/grv/www"
+
+	if completions := completer.Complete("source ${home"); !reflect.DeepEqual([]string{"${homedir}"}, completions) {
+		t.Errorf("Completions do not match expected values before refresh. Expected: [${homedir}], Actual: %v", completions)
+	}
+
+	completer.Refresh()
+
+	if completions := completer.Complete("source ${home"); !reflect.DeepEqual([]string{"${homedir}", "${homepage}"}, completions) {
+		t.Errorf("Completions do not match expected values after refresh. Expected: [${homedir} ${homepage}], Actual: %v", completions)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// commitGraphQuerier answers reachability questions about the commit graph
+type commitGraphQuerier interface {
+	MergeBase(oid1, oid2 *Oid) (*Oid, error)
+	AheadBehind(local, upstream *Oid) (ahead, behind int, err error)
+}
+
+// CommitContainment lists the refs which contain a commit
+// along with the nearest tags before and after the commit
+type CommitContainment struct {
+	commit         *Commit
+	localBranches  []Branch
+	remoteBranches []Branch
+	tags           []*Tag
+	describe       string
+	firstTag       *Tag
+}
+
+// LocalBranches returns the local branches which contain the commit
+func (commitContainment *CommitContainment) LocalBranches() []Branch {
+	return commitContainment.localBranches
+}
+
+// RemoteBranches returns the remote branches which contain the commit
+func (commitContainment *CommitContainment) RemoteBranches() []Branch {
+	return commitContainment.remoteBranches
+}
+
+// Tags returns the tags which contain the commit
+func (commitContainment *CommitContainment) Tags() []*Tag {
+	return commitContainment.tags
+}
+
+// Describe returns the commit described relative to the nearest preceding tag
+// in the format used by git describe --tags. An empty string is returned if no tag precedes the commit
+func (commitContainment *CommitContainment) Describe() string {
+	return commitContainment.describe
+}
+
+// FirstTag returns the containing tag which is the fewest commits after the commit (if any)
+func (commitContainment *CommitContainment) FirstTag() *Tag {
+	return commitContainment.firstTag
+}
+
+// newCommitContainment determines which of the provided refs contain the commit.
+// Nil is returned if cancelCh is closed before all refs have been checked
+func newCommitContainment(graph commitGraphQuerier, commit *Commit, localBranches, remoteBranches []Branch, tags []*Tag, cancelCh <-chan bool) *CommitContainment {
+	commitContainment := &CommitContainment{
+		commit: commit,
+	}
+
+	for _, localBranch := range localBranches {
+		if isCancelled(cancelCh) {
+			return nil
+		}
+
+		if isAncestor(graph, commit.oid, localBranch.Oid()) {
+			commitContainment.localBranches = append(commitContainment.localBranches, localBranch)
+		}
+	}
+
+	for _, remoteBranch := range remoteBranches {
+		if isCancelled(cancelCh) {
+			return nil
+		}
+
+		if isAncestor(graph, commit.oid, remoteBranch.Oid()) {
+			commitContainment.remoteBranches = append(commitContainment.remoteBranches, remoteBranch)
+		}
+	}
+
+	firstTagDistance := -1
+	describeTagDistance := -1
+	var describeTag *Tag
+
+	for _, tag := range tags {
+		if isCancelled(cancelCh) {
+			return nil
+		}
+
+		target := tag.TargetOid()
+
+		if isAncestor(graph, commit.oid, target) {
+			commitContainment.tags = append(commitContainment.tags, tag)
+
+			if canBeNearerTag(target, commitContainment.firstTag, firstTagDistance) {
+				if distance, ok := commitDistance(graph, target, commit.oid); ok && (firstTagDistance == -1 || distance < firstTagDistance) {
+					commitContainment.firstTag = tag
+					firstTagDistance = distance
+				}
+			}
+
+			// A tag after the commit can only precede it if it points to the commit
+			if !target.Equal(commit.oid) {
+				continue
+			}
+		}
+
+		if canBeNearerTag(target, describeTag, describeTagDistance) && isAncestor(graph, target, commit.oid) {
+			if distance, ok := commitDistance(graph, commit.oid, target); ok && (describeTagDistance == -1 || distance < describeTagDistance) {
+				describeTag = tag
+				describeTagDistance = distance
+			}
+		}
+	}
+
+	if describeTag != nil {
+		if describeTagDistance == 0 {
+			commitContainment.describe = describeTag.Shorthand()
+		} else {
+			commitContainment.describe = fmt.Sprintf("%v-%v-g%v", describeTag.Shorthand(), describeTagDistance, commit.oid.ShortID())
+		}
+	}
+
+	return commitContainment
+}
+
+// isCancelled returns true if cancelCh has been closed.
+// A nil channel is never cancelled
+func isCancelled(cancelCh <-chan bool) bool {
+	select {
+	case <-cancelCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// canBeNearerTag returns false if a tag pointing to target cannot be fewer commits from the commit than
+// the nearest tag found so far, in which case the distance to target does not need to be calculated
+func canBeNearerTag(target *Oid, nearestTag *Tag, nearestTagDistance int) bool {
+	switch {
+	case nearestTag == nil:
+		return true
+	case nearestTagDistance == 0:
+		return false
+	default:
+		return !target.Equal(nearestTag.TargetOid())
+	}
+}
+
+// isAncestor returns true if ancestor is reachable from descendant.
+// A commit is considered to be an ancestor of itself
+func isAncestor(graph commitGraphQuerier, ancestor, descendant *Oid) bool {
+	if ancestor.Equal(descendant) {
+		return true
+	}
+
+	mergeBase, err := graph.MergeBase(ancestor, descendant)
+	if err != nil {
+		log.Debugf("Unable to determine if %v is an ancestor of %v: %v", ancestor, descendant, err)
+		return false
+	}
+
+	return mergeBase.Equal(ancestor)
+}
+
+// commitDistance returns the number of commits reachable from descendant but not ancestor
+func commitDistance(graph commitGraphQuerier, descendant, ancestor *Oid) (distance int, ok bool) {
+	if descendant.Equal(ancestor) {
+		return 0, true
+	}
+
+	distance, _, err := graph.AheadBehind(descendant, ancestor)
+	if err != nil {
+		log.Debugf("Unable to determine number of commits between %v and %v: %v", ancestor, descendant, err)
+		return
+	}
+
+	return distance, true
+}
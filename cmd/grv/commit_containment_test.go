@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	git "gopkg.in/libgit2/git2go.v27"
+)
+
+type testCommitGraph struct {
+	parents          map[string]string
+	aheadBehindCalls int
+}
+
+func (testCommitGraph *testCommitGraph) ancestors(oid *Oid) (ancestors map[string]bool) {
+	ancestors = map[string]bool{}
+
+	for id, exists := oid.String(), true; exists; id, exists = testCommitGraph.parents[id] {
+		ancestors[id] = true
+	}
+
+	return
+}
+
+func (testCommitGraph *testCommitGraph) MergeBase(oid1, oid2 *Oid) (mergeBase *Oid, err error) {
+	ancestors1 := testCommitGraph.ancestors(oid1)
+	ancestors2 := testCommitGraph.ancestors(oid2)
+	maxDepth := 0
+
+	for id := range ancestors1 {
+		if depth := len(testCommitGraph.ancestors(testOid(id))); ancestors2[id] && depth > maxDepth {
+			mergeBase = testOid(id)
+			maxDepth = depth
+		}
+	}
+
+	if mergeBase == nil {
+		err = fmt.Errorf("No merge base found for %v and %v", oid1, oid2)
+	}
+
+	return
+}
+
+func (testCommitGraph *testCommitGraph) AheadBehind(local, upstream *Oid) (ahead, behind int, err error) {
+	testCommitGraph.aheadBehindCalls++
+	localAncestors := testCommitGraph.ancestors(local)
+	upstreamAncestors := testCommitGraph.ancestors(upstream)
+
+	for id := range localAncestors {
+		if !upstreamAncestors[id] {
+			ahead++
+		}
+	}
+
+	for id := range upstreamAncestors {
+		if !localAncestors[id] {
+			behind++
+		}
+	}
+
+	return
+}
+
+func testOid(id string) *Oid {
+	rawOid, err := git.NewOid(id)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Oid{oid: rawOid}
+}
+
+const (
+	testC1       = "1111111111111111111111111111111111111111"
+	testC2       = "2222222222222222222222222222222222222222"
+	testC3       = "3333333333333333333333333333333333333333"
+	testC4       = "4444444444444444444444444444444444444444"
+	testS1       = "5555555555555555555555555555555555555555"
+	testTagV2Obj = "6666666666666666666666666666666666666666"
+	testOther    = "7777777777777777777777777777777777777777"
+)
+
+type commitContainmentFixture struct {
+	graph          *testCommitGraph
+	localBranches  []Branch
+	remoteBranches []Branch
+	tags           []*Tag
+}
+
+// newCommitContainmentFixture creates the history c1 <- c2 <- c3 <- c4 with s1 branching from c2
+// and an unrelated commit
+func newCommitContainmentFixture() *commitContainmentFixture {
+	newBranch := func(id, shorthand string) *abstractBranch {
+		return &abstractBranch{oid: testOid(id), name: "refs/heads/" + shorthand, shorthand: shorthand}
+	}
+
+	return &commitContainmentFixture{
+		graph: &testCommitGraph{
+			parents: map[string]string{
+				testC2: testC1,
+				testC3: testC2,
+				testC4: testC3,
+				testS1: testC2,
+			},
+		},
+		localBranches: []Branch{
+			&LocalBranch{abstractBranch: newBranch(testC4, "master")},
+			&LocalBranch{abstractBranch: newBranch(testS1, "feature")},
+			&LocalBranch{abstractBranch: newBranch(testC1, "old")},
+			&LocalBranch{abstractBranch: newBranch(testOther, "orphan")},
+		},
+		remoteBranches: []Branch{
+			&RemoteBranch{abstractBranch: newBranch(testC3, "origin/master"), remoteName: "origin"},
+		},
+		tags: []*Tag{
+			{oid: testOid(testC1), name: "refs/tags/v1.0", shorthand: "v1.0"},
+			{oid: testOid(testTagV2Obj), name: "refs/tags/v2.0", shorthand: "v2.0", annotation: &TagAnnotation{target: testOid(testC3)}},
+			{oid: testOid(testC4), name: "refs/tags/v3.0", shorthand: "v3.0"},
+		},
+	}
+}
+
+func branchShorthands(branches []Branch) (shorthands []string) {
+	for _, branch := range branches {
+		shorthands = append(shorthands, branch.Shorthand())
+	}
+
+	return
+}
+
+func tagShorthands(tags []*Tag) (shorthands []string) {
+	for _, tag := range tags {
+		shorthands = append(shorthands, tag.Shorthand())
+	}
+
+	return
+}
+
+func TestRefsContainingCommitAreReported(t *testing.T) {
+	fixture := newCommitContainmentFixture()
+	commit := &Commit{oid: testOid(testC2)}
+
+	commitContainment := newCommitContainment(fixture.graph, commit, fixture.localBranches, fixture.remoteBranches, fixture.tags, nil)
+
+	if localBranches, expected := branchShorthands(commitContainment.LocalBranches()), []string{"master", "feature"}; !reflect.DeepEqual(localBranches, expected) {
+		t.Errorf("Local branches do not match expected value. Expected: %v, Actual: %v", expected, localBranches)
+	}
+
+	if remoteBranches, expected := branchShorthands(commitContainment.RemoteBranches()), []string{"origin/master"}; !reflect.DeepEqual(remoteBranches, expected) {
+		t.Errorf("Remote branches do not match expected value. Expected: %v, Actual: %v", expected, remoteBranches)
+	}
+
+	if tags, expected := tagShorthands(commitContainment.Tags()), []string{"v2.0", "v3.0"}; !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Tags do not match expected value. Expected: %v, Actual: %v", expected, tags)
+	}
+
+	if firstTag := commitContainment.FirstTag(); firstTag == nil || firstTag.Shorthand() != "v2.0" {
+		t.Errorf("Expected first tag to be v2.0 but found %v", firstTag)
+	}
+
+	if describe, expected := commitContainment.Describe(), "v1.0-1-g2222222"; describe != expected {
+		t.Errorf("Describe does not match expected value. Expected: %v, Actual: %v", expected, describe)
+	}
+}
+
+func TestTaggedCommitIsDescribedByTagName(t *testing.T) {
+	fixture := newCommitContainmentFixture()
+	commit := &Commit{oid: testOid(testC3)}
+
+	commitContainment := newCommitContainment(fixture.graph, commit, fixture.localBranches, fixture.remoteBranches, fixture.tags, nil)
+
+	if describe, expected := commitContainment.Describe(), "v2.0"; describe != expected {
+		t.Errorf("Describe does not match expected value. Expected: %v, Actual: %v", expected, describe)
+	}
+
+	if firstTag := commitContainment.FirstTag(); firstTag == nil || firstTag.Shorthand() != "v2.0" {
+		t.Errorf("Expected first tag to be v2.0 but found %v", firstTag)
+	}
+}
+
+func TestCommitWithoutTagsHasNoDescription(t *testing.T) {
+	fixture := newCommitContainmentFixture()
+	commit := &Commit{oid: testOid(testOther)}
+
+	commitContainment := newCommitContainment(fixture.graph, commit, fixture.localBranches, fixture.remoteBranches, fixture.tags, nil)
+
+	if localBranches, expected := branchShorthands(commitContainment.LocalBranches()), []string{"orphan"}; !reflect.DeepEqual(localBranches, expected) {
+		t.Errorf("Local branches do not match expected value. Expected: %v, Actual: %v", expected, localBranches)
+	}
+
+	if len(commitContainment.Tags()) > 0 || commitContainment.FirstTag() != nil {
+		t.Errorf("Expected no containing tags but found %v", tagShorthands(commitContainment.Tags()))
+	}
+
+	if describe := commitContainment.Describe(); describe != "" {
+		t.Errorf("Expected no description but found %v", describe)
+	}
+}
+
+func TestDistanceIsNotCalculatedForTagsWhichCannotBeNearer(t *testing.T) {
+	fixture := newCommitContainmentFixture()
+	commit := &Commit{oid: testOid(testC3)}
+
+	commitContainment := newCommitContainment(fixture.graph, commit, fixture.localBranches, fixture.remoteBranches, fixture.tags, nil)
+
+	if firstTag := commitContainment.FirstTag(); firstTag == nil || firstTag.Shorthand() != "v2.0" {
+		t.Errorf("Expected first tag to be v2.0 but found %v", firstTag)
+	}
+
+	if fixture.graph.aheadBehindCalls != 1 {
+		t.Errorf("Expected distance to only be calculated for tag v1.0 but AheadBehind was called %v times", fixture.graph.aheadBehindCalls)
+	}
+}
+
+func TestCancelledCommitContainmentReturnsNil(t *testing.T) {
+	fixture := newCommitContainmentFixture()
+	commit := &Commit{oid: testOid(testC2)}
+
+	cancelCh := make(chan bool)
+	close(cancelCh)
+
+	if commitContainment := newCommitContainment(fixture.graph, commit, fixture.localBranches, fixture.remoteBranches, fixture.tags, cancelCh); commitContainment != nil {
+		t.Errorf("Expected nil commit containment when cancelled but found %v", commitContainment)
+	}
+}
@@ -3,12 +3,14 @@ package main
 import (
 	"strings"
 
+	log "github.com/Sirupsen/logrus"
 	slice "github.com/bradfitz/slice"
 )
 
-// CreateCommitFilter constructs a commit filter from the provided query
-func CreateCommitFilter(query string) (commitFilter *CommitFilter, errors []error) {
-	filter, errors := CreateFilter(query, &CommitFieldDescriptor{})
+// CreateCommitFilter constructs a commit filter from the provided query.
+// Commit message lint fields are evaluated using the commit message rules configured when the filter is created
+func CreateCommitFilter(query string, repoData RepoData, config Config) (commitFilter *CommitFilter, errors []error) {
+	filter, errors := CreateFilter(query, NewCommitFieldDescriptor(repoData, config))
 	if len(errors) > 0 || filter == nil {
 		return
 	}
@@ -36,7 +38,19 @@ func (commitFilter *CommitFilter) MatchesFilter(commit *Commit) bool {
 }
 
 // CommitFieldDescriptor exposes functions describing commit field properties
-type CommitFieldDescriptor struct{}
+type CommitFieldDescriptor struct {
+	repoData            RepoData
+	commitMessageLinter *CommitMessageLinter
+}
+
+// NewCommitFieldDescriptor creates a new instance which lints commit messages using the configured commit message rules
+// and verifies signatures using the provided repo data
+func NewCommitFieldDescriptor(repoData RepoData, config Config) *CommitFieldDescriptor {
+	return &CommitFieldDescriptor{
+		repoData:            repoData,
+		commitMessageLinter: NewCommitMessageLinter(config),
+	}
+}
 
 // FieldType returns the type of the provided field (if it exists)
 func (commitFieldDescriptor *CommitFieldDescriptor) FieldType(fieldName string) (fieldType FieldType, fieldExists bool) {
@@ -53,79 +67,124 @@ func (commitFieldDescriptor *CommitFieldDescriptor) FieldValue(inputValue interf
 	commit := inputValue.(*Commit)
 	commitField := commitFields[strings.ToLower(fieldName)]
 
-	return commitField.value(commit)
+	return commitField.value(commit, commitFieldDescriptor)
 }
 
 // CommitFieldValue accepts a commit and returns a field value of that commit
-type CommitFieldValue func(*Commit) interface{}
+type CommitFieldValue func(*Commit, *CommitFieldDescriptor) interface{}
 
-// CommitField provides data for a commit field
+// CommitField provides data for a commit field.
+// Expensive fields run external processes or analysis for each commit and are not exported
 type CommitField struct {
 	fieldType FieldType
 	value     CommitFieldValue
+	expensive bool
 }
 
 var commitFields = map[string]CommitField{
 	"authorname": {
 		fieldType: FtString,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Author().Name
 		},
 	},
 	"authoremail": {
 		fieldType: FtString,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Author().Email
 		},
 	},
 	"authordate": {
 		fieldType: FtDate,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Author().When
 		},
 	},
 	"committername": {
 		fieldType: FtString,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Committer().Name
 		},
 	},
 	"committeremail": {
 		fieldType: FtString,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Committer().Email
 		},
 	},
 	"committerdate": {
 		fieldType: FtDate,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Committer().When
 		},
 	},
 	"id": {
 		fieldType: FtString,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Id().String()
 		},
 	},
 	"summary": {
 		fieldType: FtString,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Summary()
 		},
 	},
 	"message": {
 		fieldType: FtString,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return commit.commit.Message()
 		},
 	},
 	"parentcount": {
 		fieldType: FtNumber,
-		value: func(commit *Commit) interface{} {
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
 			return float64(commit.commit.ParentCount())
 		},
 	},
+	"lintviolations": {
+		fieldType: FtNumber,
+		expensive: true,
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
+			if commitFieldDescriptor.commitMessageLinter == nil {
+				return float64(0)
+			}
+
+			return float64(len(commitFieldDescriptor.commitMessageLinter.Lint(commit.commit.Message())))
+		},
+	},
+	"notes": {
+		fieldType: FtString,
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
+			if commitFieldDescriptor.repoData == nil {
+				return ""
+			}
+
+			notes, err := commitFieldDescriptor.repoData.Notes(commit.oid)
+			if err != nil {
+				log.Errorf("Unable to load notes for commit %v: %v", commit.oid, err)
+				return ""
+			}
+
+			var messages []string
+			for _, note := range notes {
+				messages = append(messages, note.message)
+			}
+
+			return strings.Join(messages, "\n")
+		},
+	},
+	"signature": {
+		fieldType: FtString,
+		expensive: true,
+		value: func(commit *Commit, commitFieldDescriptor *CommitFieldDescriptor) interface{} {
+			if commitFieldDescriptor.repoData == nil {
+				return SignatureUnknown.String()
+			}
+
+			return commitFieldDescriptor.repoData.VerifySignature(commit.oid).Status().String()
+		},
+	},
 }
 
 // GenerateCommitFieldHelpSection generates documentation for the commit fields available
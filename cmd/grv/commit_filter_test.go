@@ -211,7 +211,8 @@ func TestCommitFieldValuesAreExtracted(t *testing.T) {
 
 func TestNilCommitFilterIsReturnedIfQueryDoesNotDefineFilter(t *testing.T) {
 	query := " \t\v\r\n"
-	commitFilter, errors := CreateCommitFilter(query)
+	config := NewConfiguration(&MockKeyBindings{}, &MockChannels{}, &MockGRVVariableSetter{}, &MockInputConsumer{})
+	commitFilter, errors := CreateCommitFilter(query, nil, config)
 
 	if len(errors) > 0 {
 		t.Errorf("CreateCommitFilter failed with errors %v", errors)
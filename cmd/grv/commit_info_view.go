@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	civLabelWidth = 11
+)
+
+type commitInfoLine struct {
+	label          string
+	value          string
+	valueComponent ThemeComponentID
+}
+
+// CommitInfoView displays the branches and tags which contain a commit
+// along with the nearest tags before and after the commit
+type CommitInfoView struct {
+	*AbstractWindowView
+	repoData          RepoData
+	commit            *Commit
+	commitInfoLines   []commitInfoLine
+	loading           bool
+	cancelCh          chan bool
+	activeViewPos     ViewPos
+	lastViewDimension ViewDimension
+	lock              sync.Mutex
+}
+
+// NewCommitInfoView creates a new instance
+func NewCommitInfoView(repoData RepoData, commit *Commit, channels Channels, config Config, variables GRVVariableSetter) *CommitInfoView {
+	commitInfoView := &CommitInfoView{
+		repoData:      repoData,
+		commit:        commit,
+		cancelCh:      make(chan bool),
+		activeViewPos: NewViewPosition(),
+	}
+
+	commitInfoView.AbstractWindowView = NewAbstractWindowView(commitInfoView, channels, config, variables, &commitInfoView.lock, "line")
+
+	return commitInfoView
+}
+
+// Initialise starts determining which refs contain the commit in the background
+func (commitInfoView *CommitInfoView) Initialise() (err error) {
+	commitInfoView.lock.Lock()
+	defer commitInfoView.lock.Unlock()
+
+	commitInfoView.loading = true
+	commitInfoView.commitInfoLines = []commitInfoLine{
+		commitInfoView.commitLine(),
+		{},
+		{value: "Determining branches and tags containing commit...", valueComponent: CmpCommitInfoViewValue},
+	}
+
+	pendingBackgroundWork.Start()
+	go commitInfoView.loadCommitContainment(commitInfoView.cancelCh)
+
+	return
+}
+
+// Dispose stops determining which refs contain the commit if this is still in progress
+func (commitInfoView *CommitInfoView) Dispose() {
+	commitInfoView.lock.Lock()
+	defer commitInfoView.lock.Unlock()
+
+	close(commitInfoView.cancelCh)
+}
+
+func (commitInfoView *CommitInfoView) loadCommitContainment(cancelCh <-chan bool) {
+	defer pendingBackgroundWork.Complete()
+
+	commitContainment := commitInfoView.repoData.CommitContainment(commitInfoView.commit, cancelCh)
+	if commitContainment == nil {
+		log.Debugf("Determining refs containing commit %v was cancelled", commitInfoView.commit.oid.ShortID())
+		return
+	}
+
+	commitInfoView.lock.Lock()
+	commitInfoView.loading = false
+	commitInfoView.commitInfoLines = commitInfoView.generateCommitInfoLines(commitContainment)
+	log.Debugf("Commit %v is contained in %v local branches, %v remote branches and %v tags", commitInfoView.commit.oid.ShortID(),
+		len(commitContainment.LocalBranches()), len(commitContainment.RemoteBranches()), len(commitContainment.Tags()))
+	commitInfoView.lock.Unlock()
+
+	commitInfoView.channels.UpdateDisplay()
+}
+
+func (commitInfoView *CommitInfoView) commitLine() commitInfoLine {
+	return commitInfoLine{
+		label:          "Commit",
+		value:          fmt.Sprintf("%v %v", commitInfoView.commit.oid.ShortID(), commitInfoView.commit.commit.Summary()),
+		valueComponent: CmpCommitInfoViewValue,
+	}
+}
+
+func (commitInfoView *CommitInfoView) generateCommitInfoLines(commitContainment *CommitContainment) (commitInfoLines []commitInfoLine) {
+	describe := commitContainment.Describe()
+	if describe == "" {
+		describe = "None"
+	}
+
+	firstTag := "None"
+	if tag := commitContainment.FirstTag(); tag != nil {
+		firstTag = tag.Shorthand()
+	}
+
+	commitInfoLines = append(commitInfoLines,
+		commitInfoView.commitLine(),
+		commitInfoLine{label: "Describe", value: describe, valueComponent: CmpCommitInfoViewValue},
+		commitInfoLine{label: "First tag", value: firstTag, valueComponent: CmpCommitInfoViewTag},
+	)
+
+	var localBranches, remoteBranches, tags []string
+	for _, branch := range commitContainment.LocalBranches() {
+		localBranches = append(localBranches, branch.Shorthand())
+	}
+	for _, branch := range commitContainment.RemoteBranches() {
+		remoteBranches = append(remoteBranches, branch.Shorthand())
+	}
+	for _, tag := range commitContainment.Tags() {
+		tags = append(tags, tag.Shorthand())
+	}
+
+	commitInfoLines = appendCommitInfoSection(commitInfoLines, "Local branches", localBranches, CmpCommitInfoViewLocalBranch)
+	commitInfoLines = appendCommitInfoSection(commitInfoLines, "Remote branches", remoteBranches, CmpCommitInfoViewRemoteBranch)
+	commitInfoLines = appendCommitInfoSection(commitInfoLines, "Tags", tags, CmpCommitInfoViewTag)
+
+	return
+}
+
+func appendCommitInfoSection(commitInfoLines []commitInfoLine, title string, refNames []string, themeComponentID ThemeComponentID) []commitInfoLine {
+	commitInfoLines = append(commitInfoLines,
+		commitInfoLine{},
+		commitInfoLine{value: fmt.Sprintf("%v (%v)", title, len(refNames)), valueComponent: CmpCommitInfoViewHeader},
+	)
+
+	for _, refName := range refNames {
+		commitInfoLines = append(commitInfoLines, commitInfoLine{value: "  " + refName, valueComponent: themeComponentID})
+	}
+
+	return commitInfoLines
+}
+
+// ViewID returns the ViewID of the commit info view
+func (commitInfoView *CommitInfoView) ViewID() ViewID {
+	return ViewCommitInfo
+}
+
+// Render generates the commit info view and writes it to the provided window
+func (commitInfoView *CommitInfoView) Render(win RenderWindow) (err error) {
+	commitInfoView.lock.Lock()
+	defer commitInfoView.lock.Unlock()
+
+	commitInfoView.lastViewDimension = win.ViewDimensions()
+
+	winRows := win.Rows() - 2
+	viewPos := commitInfoView.viewPos()
+
+	viewRows := commitInfoView.rows()
+	viewPos.DetermineViewStartRow(winRows, viewRows)
+
+	viewRowIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+	var lineBuilder *LineBuilder
+
+	for rowIndex := uint(0); rowIndex < winRows && viewRowIndex < viewRows; rowIndex++ {
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		commitInfoLine := commitInfoView.commitInfoLines[viewRowIndex]
+		lineBuilder.Append(" ")
+
+		if commitInfoLine.label != "" {
+			lineBuilder.AppendWithStyle(CmpCommitInfoViewLabel, "%-*v", civLabelWidth, commitInfoLine.label+":")
+		}
+
+		lineBuilder.AppendWithStyle(commitInfoLine.valueComponent, "%v", commitInfoLine.value)
+
+		viewRowIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, ViewStateActive); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpCommitInfoViewTitle, "Commit Info"); err != nil {
+		return
+	}
+
+	if commitInfoView.loading {
+		err = win.SetFooter(CmpCommitInfoViewFooter, "Loading")
+	} else {
+		err = win.SetFooter(CmpCommitInfoViewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, viewRows)
+	}
+
+	if err != nil {
+		return
+	}
+
+	if searchActive, searchPattern, lastSearchFoundMatch := commitInfoView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// RenderHelpBar renders a help message for the commit info view
+func (commitInfoView *CommitInfoView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	quitKeys := commitInfoView.config.KeyStrings(ActionRemoveView, ViewHierarchy{ViewCommitInfo, ViewAll})
+
+	if len(quitKeys) > 0 {
+		quitKeyText := fmt.Sprintf("Press %v to close commit info", quitKeys[len(quitKeys)-1].keystring)
+		lineBuilder.AppendWithStyle(CmpHelpbarviewSpecial, " %v", quitKeyText)
+	}
+
+	return
+}
+
+func (commitInfoView *CommitInfoView) viewPos() ViewPos {
+	return commitInfoView.activeViewPos
+}
+
+func (commitInfoView *CommitInfoView) rows() uint {
+	return uint(len(commitInfoView.commitInfoLines))
+}
+
+func (commitInfoView *CommitInfoView) viewDimension() ViewDimension {
+	return commitInfoView.lastViewDimension
+}
+
+func (commitInfoView *CommitInfoView) onRowSelected(rowIndex uint) (err error) {
+	return
+}
+
+func (commitInfoView *CommitInfoView) line(lineIndex uint) (line string) {
+	if lineIndex >= commitInfoView.rows() {
+		return
+	}
+
+	commitInfoLine := commitInfoView.commitInfoLines[lineIndex]
+
+	if commitInfoLine.label != "" {
+		line = fmt.Sprintf("%-*v", civLabelWidth, commitInfoLine.label+":")
+	}
+
+	return line + commitInfoLine.value
+}
+
+// HandleAction handles the action if supported
+func (commitInfoView *CommitInfoView) HandleAction(action Action) (err error) {
+	commitInfoView.lock.Lock()
+	defer commitInfoView.lock.Unlock()
+
+	var handled bool
+	if handled, err = commitInfoView.AbstractWindowView.HandleAction(action); handled {
+		log.Debugf("Action handled by AbstractWindowView")
+	} else {
+		log.Debugf("Action not handled")
+	}
+
+	return
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	cmeSummaryMaxLength = 50
+	cmeBodyWrapColumn   = 72
+)
+
+var commitMessageTrailerRegex = regexp.MustCompile(`^[A-Za-z0-9-]+: `)
+
+// CommitMessageEditor stores the lines of a commit message and the cursor position within them.
+// The first line is the summary. All subsequent lines are wrapped as text is inserted
+type CommitMessageEditor struct {
+	lines [][]rune
+	row   int
+	col   int
+}
+
+// NewCommitMessageEditor creates a new instance containing the provided message
+func NewCommitMessageEditor(message string) *CommitMessageEditor {
+	commitMessageEditor := &CommitMessageEditor{}
+	commitMessageEditor.SetMessage(message)
+
+	return commitMessageEditor
+}
+
+// SetMessage replaces the content of the editor with the provided message and moves the cursor to the end of the summary
+func (commitMessageEditor *CommitMessageEditor) SetMessage(message string) {
+	commitMessageEditor.lines = nil
+
+	for _, line := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		commitMessageEditor.lines = append(commitMessageEditor.lines, []rune(line))
+	}
+
+	commitMessageEditor.row = 0
+	commitMessageEditor.col = len(commitMessageEditor.lines[0])
+}
+
+// Message returns the commit message with trailing whitespace removed
+func (commitMessageEditor *CommitMessageEditor) Message() string {
+	lines := make([]string, 0, len(commitMessageEditor.lines))
+
+	for _, line := range commitMessageEditor.lines {
+		lines = append(lines, strings.TrimRightFunc(string(line), unicode.IsSpace))
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// IsEmpty returns true if the commit message contains only whitespace
+func (commitMessageEditor *CommitMessageEditor) IsEmpty() bool {
+	return strings.TrimSpace(commitMessageEditor.Message()) == ""
+}
+
+// Lines returns the lines of the commit message
+func (commitMessageEditor *CommitMessageEditor) Lines() []string {
+	lines := make([]string, 0, len(commitMessageEditor.lines))
+
+	for _, line := range commitMessageEditor.lines {
+		lines = append(lines, string(line))
+	}
+
+	return lines
+}
+
+// Cursor returns the line and character index of the cursor
+func (commitMessageEditor *CommitMessageEditor) Cursor() (row, col int) {
+	return commitMessageEditor.row, commitMessageEditor.col
+}
+
+// SummaryLength returns the number of characters in the summary line
+func (commitMessageEditor *CommitMessageEditor) SummaryLength() int {
+	return len(commitMessageEditor.lines[0])
+}
+
+// InsertText inserts the provided text at the cursor. Control characters are ignored
+func (commitMessageEditor *CommitMessageEditor) InsertText(text string) {
+	for _, char := range text {
+		if char == '\n' {
+			commitMessageEditor.NewLine()
+		} else if !unicode.IsControl(char) {
+			commitMessageEditor.insertChar(char)
+		}
+	}
+}
+
+func (commitMessageEditor *CommitMessageEditor) insertChar(char rune) {
+	line := commitMessageEditor.lines[commitMessageEditor.row]
+	col := commitMessageEditor.col
+
+	updatedLine := make([]rune, 0, len(line)+1)
+	updatedLine = append(updatedLine, line[:col]...)
+	updatedLine = append(updatedLine, char)
+	updatedLine = append(updatedLine, line[col:]...)
+
+	commitMessageEditor.lines[commitMessageEditor.row] = updatedLine
+	commitMessageEditor.col++
+
+	commitMessageEditor.wrapLine(commitMessageEditor.row)
+}
+
+// wrapLine breaks body lines longer than the wrap column at the last space before the wrap column.
+// The summary line and trailers are never wrapped as they must remain on a single line
+func (commitMessageEditor *CommitMessageEditor) wrapLine(row int) {
+	for row > 0 && len(commitMessageEditor.lines[row]) > cmeBodyWrapColumn {
+		line := commitMessageEditor.lines[row]
+		if commitMessageTrailerRegex.MatchString(string(line)) {
+			return
+		}
+
+		breakIndex := -1
+		for index := cmeBodyWrapColumn; index > 0; index-- {
+			if line[index] == ' ' {
+				breakIndex = index
+				break
+			}
+		}
+
+		if breakIndex == -1 {
+			return
+		}
+
+		head := []rune(strings.TrimRight(string(line[:breakIndex]), " "))
+		tail := append([]rune{}, line[breakIndex+1:]...)
+
+		commitMessageEditor.lines[row] = head
+		commitMessageEditor.insertLine(row+1, tail)
+
+		if commitMessageEditor.row == row && commitMessageEditor.col > breakIndex {
+			commitMessageEditor.row++
+			commitMessageEditor.col -= breakIndex + 1
+		} else if commitMessageEditor.row == row && commitMessageEditor.col > len(head) {
+			commitMessageEditor.col = len(head)
+		} else if commitMessageEditor.row > row {
+			commitMessageEditor.row++
+		}
+
+		row++
+	}
+}
+
+func (commitMessageEditor *CommitMessageEditor) insertLine(row int, line []rune) {
+	commitMessageEditor.lines = append(commitMessageEditor.lines, nil)
+	copy(commitMessageEditor.lines[row+1:], commitMessageEditor.lines[row:])
+	commitMessageEditor.lines[row] = line
+}
+
+func (commitMessageEditor *CommitMessageEditor) removeLine(row int) {
+	commitMessageEditor.lines = append(commitMessageEditor.lines[:row], commitMessageEditor.lines[row+1:]...)
+}
+
+// NewLine splits the current line at the cursor
+func (commitMessageEditor *CommitMessageEditor) NewLine() {
+	line := commitMessageEditor.lines[commitMessageEditor.row]
+	col := commitMessageEditor.col
+
+	commitMessageEditor.lines[commitMessageEditor.row] = append([]rune{}, line[:col]...)
+	commitMessageEditor.insertLine(commitMessageEditor.row+1, append([]rune{}, line[col:]...))
+
+	commitMessageEditor.row++
+	commitMessageEditor.col = 0
+}
+
+// DeleteCharBackward deletes the character before the cursor, joining the current line to the previous line if the cursor is at the start of the line
+func (commitMessageEditor *CommitMessageEditor) DeleteCharBackward() {
+	row, col := commitMessageEditor.row, commitMessageEditor.col
+
+	if col > 0 {
+		line := commitMessageEditor.lines[row]
+		commitMessageEditor.lines[row] = append(line[:col-1:col-1], line[col:]...)
+		commitMessageEditor.col--
+	} else if row > 0 {
+		previousLine := commitMessageEditor.lines[row-1]
+		commitMessageEditor.col = len(previousLine)
+		commitMessageEditor.lines[row-1] = append(previousLine[:len(previousLine):len(previousLine)], commitMessageEditor.lines[row]...)
+		commitMessageEditor.removeLine(row)
+		commitMessageEditor.row--
+	}
+}
+
+// DeleteCharForward deletes the character under the cursor, joining the next line to the current line if the cursor is at the end of the line
+func (commitMessageEditor *CommitMessageEditor) DeleteCharForward() {
+	row, col := commitMessageEditor.row, commitMessageEditor.col
+	line := commitMessageEditor.lines[row]
+
+	if col < len(line) {
+		commitMessageEditor.lines[row] = append(line[:col:col], line[col+1:]...)
+	} else if row+1 < len(commitMessageEditor.lines) {
+		commitMessageEditor.lines[row] = append(line[:col:col], commitMessageEditor.lines[row+1]...)
+		commitMessageEditor.removeLine(row + 1)
+	}
+}
+
+// MoveCursorLeft moves the cursor one character to the left, moving to the end of the previous line at the start of a line
+func (commitMessageEditor *CommitMessageEditor) MoveCursorLeft() {
+	if commitMessageEditor.col > 0 {
+		commitMessageEditor.col--
+	} else if commitMessageEditor.row > 0 {
+		commitMessageEditor.row--
+		commitMessageEditor.col = len(commitMessageEditor.lines[commitMessageEditor.row])
+	}
+}
+
+// MoveCursorRight moves the cursor one character to the right, moving to the start of the next line at the end of a line
+func (commitMessageEditor *CommitMessageEditor) MoveCursorRight() {
+	if commitMessageEditor.col < len(commitMessageEditor.lines[commitMessageEditor.row]) {
+		commitMessageEditor.col++
+	} else if commitMessageEditor.row+1 < len(commitMessageEditor.lines) {
+		commitMessageEditor.row++
+		commitMessageEditor.col = 0
+	}
+}
+
+// MoveCursorUp moves the cursor to the previous line
+func (commitMessageEditor *CommitMessageEditor) MoveCursorUp() {
+	if commitMessageEditor.row > 0 {
+		commitMessageEditor.row--
+		commitMessageEditor.col = MinInt(commitMessageEditor.col, len(commitMessageEditor.lines[commitMessageEditor.row]))
+	}
+}
+
+// MoveCursorDown moves the cursor to the next line
+func (commitMessageEditor *CommitMessageEditor) MoveCursorDown() {
+	if commitMessageEditor.row+1 < len(commitMessageEditor.lines) {
+		commitMessageEditor.row++
+		commitMessageEditor.col = MinInt(commitMessageEditor.col, len(commitMessageEditor.lines[commitMessageEditor.row]))
+	}
+}
+
+// MoveCursorLineStart moves the cursor to the start of the current line
+func (commitMessageEditor *CommitMessageEditor) MoveCursorLineStart() {
+	commitMessageEditor.col = 0
+}
+
+// MoveCursorLineEnd moves the cursor to the end of the current line
+func (commitMessageEditor *CommitMessageEditor) MoveCursorLineEnd() {
+	commitMessageEditor.col = len(commitMessageEditor.lines[commitMessageEditor.row])
+}
+
+// AddTrailer appends a trailer with the provided token and value to the trailer block at the end of the message.
+// A blank line separates the trailer block from the rest of the message. Trailers already present are not duplicated.
+// The line index of the trailer is returned
+func (commitMessageEditor *CommitMessageEditor) AddTrailer(token, value string) (row int) {
+	trailer := strings.TrimRight(token+": "+value, " ")
+
+	for row = len(commitMessageEditor.lines) - 1; row > 0 && len(commitMessageEditor.lines[row]) == 0; row-- {
+		commitMessageEditor.removeLine(row)
+	}
+
+	for row = len(commitMessageEditor.lines) - 1; row > 0 && commitMessageTrailerRegex.MatchString(string(commitMessageEditor.lines[row])); row-- {
+		if value != "" && string(commitMessageEditor.lines[row]) == trailer {
+			commitMessageEditor.clampCursor()
+			return
+		}
+	}
+
+	if !commitMessageTrailerRegex.MatchString(string(commitMessageEditor.lines[len(commitMessageEditor.lines)-1])) ||
+		len(commitMessageEditor.lines) == 1 {
+		commitMessageEditor.lines = append(commitMessageEditor.lines, []rune{})
+	}
+
+	if value == "" {
+		trailer += " "
+	}
+
+	commitMessageEditor.lines = append(commitMessageEditor.lines, []rune(trailer))
+	commitMessageEditor.clampCursor()
+
+	return len(commitMessageEditor.lines) - 1
+}
+
+// MoveCursorToLineEnd moves the cursor to the end of the line with the provided index
+func (commitMessageEditor *CommitMessageEditor) MoveCursorToLineEnd(row int) {
+	if row >= 0 && row < len(commitMessageEditor.lines) {
+		commitMessageEditor.row = row
+		commitMessageEditor.MoveCursorLineEnd()
+	}
+}
+
+func (commitMessageEditor *CommitMessageEditor) clampCursor() {
+	commitMessageEditor.row = MinInt(commitMessageEditor.row, len(commitMessageEditor.lines)-1)
+	commitMessageEditor.col = MinInt(commitMessageEditor.col, len(commitMessageEditor.lines[commitMessageEditor.row]))
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func checkCommitMessageEditorLines(commitMessageEditor *CommitMessageEditor, expectedLines []string, t *testing.T) {
+	if lines := commitMessageEditor.Lines(); !reflect.DeepEqual(lines, expectedLines) {
+		t.Errorf("Lines do not match expected value. Expected: %q, Actual: %q", expectedLines, lines)
+	}
+}
+
+func checkCommitMessageEditorCursor(commitMessageEditor *CommitMessageEditor, expectedRow, expectedCol int, t *testing.T) {
+	if row, col := commitMessageEditor.Cursor(); row != expectedRow || col != expectedCol {
+		t.Errorf("Cursor does not match expected value. Expected: %v:%v, Actual: %v:%v", expectedRow, expectedCol, row, col)
+	}
+}
+
+func TestTextIsInsertedAtCursor(t *testing.T) {
+	commitMessageEditor := NewCommitMessageEditor("Add  support")
+
+	commitMessageEditor.MoveCursorLineStart()
+	for i := 0; i < 4; i++ {
+		commitMessageEditor.MoveCursorRight()
+	}
+
+	commitMessageEditor.InsertText("tag")
+
+	checkCommitMessageEditorLines(commitMessageEditor, []string{"Add tag support"}, t)
+	checkCommitMessageEditorCursor(commitMessageEditor, 0, 7, t)
+
+	if summaryLength := commitMessageEditor.SummaryLength(); summaryLength != 15 {
+		t.Errorf("Summary length does not match expected value. Expected: 15, Actual: %v", summaryLength)
+	}
+}
+
+func TestNewLineAndDeleteCharBackwardSplitAndJoinLines(t *testing.T) {
+	commitMessageEditor := NewCommitMessageEditor("Summary")
+
+	commitMessageEditor.NewLine()
+	commitMessageEditor.NewLine()
+	commitMessageEditor.InsertText("Body")
+
+	checkCommitMessageEditorLines(commitMessageEditor, []string{"Summary", "", "Body"}, t)
+	checkCommitMessageEditorCursor(commitMessageEditor, 2, 4, t)
+
+	commitMessageEditor.MoveCursorLineStart()
+	commitMessageEditor.DeleteCharBackward()
+
+	checkCommitMessageEditorLines(commitMessageEditor, []string{"Summary", "Body"}, t)
+	checkCommitMessageEditorCursor(commitMessageEditor, 1, 0, t)
+
+	commitMessageEditor.MoveCursorUp()
+	commitMessageEditor.MoveCursorLineEnd()
+	commitMessageEditor.DeleteCharForward()
+
+	checkCommitMessageEditorLines(commitMessageEditor, []string{"SummaryBody"}, t)
+	checkCommitMessageEditorCursor(commitMessageEditor, 0, 7, t)
+}
+
+func TestBodyLinesAreWrappedAtWrapColumn(t *testing.T) {
+	commitMessageEditor := NewCommitMessageEditor("Summary")
+	commitMessageEditor.NewLine()
+	commitMessageEditor.NewLine()
+
+	words := strings.Repeat("word ", 20)
+	commitMessageEditor.InsertText(words)
+
+	for rowIndex, line := range commitMessageEditor.Lines() {
+		if len(line) > cmeBodyWrapColumn {
+			t.Errorf("Line %v exceeds wrap column: %q", rowIndex, line)
+		}
+	}
+
+	expectedMessage := "Summary\n\n" + strings.TrimSpace(strings.Repeat("word ", 14)) + "\n" + strings.TrimSpace(strings.Repeat("word ", 6))
+	if message := commitMessageEditor.Message(); message != expectedMessage {
+		t.Errorf("Message does not match expected value. Expected: %q, Actual: %q", expectedMessage, message)
+	}
+
+	checkCommitMessageEditorCursor(commitMessageEditor, 3, 30, t)
+}
+
+func TestSummaryLineIsNotWrapped(t *testing.T) {
+	summary := strings.Repeat("word ", 20)
+	commitMessageEditor := NewCommitMessageEditor("")
+
+	commitMessageEditor.InsertText(summary)
+
+	checkCommitMessageEditorLines(commitMessageEditor, []string{summary}, t)
+}
+
+func TestTrailersAreAddedToTrailerBlock(t *testing.T) {
+	commitMessageEditor := NewCommitMessageEditor("Summary\n\nBody\n\n")
+
+	commitMessageEditor.AddTrailer(cmvSignedOffByTrailer, "John Smith <john@example.com>")
+	row := commitMessageEditor.AddTrailer(cmvCoAuthoredByTrailer, "")
+
+	checkCommitMessageEditorLines(commitMessageEditor, []string{
+		"Summary",
+		"",
+		"Body",
+		"",
+		"Signed-off-by: John Smith <john@example.com>",
+		"Co-authored-by: ",
+	}, t)
+
+	if row != 5 {
+		t.Errorf("Trailer row does not match expected value. Expected: 5, Actual: %v", row)
+	}
+
+	commitMessageEditor.AddTrailer(cmvSignedOffByTrailer, "John Smith <john@example.com>")
+
+	if lineNum := len(commitMessageEditor.Lines()); lineNum != 6 {
+		t.Errorf("Expected existing trailer not to be duplicated but message has %v lines", lineNum)
+	}
+}
+
+func TestTrailerIsSeparatedFromSummaryByBlankLine(t *testing.T) {
+	commitMessageEditor := NewCommitMessageEditor("fix: Handle empty input")
+
+	commitMessageEditor.AddTrailer(cmvSignedOffByTrailer, "John Smith <john@example.com>")
+
+	checkCommitMessageEditorLines(commitMessageEditor, []string{
+		"fix: Handle empty input",
+		"",
+		"Signed-off-by: John Smith <john@example.com>",
+	}, t)
+	checkCommitMessageEditorCursor(commitMessageEditor, 0, 23, t)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// CommitMessageLinter checks commit messages against the configured commit message rules
+type CommitMessageLinter struct {
+	summaryMaxLength int
+	blankSecondLine  bool
+	summaryRegex     *regexp.Regexp
+	requiredTrailers []string
+}
+
+// NewCommitMessageLinter creates a new instance using the commit message rules currently configured
+func NewCommitMessageLinter(config Config) *CommitMessageLinter {
+	commitMessageLinter := &CommitMessageLinter{
+		summaryMaxLength: config.GetInt(CfCommitLintSummaryMaxLength),
+		blankSecondLine:  config.GetBool(CfCommitLintBlankSecondLine),
+	}
+
+	if summaryRegex := config.GetString(CfCommitLintSummaryRegex); summaryRegex != "" {
+		var err error
+		if commitMessageLinter.summaryRegex, err = regexp.Compile(summaryRegex); err != nil {
+			log.Errorf("Ignoring invalid %v value %v: %v", CfCommitLintSummaryRegex, summaryRegex, err)
+		}
+	}
+
+	for _, trailer := range strings.Split(config.GetString(CfCommitLintRequiredTrailers), ",") {
+		if trailer = strings.TrimSpace(trailer); trailer != "" {
+			commitMessageLinter.requiredTrailers = append(commitMessageLinter.requiredTrailers, trailer)
+		}
+	}
+
+	return commitMessageLinter
+}
+
+// HasRules returns true if at least one commit message rule is configured
+func (commitMessageLinter *CommitMessageLinter) HasRules() bool {
+	return commitMessageLinter.summaryMaxLength > 0 ||
+		commitMessageLinter.blankSecondLine ||
+		commitMessageLinter.summaryRegex != nil ||
+		len(commitMessageLinter.requiredTrailers) > 0
+}
+
+// Lint returns a description of each rule the provided commit message violates
+func (commitMessageLinter *CommitMessageLinter) Lint(message string) (violations []string) {
+	lines := strings.Split(strings.TrimRightFunc(message, unicode.IsSpace), "\n")
+	summary := lines[0]
+
+	if summaryLength := utf8.RuneCountInString(summary); commitMessageLinter.summaryMaxLength > 0 &&
+		summaryLength > commitMessageLinter.summaryMaxLength {
+		violations = append(violations, fmt.Sprintf("Summary is %v characters long (maximum %v)",
+			summaryLength, commitMessageLinter.summaryMaxLength))
+	}
+
+	if commitMessageLinter.blankSecondLine && len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		violations = append(violations, "Second line is not blank")
+	}
+
+	if commitMessageLinter.summaryRegex != nil && !commitMessageLinter.summaryRegex.MatchString(summary) {
+		violations = append(violations, fmt.Sprintf("Summary does not match %v", commitMessageLinter.summaryRegex))
+	}
+
+	if len(commitMessageLinter.requiredTrailers) > 0 {
+		trailerTokens := commitMessageTrailerTokens(lines)
+
+		for _, requiredTrailer := range commitMessageLinter.requiredTrailers {
+			if !trailerTokens[strings.ToLower(requiredTrailer)] {
+				violations = append(violations, fmt.Sprintf("Missing %v trailer", requiredTrailer))
+			}
+		}
+	}
+
+	return
+}
+
+// commitMessageTrailerTokens returns the lower case tokens of the trailers in the final paragraph of the message.
+// The summary is never considered to be a trailer
+func commitMessageTrailerTokens(lines []string) (trailerTokens map[string]bool) {
+	trailerTokens = map[string]bool{}
+
+	for rowIndex := len(lines) - 1; rowIndex > 0 && strings.TrimSpace(lines[rowIndex]) != ""; rowIndex-- {
+		if line := lines[rowIndex]; commitMessageTrailerRegex.MatchString(line) {
+			trailerTokens[strings.ToLower(line[:strings.Index(line, ":")])] = true
+		}
+	}
+
+	return
+}
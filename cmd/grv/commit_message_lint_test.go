@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestCommitMessageWithoutRulesHasNoViolations(t *testing.T) {
+	commitMessageLinter := &CommitMessageLinter{}
+
+	if commitMessageLinter.HasRules() {
+		t.Errorf("Expected linter without configured rules to report no rules")
+	}
+
+	if violations := commitMessageLinter.Lint("a summary that is rather long and does not follow any convention\nbody"); len(violations) > 0 {
+		t.Errorf("Expected no violations but found: %v", violations)
+	}
+}
+
+func TestCommitMessageViolationsAreReported(t *testing.T) {
+	commitMessageLinter := &CommitMessageLinter{
+		summaryMaxLength: 20,
+		blankSecondLine:  true,
+		summaryRegex:     regexp.MustCompile(`^(feat|fix)(\(.+\))?: `),
+		requiredTrailers: []string{"Signed-off-by", "Reviewed-by"},
+	}
+
+	violations := commitMessageLinter.Lint("Update the commit message linter\nImmediately followed by body\n\nSigned-off-by: John Smith <john@example.com>\n")
+	expectedViolations := []string{
+		"Summary is 32 characters long (maximum 20)",
+		"Second line is not blank",
+		"Summary does not match ^(feat|fix)(\\(.+\\))?: ",
+		"Missing Reviewed-by trailer",
+	}
+
+	if !reflect.DeepEqual(violations, expectedViolations) {
+		t.Errorf("Violations do not match expected value. Expected: %q, Actual: %q", expectedViolations, violations)
+	}
+}
+
+func TestConformingCommitMessageHasNoViolations(t *testing.T) {
+	commitMessageLinter := &CommitMessageLinter{
+		summaryMaxLength: 50,
+		blankSecondLine:  true,
+		summaryRegex:     regexp.MustCompile(`^(feat|fix)(\(.+\))?: `),
+		requiredTrailers: []string{"signed-off-by"},
+	}
+
+	if violations := commitMessageLinter.Lint("fix(ui): Handle empty input\n\nBody\n\nSigned-off-by: John Smith <john@example.com>"); len(violations) > 0 {
+		t.Errorf("Expected no violations but found: %v", violations)
+	}
+}
+
+func TestTrailersAreOnlyRecognisedInFinalParagraph(t *testing.T) {
+	commitMessageLinter := &CommitMessageLinter{
+		requiredTrailers: []string{"Signed-off-by"},
+	}
+
+	violations := commitMessageLinter.Lint("Signed-off-by: John Smith <john@example.com>\n\nSigned-off-by: is mentioned here\n\nBody")
+	expectedViolations := []string{"Missing Signed-off-by trailer"}
+
+	if !reflect.DeepEqual(violations, expectedViolations) {
+		t.Errorf("Violations do not match expected value. Expected: %q, Actual: %q", expectedViolations, violations)
+	}
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	cmvViewRows            = 20
+	cmvViewCols            = cmeBodyWrapColumn + 4
+	cmvContentColOffset    = 2
+	cmvDeleteChar          = "\x7f"
+	cmvSignedOffByTrailer  = "Signed-off-by"
+	cmvCoAuthoredByTrailer = "Co-authored-by"
+)
+
+// OnCommitMessageClosed is called with the message entered when the commit message view is closed
+type OnCommitMessageClosed func(message string)
+
+// CommitMessageConfig is the configuration for the CommitMessageView
+type CommitMessageConfig struct {
+	Title          string
+	Message        string
+	RecentMessages []string
+	Signature      string
+	OnSubmit       OnCommitMessageClosed
+	OnCancel       OnCommitMessageClosed
+}
+
+type commitMessageViewHandler func(*CommitMessageView, Action) error
+
+// CommitMessageView is a multi-line editor for entering a commit message
+type CommitMessageView struct {
+	*AbstractWindowView
+	commitMessageConfig CommitMessageConfig
+	editor              *CommitMessageEditor
+	draft               string
+	recentMessageIndex  int
+	submitted           bool
+	viewStartRow        int
+	activeViewPos       ViewPos
+	lastViewDimension   ViewDimension
+	handlers            map[ActionType]commitMessageViewHandler
+	lock                sync.Mutex
+}
+
+// NewCommitMessageView creates a new instance
+func NewCommitMessageView(commitMessageConfig CommitMessageConfig, channels Channels, config Config, variables GRVVariableSetter) *CommitMessageView {
+	commitMessageView := &CommitMessageView{
+		commitMessageConfig: commitMessageConfig,
+		editor:              NewCommitMessageEditor(commitMessageConfig.Message),
+		recentMessageIndex:  -1,
+		activeViewPos:       NewViewPosition(),
+		handlers: map[ActionType]commitMessageViewHandler{
+			ActionInsertText:          insertCommitMessageText,
+			ActionNewLine:             insertCommitMessageNewLine,
+			ActionDeleteCharBackward:  deleteCommitMessageCharBackward,
+			ActionDeleteCharForward:   deleteCommitMessageCharForward,
+			ActionCursorLeft:          moveCommitMessageCursorLeft,
+			ActionCursorRight:         moveCommitMessageCursorRight,
+			ActionCursorUp:            moveCommitMessageCursorUp,
+			ActionCursorDown:          moveCommitMessageCursorDown,
+			ActionCursorLineStart:     moveCommitMessageCursorLineStart,
+			ActionCursorLineEnd:       moveCommitMessageCursorLineEnd,
+			ActionSubmitCommitMessage: submitCommitMessage,
+			ActionAddSignedOffBy:      addSignedOffByTrailer,
+			ActionAddCoAuthoredBy:     addCoAuthoredByTrailer,
+			ActionPrevCommitMessage:   recallPrevCommitMessage,
+			ActionNextCommitMessage:   recallNextCommitMessage,
+		},
+	}
+
+	commitMessageView.AbstractWindowView = NewAbstractWindowView(commitMessageView, channels, config, variables, &commitMessageView.lock, "commit message line")
+
+	return commitMessageView
+}
+
+// Dispose passes any message entered to the cancel callback if the message was not submitted
+func (commitMessageView *CommitMessageView) Dispose() {
+	commitMessageView.lock.Lock()
+	defer commitMessageView.lock.Unlock()
+
+	if !commitMessageView.submitted && !commitMessageView.editor.IsEmpty() && commitMessageView.commitMessageConfig.OnCancel != nil {
+		commitMessageView.commitMessageConfig.OnCancel(commitMessageView.editor.Message())
+	}
+}
+
+// ViewID returns the ViewID of the commit message view
+func (commitMessageView *CommitMessageView) ViewID() ViewID {
+	return ViewCommitMessage
+}
+
+// Render generates the commit message view and writes it to the provided window
+func (commitMessageView *CommitMessageView) Render(win RenderWindow) (err error) {
+	commitMessageView.lock.Lock()
+	defer commitMessageView.lock.Unlock()
+
+	commitMessageView.lastViewDimension = win.ViewDimensions()
+
+	if win.Rows() < 3 || win.Cols() <= cmvContentColOffset+1 {
+		log.Errorf("Unable to render CommitMessageView - too few rows and/or columns: %v", win.ViewDimensions())
+		return
+	}
+
+	editor := commitMessageView.editor
+	lines := editor.Lines()
+	cursorRow, cursorCol := editor.Cursor()
+
+	contentRows := int(win.Rows()) - 2
+	contentCols := int(win.Cols()) - cmvContentColOffset - 1
+
+	if cursorRow < commitMessageView.viewStartRow {
+		commitMessageView.viewStartRow = cursorRow
+	} else if cursorRow >= commitMessageView.viewStartRow+contentRows {
+		commitMessageView.viewStartRow = cursorRow - contentRows + 1
+	}
+
+	startCol := MaxInt(0, cursorCol-contentCols+1)
+
+	win.ApplyStyle(CmpCommitMessageViewBody)
+
+	var lineBuilder *LineBuilder
+	for rowIndex := 0; rowIndex < contentRows && commitMessageView.viewStartRow+rowIndex < len(lines); rowIndex++ {
+		if lineBuilder, err = win.LineBuilder(uint(rowIndex+1), 1); err != nil {
+			return
+		}
+
+		lineIndex := commitMessageView.viewStartRow + rowIndex
+		lineBuilder.Append("%v", strings.Repeat(" ", cmvContentColOffset))
+		renderCommitMessageLine(lineBuilder, lineIndex, []rune(lines[lineIndex]), startCol)
+	}
+
+	win.DrawBorderWithStyle(CmpCommitMessageViewBody)
+
+	if err = win.SetTitle(CmpCommitMessageViewTitle, "%v", commitMessageView.commitMessageConfig.Title); err != nil {
+		return
+	}
+
+	footerThemeComponentID := CmpCommitMessageViewFooter
+	if editor.SummaryLength() > cmeSummaryMaxLength {
+		footerThemeComponentID = CmpCommitMessageViewSummaryOverflow
+	}
+
+	if err = win.SetFooter(footerThemeComponentID, "Summary %v/%v", editor.SummaryLength(), cmeSummaryMaxLength); err != nil {
+		return
+	}
+
+	cursorLine := []rune(lines[cursorRow])
+	cursorDisplayCol := cmvContentColOffset + StringWidth(string(cursorLine[startCol:cursorCol]))
+
+	return win.SetCursor(uint(cursorRow-commitMessageView.viewStartRow+1), uint(MinInt(cursorDisplayCol, int(win.Cols())-2)))
+}
+
+func renderCommitMessageLine(lineBuilder *LineBuilder, lineIndex int, line []rune, startCol int) {
+	if startCol >= len(line) {
+		return
+	}
+
+	switch {
+	case lineIndex == 0:
+		for charIndex := startCol; charIndex < len(line); charIndex++ {
+			themeComponentID := CmpCommitMessageViewSummary
+			if charIndex >= cmeSummaryMaxLength {
+				themeComponentID = CmpCommitMessageViewSummaryOverflow
+			}
+
+			lineBuilder.AppendWithStyle(themeComponentID, "%c", line[charIndex])
+		}
+	case commitMessageTrailerRegex.MatchString(string(line)):
+		lineBuilder.AppendWithStyle(CmpCommitMessageViewTrailer, "%v", string(line[startCol:]))
+	default:
+		lineBuilder.AppendWithStyle(CmpCommitMessageViewBody, "%v", string(line[startCol:]))
+	}
+}
+
+// RenderHelpBar renders key bindings for the main commit message view actions
+func (commitMessageView *CommitMessageView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	helpActions := []struct {
+		actionType  ActionType
+		description string
+	}{
+		{actionType: ActionSubmitCommitMessage, description: "commit"},
+		{actionType: ActionRemoveView, description: "cancel"},
+		{actionType: ActionAddSignedOffBy, description: "sign off"},
+		{actionType: ActionAddCoAuthoredBy, description: "co-author"},
+		{actionType: ActionPrevCommitMessage, description: "previous message"},
+		{actionType: ActionNextCommitMessage, description: "next message"},
+	}
+
+	for _, helpAction := range helpActions {
+		keys := commitMessageView.config.KeyStrings(helpAction.actionType, ViewHierarchy{ViewCommitMessage})
+
+		if len(keys) > 0 {
+			lineBuilder.AppendWithStyle(CmpHelpbarviewSpecial, " %v", keys[len(keys)-1].keystring)
+			lineBuilder.AppendWithStyle(CmpHelpbarviewNormal, " %v ", helpAction.description)
+		}
+	}
+
+	return
+}
+
+func (commitMessageView *CommitMessageView) viewPos() ViewPos {
+	return commitMessageView.activeViewPos
+}
+
+func (commitMessageView *CommitMessageView) rows() uint {
+	return uint(len(commitMessageView.editor.Lines()))
+}
+
+func (commitMessageView *CommitMessageView) viewDimension() ViewDimension {
+	return commitMessageView.lastViewDimension
+}
+
+func (commitMessageView *CommitMessageView) onRowSelected(rowIndex uint) (err error) {
+	return
+}
+
+func (commitMessageView *CommitMessageView) line(lineIndex uint) (line string) {
+	if lines := commitMessageView.editor.Lines(); lineIndex < uint(len(lines)) {
+		line = lines[lineIndex]
+	}
+
+	return
+}
+
+// HandleAction checks if the commit message view supports the provided action and executes it if so
+func (commitMessageView *CommitMessageView) HandleAction(action Action) (err error) {
+	commitMessageView.lock.Lock()
+	defer commitMessageView.lock.Unlock()
+
+	if handler, ok := commitMessageView.handlers[action.ActionType]; ok {
+		log.Debugf("Action handled by CommitMessageView")
+		err = handler(commitMessageView, action)
+		commitMessageView.channels.UpdateDisplay()
+	} else {
+		log.Debugf("Action not handled")
+	}
+
+	return
+}
+
+func insertCommitMessageText(commitMessageView *CommitMessageView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected ActionInsertTextArgs argument")
+	}
+
+	arg, ok := action.Args[0].(ActionInsertTextArgs)
+	if !ok {
+		return fmt.Errorf("Expected ActionInsertTextArgs argument but got %T", action.Args[0])
+	}
+
+	// Many terminals send DEL when backspace is pressed
+	if arg.text == cmvDeleteChar {
+		commitMessageView.editor.DeleteCharBackward()
+	} else if utf8.RuneCountInString(arg.text) == 1 {
+		commitMessageView.editor.InsertText(arg.text)
+	} else {
+		log.Debugf("Ignoring key %v in commit message view", arg.text)
+	}
+
+	return
+}
+
+func insertCommitMessageNewLine(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.NewLine()
+	return
+}
+
+func deleteCommitMessageCharBackward(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.DeleteCharBackward()
+	return
+}
+
+func deleteCommitMessageCharForward(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.DeleteCharForward()
+	return
+}
+
+func moveCommitMessageCursorLeft(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.MoveCursorLeft()
+	return
+}
+
+func moveCommitMessageCursorRight(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.MoveCursorRight()
+	return
+}
+
+func moveCommitMessageCursorUp(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.MoveCursorUp()
+	return
+}
+
+func moveCommitMessageCursorDown(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.MoveCursorDown()
+	return
+}
+
+func moveCommitMessageCursorLineStart(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.MoveCursorLineStart()
+	return
+}
+
+func moveCommitMessageCursorLineEnd(commitMessageView *CommitMessageView, action Action) (err error) {
+	commitMessageView.editor.MoveCursorLineEnd()
+	return
+}
+
+func submitCommitMessage(commitMessageView *CommitMessageView, action Action) (err error) {
+	if commitMessageView.editor.IsEmpty() {
+		return fmt.Errorf("Unable to commit with an empty commit message")
+	}
+
+	commitMessageView.submitted = true
+	commitMessageView.channels.DoAction(Action{ActionType: ActionRemoveView})
+
+	if commitMessageView.commitMessageConfig.OnSubmit != nil {
+		commitMessageView.commitMessageConfig.OnSubmit(commitMessageView.editor.Message())
+	}
+
+	return
+}
+
+func addSignedOffByTrailer(commitMessageView *CommitMessageView, action Action) (err error) {
+	signature := commitMessageView.commitMessageConfig.Signature
+	if signature == "" {
+		return fmt.Errorf("Unable to add %v trailer as no git user name and email are configured", cmvSignedOffByTrailer)
+	}
+
+	commitMessageView.editor.AddTrailer(cmvSignedOffByTrailer, signature)
+	return
+}
+
+func addCoAuthoredByTrailer(commitMessageView *CommitMessageView, action Action) (err error) {
+	row := commitMessageView.editor.AddTrailer(cmvCoAuthoredByTrailer, "")
+	commitMessageView.editor.MoveCursorToLineEnd(row)
+	return
+}
+
+func recallPrevCommitMessage(commitMessageView *CommitMessageView, action Action) (err error) {
+	recentMessages := commitMessageView.commitMessageConfig.RecentMessages
+	if commitMessageView.recentMessageIndex+1 >= len(recentMessages) {
+		return
+	}
+
+	if commitMessageView.recentMessageIndex == -1 {
+		commitMessageView.draft = commitMessageView.editor.Message()
+	}
+
+	commitMessageView.recentMessageIndex++
+	commitMessageView.editor.SetMessage(recentMessages[commitMessageView.recentMessageIndex])
+
+	return
+}
+
+func recallNextCommitMessage(commitMessageView *CommitMessageView, action Action) (err error) {
+	if commitMessageView.recentMessageIndex == -1 {
+		return
+	}
+
+	commitMessageView.recentMessageIndex--
+
+	if commitMessageView.recentMessageIndex == -1 {
+		commitMessageView.editor.SetMessage(commitMessageView.draft)
+	} else {
+		commitMessageView.editor.SetMessage(commitMessageView.commitMessageConfig.RecentMessages[commitMessageView.recentMessageIndex])
+	}
+
+	return
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCancelledCommitMessageIsAddedToHistoryWhenPopupViewIsRemoved(t *testing.T) {
+	gitStatusView := &GitStatusView{
+		commitMessageHistory: []string{"Update README"},
+	}
+
+	commitMessageView := NewCommitMessageView(CommitMessageConfig{
+		Message: "Fix typo in help text",
+		OnSubmit: func(message string) {
+			t.Errorf("Expected cancelled message not to be submitted")
+		},
+		OnCancel: gitStatusView.addCommitMessageToHistory,
+	}, &MockChannels{}, &MockConfig{}, &MockGRVVariableSetter{})
+
+	view := &View{}
+	err := view.HandleEvent(Event{
+		EventType: ViewRemovedEvent,
+		Args: []interface{}{
+			&fixedSizePopupView{
+				abstractPopupView: &abstractPopupView{
+					view: commitMessageView,
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Unable to handle event: %v", err)
+	}
+
+	expectedHistory := []string{"Fix typo in help text", "Update README"}
+	if !reflect.DeepEqual(expectedHistory, gitStatusView.commitMessageHistory) {
+		t.Errorf("Commit message history does not match expected value. Expected: %v, Actual: %v", expectedHistory, gitStatusView.commitMessageHistory)
+	}
+}
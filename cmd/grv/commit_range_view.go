@@ -0,0 +1,324 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	crvDateFormat = "2006-01-02 15:04"
+)
+
+// CommitRangeViewListener is notified when either a commit or the header of a commit range view is selected
+type CommitRangeViewListener interface {
+	CommitViewListener
+	OnCommitRangeSelected(from, to *Commit) error
+}
+
+// CommitRangeView displays the commits reachable from one ref but not another.
+// The first row is a header describing the merge base of the two refs and
+// the number of commits each ref is ahead of the other.
+// The range is determined from the refs when the view is created and is not updated when the refs change
+type CommitRangeView struct {
+	*AbstractWindowView
+	channels                 Channels
+	repoData                 RepoData
+	config                   Config
+	variables                GRVVariableSetter
+	include                  Ref
+	exclude                  Ref
+	mergeBase                *Commit
+	ahead                    int
+	behind                   int
+	commits                  []*Commit
+	loading                  bool
+	activeViewPos            ViewPos
+	lastViewDimension        ViewDimension
+	commitRangeViewListeners []CommitRangeViewListener
+	lock                     sync.Mutex
+}
+
+// NewCommitRangeView creates a new instance which displays the commits reachable from include but not exclude
+func NewCommitRangeView(repoData RepoData, channels Channels, config Config, variables GRVVariableSetter, include, exclude Ref) *CommitRangeView {
+	commitRangeView := &CommitRangeView{
+		repoData:      repoData,
+		channels:      channels,
+		config:        config,
+		variables:     variables,
+		include:       include,
+		exclude:       exclude,
+		activeViewPos: NewViewPosition(),
+	}
+
+	commitRangeView.AbstractWindowView = NewAbstractWindowView(commitRangeView, channels, config, variables, &commitRangeView.lock, "commit")
+
+	return commitRangeView
+}
+
+// Initialise starts determining the merge base of the two refs and loading the commits in the range
+func (commitRangeView *CommitRangeView) Initialise() (err error) {
+	commitRangeView.loading = true
+	pendingBackgroundWork.Start()
+	go commitRangeView.loadCommits()
+
+	return
+}
+
+func (commitRangeView *CommitRangeView) loadCommits() {
+	defer pendingBackgroundWork.Complete()
+
+	if err := commitRangeView.loadCommitRange(); err != nil {
+		commitRangeView.channels.ReportError(err)
+	}
+
+	commitRangeView.lock.Lock()
+	commitRangeView.loading = false
+	log.Debugf("Loaded %v commits in %v but not in %v", len(commitRangeView.commits),
+		commitRangeView.include.Shorthand(), commitRangeView.exclude.Shorthand())
+	commitRangeView.lock.Unlock()
+
+	commitRangeView.channels.UpdateDisplay()
+}
+
+func (commitRangeView *CommitRangeView) loadCommitRange() (err error) {
+	include := commitRangeView.include.Oid()
+	exclude := commitRangeView.exclude.Oid()
+
+	mergeBaseOid, err := commitRangeView.repoData.MergeBase(include, exclude)
+	if err != nil {
+		return
+	}
+
+	mergeBase, err := commitRangeView.repoData.Commit(mergeBaseOid)
+	if err != nil {
+		return
+	}
+
+	ahead, behind, err := commitRangeView.repoData.AheadBehind(include, exclude)
+	if err != nil {
+		return
+	}
+
+	commitRangeView.lock.Lock()
+	commitRangeView.mergeBase = mergeBase
+	commitRangeView.ahead = ahead
+	commitRangeView.behind = behind
+
+	// The header may have been selected before the merge base was determined
+	if commitRangeView.viewState == ViewStateActive && commitRangeView.activeViewPos.ActiveRowIndex() == 0 {
+		err = commitRangeView.onRowSelected(0)
+	}
+
+	commitRangeView.lock.Unlock()
+
+	if err != nil {
+		return
+	}
+
+	commitRangeView.channels.UpdateDisplay()
+
+	commitCh, err := commitRangeView.repoData.CommitRange(fmt.Sprintf("%v..%v", exclude, include))
+	if err != nil {
+		return
+	}
+
+	for commit := range commitCh {
+		commitRangeView.lock.Lock()
+		commitRangeView.commits = append(commitRangeView.commits, commit)
+		commitRangeView.lock.Unlock()
+	}
+
+	return
+}
+
+// Render generates and writes the commit range view to the provided window
+func (commitRangeView *CommitRangeView) Render(win RenderWindow) (err error) {
+	commitRangeView.lock.Lock()
+	defer commitRangeView.lock.Unlock()
+
+	commitRangeView.lastViewDimension = win.ViewDimensions()
+
+	rows := win.Rows() - 2
+	lineNum := commitRangeView.rows()
+	viewPos := commitRangeView.activeViewPos
+	viewPos.DetermineViewStartRow(rows, lineNum)
+
+	lineIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+	var lineBuilder *LineBuilder
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		if lineIndex == 0 {
+			lineBuilder.AppendWithStyle(CmpCommitRangeViewHeader, " %v", commitRangeView.header())
+		} else {
+			commit := commitRangeView.commits[lineIndex-1]
+			author := commit.commit.Author()
+
+			lineBuilder.Append(" ").
+				AppendWithStyle(CmpCommitRangeViewShortOid, "%v ", commit.oid.ShortID()).
+				AppendWithStyle(CmpCommitRangeViewDate, "%v ", author.When.Format(crvDateFormat)).
+				AppendWithStyle(CmpCommitRangeViewAuthor, "%v ", author.Name).
+				AppendWithStyle(CmpCommitRangeViewSummary, "%v", commit.commit.Summary())
+		}
+
+		lineIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, commitRangeView.viewState); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpCommitRangeViewTitle, "Only in %v", commitRangeView.include.Shorthand()); err != nil {
+		return
+	}
+
+	if err = commitRangeView.renderFooter(win); err != nil {
+		return
+	}
+
+	if searchActive, searchPattern, lastSearchFoundMatch := commitRangeView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (commitRangeView *CommitRangeView) renderFooter(win RenderWindow) (err error) {
+	commitNum := len(commitRangeView.commits)
+
+	switch activeRowIndex := commitRangeView.activeViewPos.ActiveRowIndex(); {
+	case commitRangeView.loading:
+		err = win.SetFooter(CmpCommitRangeViewFooter, "Loading commits (%v loaded)", commitNum)
+	case activeRowIndex == 0:
+		err = win.SetFooter(CmpCommitRangeViewFooter, "%v commits", commitNum)
+	default:
+		err = win.SetFooter(CmpCommitRangeViewFooter, "Commit %v of %v", activeRowIndex, commitNum)
+	}
+
+	return
+}
+
+func (commitRangeView *CommitRangeView) header() string {
+	if commitRangeView.mergeBase == nil {
+		if commitRangeView.loading {
+			return fmt.Sprintf("Determining merge base of %v and %v", commitRangeView.include.Shorthand(), commitRangeView.exclude.Shorthand())
+		}
+
+		return fmt.Sprintf("Unable to determine merge base of %v and %v", commitRangeView.include.Shorthand(), commitRangeView.exclude.Shorthand())
+	}
+
+	return fmt.Sprintf("Merge base %v: %v is %v ahead and %v behind %v", commitRangeView.mergeBase.oid.ShortID(),
+		commitRangeView.include.Shorthand(), commitRangeView.ahead, commitRangeView.behind, commitRangeView.exclude.Shorthand())
+}
+
+// OnStateChange updates the active state of the view and notifies listeners
+// of the selected row when the view becomes active
+func (commitRangeView *CommitRangeView) OnStateChange(viewState ViewState) {
+	commitRangeView.AbstractWindowView.OnStateChange(viewState)
+
+	if viewState == ViewStateActive {
+		commitRangeView.lock.Lock()
+		defer commitRangeView.lock.Unlock()
+
+		if err := commitRangeView.onRowSelected(commitRangeView.activeViewPos.ActiveRowIndex()); err != nil {
+			commitRangeView.channels.ReportError(err)
+		}
+	}
+}
+
+// ViewID returns the commit range views ID
+func (commitRangeView *CommitRangeView) ViewID() ViewID {
+	return ViewCommitRange
+}
+
+// RegisterCommitRangeViewListener accepts a listener to be notified when a commit or the header is selected
+func (commitRangeView *CommitRangeView) RegisterCommitRangeViewListener(commitRangeViewListener CommitRangeViewListener) {
+	commitRangeView.lock.Lock()
+	defer commitRangeView.lock.Unlock()
+
+	commitRangeView.commitRangeViewListeners = append(commitRangeView.commitRangeViewListeners, commitRangeViewListener)
+}
+
+func (commitRangeView *CommitRangeView) viewPos() ViewPos {
+	return commitRangeView.activeViewPos
+}
+
+func (commitRangeView *CommitRangeView) line(lineIndex uint) (line string) {
+	if lineIndex >= commitRangeView.rows() {
+		return
+	} else if lineIndex == 0 {
+		return commitRangeView.header()
+	}
+
+	commit := commitRangeView.commits[lineIndex-1]
+	author := commit.commit.Author()
+
+	return fmt.Sprintf("%v %v %v %v", commit.oid.ShortID(), author.When.Format(crvDateFormat), author.Name, commit.commit.Summary())
+}
+
+func (commitRangeView *CommitRangeView) rows() uint {
+	return uint(len(commitRangeView.commits)) + 1
+}
+
+func (commitRangeView *CommitRangeView) viewDimension() ViewDimension {
+	return commitRangeView.lastViewDimension
+}
+
+// onRowSelected displays the cumulative changes of the range when the header is selected
+// and the changes of the selected commit otherwise
+func (commitRangeView *CommitRangeView) onRowSelected(rowIndex uint) (err error) {
+	if rowIndex == 0 {
+		if commitRangeView.mergeBase == nil {
+			return
+		}
+
+		var include *Commit
+		if include, err = commitRangeView.repoData.Commit(commitRangeView.include.Oid()); err != nil {
+			return
+		}
+
+		for _, commitRangeViewListener := range commitRangeView.commitRangeViewListeners {
+			if err = commitRangeViewListener.OnCommitRangeSelected(commitRangeView.mergeBase, include); err != nil {
+				return
+			}
+		}
+
+		return
+	}
+
+	commit := commitRangeView.commits[rowIndex-1]
+	commitRangeView.variables.SetViewVariable(VarCommit, commit.oid.String(), commitRangeView.viewState)
+
+	for _, commitRangeViewListener := range commitRangeView.commitRangeViewListeners {
+		if err = commitRangeViewListener.OnCommitSelected(commit); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// HandleAction checks if the commit range view supports the provided action and executes it if so
+func (commitRangeView *CommitRangeView) HandleAction(action Action) (err error) {
+	commitRangeView.lock.Lock()
+	defer commitRangeView.lock.Unlock()
+
+	var handled bool
+	if handled, err = commitRangeView.AbstractWindowView.HandleAction(action); handled {
+		log.Debugf("Action handled by AbstractWindowView")
+	} else {
+		log.Debugf("Action not handled")
+	}
+
+	return
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -12,7 +13,6 @@ import (
 
 const (
 	cvLoadRefreshMs                     = 500
-	cvColumnNum                         = 4
 	cvDateFormat                        = "2006-01-02 15:04"
 	cvCommitGraphLoadRequestChannelSize = 10
 	cvCommitSelectedChannelSize         = 100
@@ -32,6 +32,7 @@ type referenceViewData struct {
 	viewPos        ViewPos
 	tableFormatter *TableFormatter
 	commitGraph    *CommitGraph
+	filterQueries  []string
 }
 
 type commitGraphLoadRequest struct {
@@ -53,6 +54,7 @@ type CommitView struct {
 	config                 Config
 	activeRef              Ref
 	refViewData            map[string]*referenceViewData
+	columns                []*commitViewColumn
 	handlers               map[ActionType]commitViewHandler
 	refreshTask            *loadingCommitsRefreshTask
 	commitViewListeners    []CommitViewListener
@@ -62,6 +64,7 @@ type CommitView struct {
 	lastDotRenderTime      time.Time
 	commitGraphLoadCh      chan commitGraphLoadRequest
 	commitSelectedCh       chan *Commit
+	pendingSelectedOid     string
 	variables              GRVVariableSetter
 	waitGroup              sync.WaitGroup
 	lock                   sync.Mutex
@@ -82,17 +85,23 @@ func NewCommitView(repoData RepoData, repoController RepoController, channels Ch
 		handlers: map[ActionType]commitViewHandler{
 			ActionAddFilter:               addCommitFilter,
 			ActionRemoveFilter:            removeCommitFilter,
+			ActionExport:                  exportCommits,
 			ActionSelect:                  selectCommit,
 			ActionCheckoutCommit:          checkoutCommit,
 			ActionCreateBranch:            createBranchFromCommit,
 			ActionCreateBranchAndCheckout: createBranchFromCommitAndCheckout,
 			ActionCreateTag:               createTagFromCommit,
 			ActionCreateAnnotatedTag:      createAnnotatedTagFromCommit,
+			ActionAddNote:                 addNoteToCommit,
+			ActionEditNote:                editNoteOnCommit,
+			ActionRemoveNote:              removeNoteFromCommit,
+			ActionShowCommitInfo:          showCommitInfo,
 			ActionShowAvailableActions:    showActionsForCommit,
 		},
 	}
 
 	commitView.AbstractWindowView = NewAbstractWindowView(commitView, channels, config, variables, &commitView.lock, "commit")
+	commitView.columns = commitView.parseColumns()
 
 	return commitView
 }
@@ -110,6 +119,8 @@ func (commitView *CommitView) Initialise() (err error) {
 	go commitView.processCommitGraphLoadRequests()
 	go commitView.processSelectedCommits()
 
+	commitView.config.AddOnChangeListener(CfCommitViewColumns, commitView)
+
 	return
 }
 
@@ -244,25 +255,83 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 }
 
 func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, rowIndex uint, commit *Commit) (err error) {
-	author := commit.commit.Author()
-	commitRefs := commitView.repoData.RefsForCommit(commit)
-	colIndex := uint(0)
-
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewShortOid, "%v", commit.oid.ShortID()); err != nil {
-		return
+	for colIndex, column := range commitView.columns {
+		for _, entry := range column.entries {
+			if err = commitView.renderCommitField(tableFormatter, rowIndex, uint(colIndex), entry, commit); err != nil {
+				return
+			}
+		}
 	}
 
-	colIndex++
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewDate, "%v", author.When.Format(cvDateFormat)); err != nil {
+	return
+}
+
+func (commitView *CommitView) renderCommitField(tableFormatter *TableFormatter, rowIndex, colIndex uint, entry commitViewColumnEntry, commit *Commit) (err error) {
+	var value string
+
+	switch entry.field {
+	case cvfLiteral:
+		value = entry.text
+	case cvfOid:
+		value = commit.oid.String()
+	case cvfShortOid:
+		value = commit.oid.ShortID()
+	case cvfAuthorName:
+		value = commit.commit.Author().Name
+	case cvfAuthorEmail:
+		value = commit.commit.Author().Email
+	case cvfAuthorDate:
+		value = commit.commit.Author().When.Format(cvDateFormat)
+	case cvfAuthorDateLocal:
+		value = commit.commit.Author().When.Local().Format(cvDateFormat)
+	case cvfAuthorDateRelative:
+		value = RelativeTime(commit.commit.Author().When, time.Now())
+	case cvfCommitterName:
+		value = commit.commit.Committer().Name
+	case cvfCommitterEmail:
+		value = commit.commit.Committer().Email
+	case cvfCommitterDate:
+		value = commit.commit.Committer().When.Format(cvDateFormat)
+	case cvfCommitterDateLocal:
+		value = commit.commit.Committer().When.Local().Format(cvDateFormat)
+	case cvfCommitterDateRelative:
+		value = RelativeTime(commit.commit.Committer().When, time.Now())
+	case cvfRefs:
+		return commitView.renderCommitRefs(tableFormatter, rowIndex, colIndex, commit)
+	case cvfSummary:
+		value = commit.commit.Summary()
+	case cvfSignature:
+		return commitView.renderCommitSignature(tableFormatter, rowIndex, colIndex, commit)
+	default:
+		return fmt.Errorf("Unsupported commit view field: %v", entry.field)
+	}
+
+	return tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, entry.themeComponentID, "%v", value)
+}
+
+// renderCommitSignature displays a marker for the signature status of the commit.
+// Nothing is displayed for unsigned commits or while the signature is being verified
+func (commitView *CommitView) renderCommitSignature(tableFormatter *TableFormatter, rowIndex, colIndex uint, commit *Commit) (err error) {
+	verification, verified := commitView.repoData.SignatureVerification(commit.oid)
+	if !verified {
 		return
 	}
 
-	colIndex++
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewAuthor, "%v", author.Name); err != nil {
-		return
+	switch verification.Status() {
+	case SignatureGood:
+		err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSignatureGood, "G")
+	case SignatureBad:
+		err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSignatureBad, "B")
+	case SignatureUnknown:
+		err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSignatureUnknown, "U")
 	}
 
-	colIndex++
+	return
+}
+
+func (commitView *CommitView) renderCommitRefs(tableFormatter *TableFormatter, rowIndex, colIndex uint, commit *Commit) (err error) {
+	commitRefs := commitView.repoData.RefsForCommit(commit)
+
 	if len(commitRefs.tags) > 0 {
 		for _, tag := range commitRefs.tags {
 			if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewTag, "<%v>", tag.Shorthand()); err != nil {
@@ -293,13 +362,62 @@ func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, rowIn
 		}
 	}
 
-	if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, "%v", commit.commit.Summary()); err != nil {
-		return
+	return
+}
+
+func (commitView *CommitView) parseColumns() []*commitViewColumn {
+	columns, err := ParseCommitViewColumns(commitView.config.GetString(CfCommitViewColumns))
+	if err != nil {
+		log.Errorf("Unable to parse commit view columns: %v", err)
+		columns, _ = ParseCommitViewColumns(cfCommitViewColumnsDefaultValue)
+	}
+
+	return columns
+}
+
+func (commitView *CommitView) createTableFormatter() (tableFormatter *TableFormatter, err error) {
+	tableFormatter = NewTableFormatter(uint(len(commitView.columns)), commitView.config)
+	commitGraphColIndex := uint(len(commitView.columns) - 1)
+
+	for colIndex, column := range commitView.columns {
+		if column.width > 0 {
+			if err = tableFormatter.SetColumnWidth(uint(colIndex), column.width); err != nil {
+				return
+			}
+		}
+
+		if (column.hasField(cvfRefs) || column.hasField(cvfSummary)) && uint(colIndex) < commitGraphColIndex {
+			commitGraphColIndex = uint(colIndex)
+		}
 	}
 
+	err = tableFormatter.SetCellRendererListener(commitGraphColIndex, commitView)
+
 	return
 }
 
+func (commitView *CommitView) onConfigVariableChange(configVariable ConfigVariable) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	switch configVariable {
+	case CfCommitViewColumns:
+		commitView.columns = commitView.parseColumns()
+
+		for refName, refViewData := range commitView.refViewData {
+			tableFormatter, err := commitView.createTableFormatter()
+			if err != nil {
+				log.Errorf("Unable to create commit view table formatter for ref %v: %v", refName, err)
+				continue
+			}
+
+			refViewData.tableFormatter = tableFormatter
+		}
+
+		commitView.channels.UpdateDisplay()
+	}
+}
+
 // RenderHelpBar shows key bindings custom to the commit view
 func (commitView *CommitView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 	RenderKeyBindingHelp(commitView.ViewID(), lineBuilder, commitView.config, []ActionMessage{
@@ -369,16 +487,17 @@ func (commitView *CommitView) OnRefSelect(ref Ref) (err error) {
 
 	refViewData, refViewDataExists := commitView.refViewData[ref.Name()]
 	if !refViewDataExists {
+		var tableFormatter *TableFormatter
+		if tableFormatter, err = commitView.createTableFormatter(); err != nil {
+			return
+		}
+
 		refViewData = &referenceViewData{
 			viewPos:        NewViewPosition(),
-			tableFormatter: NewTableFormatter(cvColumnNum, commitView.config),
+			tableFormatter: tableFormatter,
 			commitGraph:    NewCommitGraph(commitView.repoData),
 		}
 
-		if err = refViewData.tableFormatter.SetCellRendererListener(3, commitView); err != nil {
-			return
-		}
-
 		commitView.refViewData[ref.Name()] = refViewData
 	}
 
@@ -422,17 +541,35 @@ func (commitView *CommitView) OnCommitsLoaded(ref Ref) {
 
 	commitSetState := commitView.repoData.CommitSetState(ref)
 	commitView.channels.ReportStatus("Loaded %v commits for ref %v", commitSetState.commitNum, ref.Shorthand())
+
+	if commitView.pendingSelectedOid != "" && commitView.activeRef.Name() == ref.Name() {
+		commitView.selectPendingCommit()
+	}
 }
 
-// OnCommitsUpdated adjusts the active row index to take account of the newly loaded commits
+// OnCommitsUpdated adjusts the active row index to take account of the newly loaded commits.
+// A commit pending selection after the session state was restored is selected instead
 func (commitView *CommitView) OnCommitsUpdated(ref Ref) {
 	commitView.lock.Lock()
 	defer commitView.lock.Unlock()
 
 	if commitView.activeRef.Name() == ref.Name() {
+		if commitView.pendingSelectedOid != "" {
+			commitView.selectPendingCommit()
+			return
+		}
+
 		commitSetState := commitView.repoData.CommitSetState(ref)
 		if commitSetState.filterState != nil {
-			log.Debugf("Filters applied - leaving active row index unchanged")
+			log.Debugf("Filters applied - selecting commit at active row index")
+
+			if activeRowIndex := commitView.viewPos().ActiveRowIndex(); activeRowIndex < commitSetState.commitNum {
+				if err := commitView.selectCommit(activeRowIndex); err != nil {
+					commitView.channels.ReportError(err)
+				}
+			}
+
+			commitView.channels.UpdateDisplay()
 			return
 		}
 
@@ -454,6 +591,129 @@ func (commitView *CommitView) OnCommitsUpdated(ref Ref) {
 	}
 }
 
+// SessionState returns the selected ref, filters and selected commit of this view
+func (commitView *CommitView) SessionState() *SessionViewState {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	sessionViewState := &SessionViewState{
+		View: ViewName(commitView.ViewID()),
+	}
+
+	if commitView.activeRef == nil {
+		return sessionViewState
+	}
+
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+	commitViewState := &CommitViewSessionState{
+		Ref:     commitView.activeRef.Name(),
+		Filters: append([]string(nil), refViewData.filterQueries...),
+	}
+
+	if commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, refViewData.viewPos.ActiveRowIndex()); err == nil {
+		commitViewState.SelectedOid = commit.oid.String()
+	}
+
+	sessionViewState.CommitView = commitViewState
+
+	return sessionViewState
+}
+
+// RestoreSessionState loads the stored ref, reapplies any filters and
+// selects the stored commit once it has been loaded
+func (commitView *CommitView) RestoreSessionState(sessionViewState *SessionViewState) (err error) {
+	commitViewState := sessionViewState.CommitView
+	if commitViewState == nil || commitViewState.Ref == "" {
+		return
+	}
+
+	ref, err := commitView.repoData.Ref(commitViewState.Ref)
+	if err != nil {
+		return fmt.Errorf("Unable to restore CommitView ref %v: %v", commitViewState.Ref, err)
+	}
+
+	if err = commitView.OnRefSelect(ref); err != nil {
+		return
+	}
+
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	refViewData := commitView.refViewData[ref.Name()]
+
+	for _, query := range commitViewState.Filters {
+		commitFilter, errors := CreateCommitFilter(query, commitView.repoData, commitView.config)
+		if len(errors) > 0 {
+			commitView.channels.ReportErrors(errors)
+			continue
+		} else if commitFilter == nil {
+			continue
+		}
+
+		if err = commitView.repoData.AddCommitFilter(ref, commitFilter); err != nil {
+			return
+		}
+
+		refViewData.filterQueries = append(refViewData.filterQueries, query)
+	}
+
+	// Commits are selected once they have loaded and any filters have been applied.
+	// Listeners are notified of both, so only an unfiltered loaded commit set is searched immediately
+	commitView.pendingSelectedOid = commitViewState.SelectedOid
+	if commitView.pendingSelectedOid == "" || commitView.repoData.CommitSetState(ref).loading || len(refViewData.filterQueries) > 0 {
+		return
+	}
+
+	commitView.selectPendingCommit()
+
+	return
+}
+
+// selectPendingCommit selects the commit pending selection if it has been loaded.
+// The commit remains pending if it has not been found while commits are still loading or filters are being applied
+func (commitView *CommitView) selectPendingCommit() {
+	oid := commitView.pendingSelectedOid
+
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+	commitCh, err := commitView.repoData.Commits(commitView.activeRef, 0, commitSetState.commitNum)
+	if err != nil {
+		commitView.pendingSelectedOid = ""
+		log.Errorf("Unable to select commit %v: %v", oid, err)
+		return
+	}
+
+	commitIndex := uint(0)
+	found := false
+
+	for commit := range commitCh {
+		if !found && commit.oid.String() == oid {
+			found = true
+		} else if !found {
+			commitIndex++
+		}
+	}
+
+	if !found {
+		if commitSetState.loading || (commitSetState.filterState != nil && commitSetState.filterState.initialising) {
+			log.Debugf("Commit %v has not been loaded yet for ref %v", oid, commitView.activeRef.Name())
+			return
+		}
+
+		commitView.pendingSelectedOid = ""
+		log.Infof("Commit %v is no longer present for ref %v", oid, commitView.activeRef.Name())
+		return
+	}
+
+	commitView.pendingSelectedOid = ""
+
+	if err = commitView.selectCommit(commitIndex); err != nil {
+		log.Errorf("Unable to select commit %v: %v", oid, err)
+		return
+	}
+
+	commitView.channels.UpdateDisplay()
+}
+
 func (commitView *CommitView) preRenderCell(rowIndex, colIndex uint, lineBuilder *LineBuilder, tableCell *TableCell) (err error) {
 	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
 
@@ -467,9 +727,12 @@ func (commitView *CommitView) preRenderCell(rowIndex, colIndex uint, lineBuilder
 				ref:         commitView.activeRef,
 			}
 
+			pendingBackgroundWork.Start()
+
 			select {
 			case commitView.commitGraphLoadCh <- request:
 			default:
+				pendingBackgroundWork.Complete()
 			}
 		} else {
 			refViewData.commitGraph.Render(lineBuilder, commitIndex)
@@ -516,6 +779,7 @@ func (commitView *CommitView) commitViewListenerCount() uint {
 
 func (commitView *CommitView) notifyCommitViewListeners(commit *Commit) {
 	if commitView.commitSelectedCh != nil {
+		pendingBackgroundWork.Start()
 		commitView.commitSelectedCh <- commit
 	}
 
@@ -534,6 +798,8 @@ func (commitView *CommitView) processSelectedCommits() {
 				commitView.channels.ReportError(err)
 			}
 		}
+
+		pendingBackgroundWork.Complete()
 	}
 }
 
@@ -699,13 +965,13 @@ func (commitView *CommitView) processCommitGraphLoadRequests() {
 		log.Debugf("Processing commit graph load request: %v:%v", request.ref.Name(), request.commitIndex)
 		activeRef, commitGraph := commitView.retriveDataForCommitGraphLoadRequest(request)
 
-		if commitGraph == nil {
-			continue
+		if commitGraph != nil {
+			if err := commitView.processCommitGraphLoadRequest(request, commitGraph, activeRef); err != nil {
+				log.Errorf("Failed to process CommitGraph load request: %v", err)
+			}
 		}
 
-		if err := commitView.processCommitGraphLoadRequest(request, commitGraph, activeRef); err != nil {
-			log.Errorf("Failed to process CommitGraph load request: %v", err)
-		}
+		pendingBackgroundWork.Complete()
 	}
 
 	log.Info("Finished processing commit graph load requests")
@@ -717,6 +983,8 @@ func (commitView *CommitView) retrieveLatestCommitGraphLoadRequest(request commi
 	for requestFound {
 		select {
 		case request = <-commitView.commitGraphLoadCh:
+			// The superseded request is complete as it will not be processed
+			pendingBackgroundWork.Complete()
 		default:
 			requestFound = false
 		}
@@ -805,7 +1073,7 @@ func addCommitFilter(commitView *CommitView, action Action) (err error) {
 		return fmt.Errorf("Expected filter query argument to have type string")
 	}
 
-	commitFilter, errors := CreateCommitFilter(query)
+	commitFilter, errors := CreateCommitFilter(query, commitView.repoData, commitView.config)
 	if len(errors) > 0 {
 		commitView.channels.ReportErrors(errors)
 		return
@@ -818,21 +1086,10 @@ func addCommitFilter(commitView *CommitView, action Action) (err error) {
 		return
 	}
 
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+	refViewData.filterQueries = append(refViewData.filterQueries, query)
+	// The first commit is selected once listeners are notified the filter has been applied
 	commitView.viewPos().SetActiveRowIndex(0)
-
-	go func() {
-		// TODO: Works in practice, but there is no guarantee the filtered commit set will have
-		// been populated after 250ms. Need an event based mechanism to be notified when a filtered
-		// set has started to be populated so that the first commit can be selected
-		time.Sleep(250 * time.Millisecond)
-		commitView.lock.Lock()
-		defer commitView.lock.Unlock()
-
-		if err := commitView.selectCommit(commitView.viewPos().ActiveRowIndex()); err != nil {
-			log.Errorf("Unable to select commit after filter has been applied: %v", err)
-		}
-	}()
-
 	commitView.channels.UpdateDisplay()
 
 	return
@@ -843,6 +1100,11 @@ func removeCommitFilter(commitView *CommitView, action Action) (err error) {
 		return
 	}
 
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+	if filterNum := len(refViewData.filterQueries); filterNum > 0 {
+		refViewData.filterQueries = refViewData.filterQueries[:filterNum-1]
+	}
+
 	if err = commitView.selectCommit(0); err != nil {
 		return
 	}
@@ -852,6 +1114,82 @@ func removeCommitFilter(commitView *CommitView, action Action) (err error) {
 	return
 }
 
+func exportCommits(commitView *CommitView, action Action) (err error) {
+	filePath, format, err := exportActionArgs(action)
+	if err != nil {
+		return
+	}
+
+	commitFieldDescriptor := NewCommitFieldDescriptor(commitView.repoData, commitView.config)
+	fieldNames := exportedCommitFieldNames()
+	fields := append(fieldNames, "refs")
+
+	repoData := commitView.repoData
+	ref := commitView.activeRef
+	commitNum := repoData.CommitSetState(ref).commitNum
+
+	runExport(commitView.channels, "commits", filePath, format, fields, func(recordWriter RecordWriter) (recordNum uint, err error) {
+		commitCh, err := repoData.Commits(ref, 0, commitNum)
+		if err != nil {
+			return
+		}
+
+		defer func() {
+			// Drain any remaining commits so the producing go routine can exit
+			for range commitCh {
+			}
+		}()
+
+		values := make([]interface{}, len(fields))
+
+		for commit := range commitCh {
+			for fieldIndex, fieldName := range fieldNames {
+				values[fieldIndex] = commitFields[fieldName].value(commit, commitFieldDescriptor)
+			}
+
+			values[len(fieldNames)] = commitRefNames(repoData.RefsForCommit(commit))
+
+			if err = recordWriter.WriteRecord(values); err != nil {
+				return
+			}
+
+			recordNum++
+		}
+
+		return
+	})
+
+	return
+}
+
+// exportedCommitFieldNames returns the sorted names of the commit fields which are exported.
+// Expensive fields are excluded as an export would otherwise compute them for every loaded commit
+func exportedCommitFieldNames() (fieldNames []string) {
+	for fieldName, commitField := range commitFields {
+		if !commitField.expensive {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+
+	sort.Strings(fieldNames)
+
+	return
+}
+
+func commitRefNames(commitRefs *CommitRefs) (refNames []string) {
+	refNames = []string{}
+
+	for _, tag := range commitRefs.tags {
+		refNames = append(refNames, tag.Shorthand())
+	}
+
+	for _, branch := range commitRefs.branches {
+		refNames = append(refNames, branch.Shorthand())
+	}
+
+	return
+}
+
 func selectCommit(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.viewPos()
 
@@ -994,6 +1332,94 @@ func createAnnotatedTagFromCommit(commitView *CommitView, action Action) (err er
 	return
 }
 
+func (commitView *CommitView) selectedCommit() (commit *Commit, err error) {
+	if commitView.rows() == 0 {
+		return
+	}
+
+	viewPos := commitView.viewPos()
+	return commitView.repoData.CommitByIndex(commitView.activeRef, viewPos.ActiveRowIndex())
+}
+
+func addNoteToCommit(commitView *CommitView, action Action) (err error) {
+	commit, err := commitView.selectedCommit()
+	if commit == nil || err != nil {
+		return
+	}
+
+	commitView.repoController.AddNote(commit.oid, func(err error) {
+		if err != nil {
+			commitView.channels.ReportError(fmt.Errorf("Failed to add note to commit: %v", err))
+			return
+		}
+
+		commitView.channels.ReportStatus("Added note to %v", commit.oid.ShortID())
+	})
+
+	return
+}
+
+func editNoteOnCommit(commitView *CommitView, action Action) (err error) {
+	commit, err := commitView.selectedCommit()
+	if commit == nil || err != nil {
+		return
+	}
+
+	commitView.repoController.EditNote(commit.oid, func(err error) {
+		if err != nil {
+			commitView.channels.ReportError(fmt.Errorf("Failed to edit note on commit: %v", err))
+			return
+		}
+
+		commitView.channels.ReportStatus("Edited note on %v", commit.oid.ShortID())
+	})
+
+	return
+}
+
+func removeNoteFromCommit(commitView *CommitView, action Action) (err error) {
+	commit, err := commitView.selectedCommit()
+	if commit == nil || err != nil {
+		return
+	}
+
+	question := fmt.Sprintf("Are you sure you want to remove the note from %v?", commit.oid.ShortID())
+
+	commitView.channels.DoAction(YesNoQuestion(question, func(response QuestionResponse) {
+		if response == ResponseNo {
+			return
+		}
+
+		if err := commitView.repoController.RemoveNote(commit.oid); err != nil {
+			commitView.channels.ReportError(fmt.Errorf("Failed to remove note from commit: %v", err))
+			return
+		}
+
+		commitView.channels.ReportStatus("Removed note from %v", commit.oid.ShortID())
+	}))
+
+	return
+}
+
+func showCommitInfo(commitView *CommitView, action Action) (err error) {
+	commit, err := commitView.selectedCommit()
+	if commit == nil || err != nil {
+		return
+	}
+
+	commitView.channels.DoAction(Action{ActionType: ActionCreateCommitInfoView, Args: []interface{}{
+		ActionCreateCommitInfoViewArgs{
+			commit: commit,
+			viewDimension: ViewDimension{
+				rows: 24,
+				cols: 80,
+			},
+		},
+	}})
+
+	return
+}
+
 func showActionsForCommit(commitView *CommitView, action Action) (err error) {
 	if commitView.rows() == 0 {
 		return
@@ -1012,7 +1438,7 @@ func showActionsForCommit(commitView *CommitView, action Action) (err error) {
 		Args: []interface{}{
 			ActionCreateContextMenuArgs{
 				viewDimension: ViewDimension{
-					rows: 10,
+					rows: 14,
 					cols: 60,
 				},
 				config: ContextMenuConfig{
@@ -1038,6 +1464,22 @@ func showActionsForCommit(commitView *CommitView, action Action) (err error) {
 							DisplayName: "Create annotated tag at commit",
 							Value:       Action{ActionType: ActionCreateAnnotatedTag},
 						},
+						{
+							DisplayName: "Add note to commit",
+							Value:       Action{ActionType: ActionAddNote},
+						},
+						{
+							DisplayName: "Edit note on commit",
+							Value:       Action{ActionType: ActionEditNote},
+						},
+						{
+							DisplayName: "Remove note from commit",
+							Value:       Action{ActionType: ActionRemoveNote},
+						},
+						{
+							DisplayName: "Show branches and tags containing commit",
+							Value:       Action{ActionType: ActionShowCommitInfo},
+						},
 						{
 							DisplayName: fmt.Sprintf(`Filter commits by author "%v"`, commitAuthor),
 							Value: Action{
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	cvcFieldPrefix = '%'
+	cvcWidthPrefix = "%<("
+	cvcWidthSuffix = ')'
+)
+
+type commitViewField int
+
+const (
+	cvfLiteral commitViewField = iota
+	cvfOid
+	cvfShortOid
+	cvfAuthorName
+	cvfAuthorEmail
+	cvfAuthorDate
+	cvfAuthorDateLocal
+	cvfAuthorDateRelative
+	cvfCommitterName
+	cvfCommitterEmail
+	cvfCommitterDate
+	cvfCommitterDateLocal
+	cvfCommitterDateRelative
+	cvfRefs
+	cvfSummary
+	cvfSignature
+)
+
+type commitViewFieldDescriptor struct {
+	specifier        string
+	field            commitViewField
+	themeComponentID ThemeComponentID
+	description      string
+}
+
+// When specifiers share a prefix the longest matching specifier is used
+var commitViewFieldDescriptors = []commitViewFieldDescriptor{
+	{specifier: "H", field: cvfOid, themeComponentID: CmpCommitviewOid, description: "Commit oid"},
+	{specifier: "h", field: cvfShortOid, themeComponentID: CmpCommitviewShortOid, description: "Abbreviated commit oid"},
+	{specifier: "an", field: cvfAuthorName, themeComponentID: CmpCommitviewAuthor, description: "Author name"},
+	{specifier: "ae", field: cvfAuthorEmail, themeComponentID: CmpCommitviewAuthor, description: "Author email"},
+	{specifier: "adl", field: cvfAuthorDateLocal, themeComponentID: CmpCommitviewDate, description: "Author date in the local timezone"},
+	{specifier: "ad", field: cvfAuthorDate, themeComponentID: CmpCommitviewDate, description: "Author date"},
+	{specifier: "ar", field: cvfAuthorDateRelative, themeComponentID: CmpCommitviewDate, description: "Author date relative to now"},
+	{specifier: "cn", field: cvfCommitterName, themeComponentID: CmpCommitviewCommitter, description: "Committer name"},
+	{specifier: "ce", field: cvfCommitterEmail, themeComponentID: CmpCommitviewCommitter, description: "Committer email"},
+	{specifier: "cdl", field: cvfCommitterDateLocal, themeComponentID: CmpCommitviewCommitterDate, description: "Committer date in the local timezone"},
+	{specifier: "cd", field: cvfCommitterDate, themeComponentID: CmpCommitviewCommitterDate, description: "Committer date"},
+	{specifier: "cr", field: cvfCommitterDateRelative, themeComponentID: CmpCommitviewCommitterDate, description: "Committer date relative to now"},
+	{specifier: "d", field: cvfRefs, themeComponentID: CmpNone, description: "Tags and branches pointing to the commit"},
+	{specifier: "s", field: cvfSummary, themeComponentID: CmpCommitviewSummary, description: "Commit summary"},
+	{specifier: "G?", field: cvfSignature, themeComponentID: CmpNone, description: "Signature: G good, B bad, U unknown"},
+}
+
+type commitViewColumnEntry struct {
+	field            commitViewField
+	text             string
+	themeComponentID ThemeComponentID
+}
+
+type commitViewColumn struct {
+	entries []commitViewColumnEntry
+	width   uint
+}
+
+func (column *commitViewColumn) hasField(field commitViewField) bool {
+	for _, entry := range column.entries {
+		if entry.field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (column *commitViewColumn) isRefsOnly() bool {
+	return column.width == 0 && len(column.entries) == 1 && column.entries[0].field == cvfRefs
+}
+
+// ParseCommitViewColumns parses a commit view column format string.
+// Columns are separated by whitespace and each column can contain any number of
+// field specifiers and literal text. A column consisting only of refs (%d) is
+// rendered at the start of the column that follows it
+func ParseCommitViewColumns(format string) (columns []*commitViewColumn, err error) {
+	tokens := strings.Fields(format)
+	var refsEntries []commitViewColumnEntry
+
+	for tokenIndex, token := range tokens {
+		var column *commitViewColumn
+		if column, err = parseCommitViewColumn(token); err != nil {
+			return
+		}
+
+		if column.isRefsOnly() && tokenIndex != len(tokens)-1 {
+			refsEntries = append(refsEntries, column.entries...)
+			continue
+		}
+
+		column.entries = append(refsEntries, column.entries...)
+		refsEntries = nil
+
+		columns = append(columns, column)
+	}
+
+	if len(columns) == 0 {
+		err = fmt.Errorf("Commit view column format must contain at least one column")
+	}
+
+	return
+}
+
+func parseCommitViewColumn(token string) (column *commitViewColumn, err error) {
+	column = &commitViewColumn{}
+	remaining := token
+
+	if strings.HasPrefix(remaining, cvcWidthPrefix) {
+		widthEndIndex := strings.IndexByte(remaining, cvcWidthSuffix)
+		if widthEndIndex == -1 {
+			err = fmt.Errorf("Unterminated column width in column \"%v\"", token)
+			return
+		}
+
+		widthValue := remaining[len(cvcWidthPrefix):widthEndIndex]
+		width, parseErr := strconv.ParseUint(widthValue, 10, 32)
+		if parseErr != nil || width == 0 {
+			err = fmt.Errorf("Invalid column width \"%v\" in column \"%v\"", widthValue, token)
+			return
+		}
+
+		column.width = uint(width)
+		remaining = remaining[widthEndIndex+1:]
+	}
+
+	var literal bytes.Buffer
+
+	appendLiteral := func() {
+		if literal.Len() > 0 {
+			column.entries = append(column.entries, commitViewColumnEntry{
+				field:            cvfLiteral,
+				text:             literal.String(),
+				themeComponentID: CmpNone,
+			})
+
+			literal.Reset()
+		}
+	}
+
+	for index := 0; index < len(remaining); {
+		if remaining[index] != cvcFieldPrefix {
+			literal.WriteByte(remaining[index])
+			index++
+			continue
+		}
+
+		specifier := remaining[index+1:]
+
+		if len(specifier) > 0 && specifier[0] == cvcFieldPrefix {
+			literal.WriteByte(cvcFieldPrefix)
+			index += 2
+			continue
+		}
+
+		descriptor, found := matchCommitViewField(specifier)
+		if !found {
+			err = fmt.Errorf("Invalid field specifier \"%v\" in column \"%v\"", remaining[index:], token)
+			return
+		}
+
+		appendLiteral()
+
+		column.entries = append(column.entries, commitViewColumnEntry{
+			field:            descriptor.field,
+			themeComponentID: descriptor.themeComponentID,
+		})
+
+		index += len(descriptor.specifier) + 1
+	}
+
+	appendLiteral()
+
+	if len(column.entries) == 0 {
+		err = fmt.Errorf("Column \"%v\" contains no content", token)
+	}
+
+	return
+}
+
+func matchCommitViewField(specifier string) (descriptor commitViewFieldDescriptor, found bool) {
+	for _, fieldDescriptor := range commitViewFieldDescriptors {
+		if strings.HasPrefix(specifier, fieldDescriptor.specifier) &&
+			(!found || len(fieldDescriptor.specifier) > len(descriptor.specifier)) {
+			descriptor = fieldDescriptor
+			found = true
+		}
+	}
+
+	return
+}
+
+type commitViewColumnsValidator struct{}
+
+func (commitViewColumnsValidator *commitViewColumnsValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseCommitViewColumns(value); err != nil {
+		err = fmt.Errorf("Invalid %v value %v: %v", CfCommitViewColumns, value, err)
+	} else {
+		processedValue = value
+	}
+
+	return
+}
+
+// GenerateCommitViewColumnsHelpSection generates documentation for the fields that can be displayed in the commit view
+func GenerateCommitViewColumnsHelpSection(config Config) *HelpSection {
+	headers := []TableHeader{
+		{text: "Specifier", themeComponentID: CmpHelpViewSectionTableHeader},
+		{text: "Description", themeComponentID: CmpHelpViewSectionTableHeader},
+	}
+
+	tableFormatter := NewTableFormatterWithHeaders(headers, config)
+	tableFormatter.SetGridLines(true)
+
+	tableFormatter.Resize(uint(len(commitViewFieldDescriptors)))
+
+	for rowIndex, descriptor := range commitViewFieldDescriptors {
+		tableFormatter.SetCellWithStyle(uint(rowIndex), 0, CmpHelpViewSectionTableRow, "%c%v", cvcFieldPrefix, descriptor.specifier)
+		tableFormatter.SetCellWithStyle(uint(rowIndex), 1, CmpHelpViewSectionTableRow, "%v", descriptor.description)
+	}
+
+	return &HelpSection{
+		title: HelpSectionText{text: "Commit View Columns"},
+		description: []HelpSectionText{
+			{text: "The columns displayed in the commit view are configured using the commit-view-columns variable."},
+			{text: "Columns are separated by whitespace and may contain any of the field specifiers below along with literal text."},
+			{text: "A column can be given a fixed width by prefixing it with %<(N), where N is the width. Text exceeding the width is truncated."},
+			{text: "Use %% to display a literal percent sign. A column containing only %d is displayed at the start of the following column."},
+			{text: "For example, to display the relative committer date with a fixed width author column:"},
+			{},
+			{text: "set commit-view-columns \"%h %cr %<(15)%an %d %s\"", themeComponentID: CmpHelpViewSectionCodeBlock},
+			{},
+			{text: "The fields available are:"},
+		},
+		tableFormatter: tableFormatter,
+	}
+}
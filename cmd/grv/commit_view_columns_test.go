@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidCommitViewColumnFormatsAreParsed(t *testing.T) {
+	var tests = []struct {
+		format          string
+		expectedColumns []*commitViewColumn
+	}{
+		{
+			format: cfCommitViewColumnsDefaultValue,
+			expectedColumns: []*commitViewColumn{
+				{entries: []commitViewColumnEntry{{field: cvfShortOid, themeComponentID: CmpCommitviewShortOid}}},
+				{entries: []commitViewColumnEntry{{field: cvfAuthorDate, themeComponentID: CmpCommitviewDate}}},
+				{entries: []commitViewColumnEntry{{field: cvfAuthorName, themeComponentID: CmpCommitviewAuthor}}},
+				{entries: []commitViewColumnEntry{
+					{field: cvfRefs, themeComponentID: CmpNone},
+					{field: cvfSummary, themeComponentID: CmpCommitviewSummary},
+				}},
+			},
+		},
+		{
+			format: "%H  %<(20)%cn",
+			expectedColumns: []*commitViewColumn{
+				{entries: []commitViewColumnEntry{{field: cvfOid, themeComponentID: CmpCommitviewOid}}},
+				{entries: []commitViewColumnEntry{{field: cvfCommitterName, themeComponentID: CmpCommitviewCommitter}}, width: 20},
+			},
+		},
+		{
+			format: "%adl %cr %d",
+			expectedColumns: []*commitViewColumn{
+				{entries: []commitViewColumnEntry{{field: cvfAuthorDateLocal, themeComponentID: CmpCommitviewDate}}},
+				{entries: []commitViewColumnEntry{{field: cvfCommitterDateRelative, themeComponentID: CmpCommitviewCommitterDate}}},
+				{entries: []commitViewColumnEntry{{field: cvfRefs, themeComponentID: CmpNone}}},
+			},
+		},
+		{
+			format: "%G?%h %s",
+			expectedColumns: []*commitViewColumn{
+				{entries: []commitViewColumnEntry{
+					{field: cvfSignature, themeComponentID: CmpNone},
+					{field: cvfShortOid, themeComponentID: CmpCommitviewShortOid},
+				}},
+				{entries: []commitViewColumnEntry{{field: cvfSummary, themeComponentID: CmpCommitviewSummary}}},
+			},
+		},
+		{
+			format: "<%ae>|100%%",
+			expectedColumns: []*commitViewColumn{
+				{entries: []commitViewColumnEntry{
+					{field: cvfLiteral, text: "<", themeComponentID: CmpNone},
+					{field: cvfAuthorEmail, themeComponentID: CmpCommitviewAuthor},
+					{field: cvfLiteral, text: ">|100%", themeComponentID: CmpNone},
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		columns, err := ParseCommitViewColumns(test.format)
+		if err != nil {
+			t.Errorf("ParseCommitViewColumns failed for format %v with error: %v", test.format, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(test.expectedColumns, columns) {
+			t.Errorf("Parsed columns do not match expected columns for format %v. Expected: %v, Actual: %v", test.format, test.expectedColumns, columns)
+		}
+	}
+}
+
+func TestInvalidCommitViewColumnFormatsAreRejected(t *testing.T) {
+	var formats = []string{
+		"",
+		"   ",
+		"%h %x",
+		"%h %",
+		"%<(10%an",
+		"%<(0)%an",
+		"%<(abc)%an",
+		"%<(10)",
+	}
+
+	for _, format := range formats {
+		if _, err := ParseCommitViewColumns(format); err == nil {
+			t.Errorf("Expected ParseCommitViewColumns to fail for format \"%v\"", format)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+)
+
+// NewCompareView creates a new instance of the compare view which lists the commits
+// unique to each of the provided refs and displays the diff of the selected entry
+func NewCompareView(repoData RepoData, channels Channels, config Config, variables GRVVariableSetter, refA, refB Ref) *ContainerView {
+	onlyInRefAView := NewCommitRangeView(repoData, channels, config, variables, refA, refB)
+	onlyInRefBView := NewCommitRangeView(repoData, channels, config, variables, refB, refA)
+	diffView := NewDiffView(repoData, channels, config, variables)
+
+	onlyInRefAView.RegisterCommitRangeViewListener(diffView)
+	onlyInRefBView.RegisterCommitRangeViewListener(diffView)
+
+	commitRangeContainer := NewContainerView(channels, config)
+	commitRangeContainer.SetOrientation(CoVertical)
+	commitRangeContainer.AddChildViews(onlyInRefAView, onlyInRefBView)
+
+	compareView := NewContainerView(channels, config)
+	compareView.SetTitle(CompareViewTitle(refA, refB))
+	compareView.SetOrientation(CoHorizontal)
+	compareView.SetViewID(ViewCompare)
+	compareView.AddChildViews(commitRangeContainer, diffView)
+
+	return compareView
+}
+
+// CompareViewTitle returns the title of the compare view for the provided refs
+func CompareViewTitle(refA, refB Ref) string {
+	return fmt.Sprintf("Compare %v...%v", refA.Shorthand(), refB.Shorthand())
+}
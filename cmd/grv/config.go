@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	log "github.com/Sirupsen/logrus"
@@ -17,23 +21,37 @@ import (
 )
 
 const (
-	cfDefaultConfigHomeDir                = "/.config"
-	cfGrvConfigDir                        = "/grv"
-	cfGrvrcFile                           = "/grvrc"
-	cfTabWidthMinValue                    = 1
-	cfTabWidthDefaultValue                = 8
-	cfClassicThemeName                    = "classic"
-	cfSolarizedThemeName                  = "solarized"
-	cfMouseDefaultValue                   = false
-	cfMouseScrollRowsDefaultValue         = 3
-	cfCommitGraphDefaultValue             = false
-	cfConfirmCheckoutDefaultValue         = true
-	cfPromptHistorySizeDefaultValue       = 1000
-	cfGitBinaryFilePathDefaultValue       = ""
-	cfCommitLimitDefaultValue             = "100000"
-	cfDefaultViewDefaultValue             = ""
-	cfDiffDisplayDefaultValue             = "fancy"
-	cfInputPromptAfterCommandDefaultValue = true
+	cfDefaultConfigHomeDir                   = "/.config"
+	cfGrvConfigDir                           = "/grv"
+	cfGrvrcFile                              = "/grvrc"
+	cfTabWidthMinValue                       = 1
+	cfTabWidthDefaultValue                   = 8
+	cfClassicThemeName                       = "classic"
+	cfSolarizedThemeName                     = "solarized"
+	cfSolarizedLightThemeName                = "solarized-light"
+	cfHighContrastThemeName                  = "high-contrast"
+	cfMonochromeThemeName                    = "monochrome"
+	cfNoColorEnvVariable                     = "NO_COLOR"
+	cfMouseDefaultValue                      = false
+	cfMouseScrollRowsDefaultValue            = 3
+	cfCommitGraphDefaultValue                = false
+	cfConfirmCheckoutDefaultValue            = true
+	cfPromptHistorySizeDefaultValue          = 1000
+	cfGitBinaryFilePathDefaultValue          = ""
+	cfCommitLimitDefaultValue                = "100000"
+	cfDefaultViewDefaultValue                = ""
+	cfDiffDisplayDefaultValue                = "fancy"
+	cfInputPromptAfterCommandDefaultValue    = true
+	cfCommitViewColumnsDefaultValue          = "%h %ad %an %d %s"
+	cfKeyHintTimeoutDefaultValue             = 1000
+	cfKeymapDefaultValue                     = kmViKeymapName
+	cfBuiltinCommitEditorDefaultValue        = false
+	cfCommitLintSummaryMaxLengthDefaultValue = 0
+	cfCommitLintBlankSecondLineDefaultValue  = false
+	cfCommitLintSummaryRegexDefaultValue     = ""
+	cfCommitLintRequiredTrailersDefaultValue = ""
+	cfSignatureVerifyCommandDefaultValue     = "gpg --verify"
+	cfNotesRefsDefaultValue                  = "refs/notes/commits"
 
 	cfAllView             = "All"
 	cfMainView            = "MainView"
@@ -57,6 +75,13 @@ const (
 	cfGRVVariableView     = "GRVVariableView"
 	cfRemoteView          = "RemoteView"
 	cfGitSummaryView      = "GitSummaryView"
+	cfKeyHintView         = "KeyHintView"
+	cfMacroView           = "MacroView"
+	cfFuzzyFinderView     = "FuzzyFinderView"
+	cfCommitMessageView   = "CommitMessageView"
+	cfCompareView         = "CompareView"
+	cfCommitRangeView     = "CommitRangeView"
+	cfCommitInfoView      = "CommitInfoView"
 )
 
 // ConfigVariable stores a config variable name
@@ -87,6 +112,28 @@ const (
 	CfDiffDisplay ConfigVariable = "diff-display"
 	// CfInputPromptAfterCommand stores whether the user is prompted for input after a command
 	CfInputPromptAfterCommand ConfigVariable = "input-prompt-after-command"
+	// CfCommitViewColumns stores the format of the columns displayed in the commit view
+	CfCommitViewColumns ConfigVariable = "commit-view-columns"
+	// CfKeyHintTimeout stores the number of milliseconds to wait before displaying key binding completions
+	CfKeyHintTimeout ConfigVariable = "key-hint-timeout"
+	// CfKeymap stores the name of the active keymap
+	CfKeymap ConfigVariable = "keymap"
+	// CfMonochrome stores whether the display uses text attributes and markers instead of color
+	CfMonochrome ConfigVariable = "monochrome"
+	// CfBuiltinCommitEditor stores whether commit messages are entered in grv instead of an external editor
+	CfBuiltinCommitEditor ConfigVariable = "builtin-commit-editor"
+	// CfCommitLintSummaryMaxLength stores the maximum number of characters permitted in a commit summary
+	CfCommitLintSummaryMaxLength ConfigVariable = "commit-lint-summary-max-length"
+	// CfCommitLintBlankSecondLine stores whether the line following the commit summary must be blank
+	CfCommitLintBlankSecondLine ConfigVariable = "commit-lint-blank-second-line"
+	// CfCommitLintSummaryRegex stores the regular expression commit summaries must match
+	CfCommitLintSummaryRegex ConfigVariable = "commit-lint-summary-regex"
+	// CfCommitLintRequiredTrailers stores the comma separated list of trailers commit messages must contain
+	CfCommitLintRequiredTrailers ConfigVariable = "commit-lint-required-trailers"
+	// CfSignatureVerifyCommand stores the command used to verify commit and tag signatures
+	CfSignatureVerifyCommand ConfigVariable = "signature-verify-command"
+	// CfNotesRefs stores the comma separated list of notes refs notes are loaded from
+	CfNotesRefs ConfigVariable = "notes-refs"
 )
 
 var systemColorValues = map[string]SystemColorValue{
@@ -124,6 +171,13 @@ var viewIDNames = map[string]ViewID{
 	cfGRVVariableView:     ViewGRVVariable,
 	cfRemoteView:          ViewRemote,
 	cfGitSummaryView:      ViewGitSummary,
+	cfKeyHintView:         ViewKeyHint,
+	cfMacroView:           ViewMacro,
+	cfFuzzyFinderView:     ViewFuzzyFinder,
+	cfCommitMessageView:   ViewCommitMessage,
+	cfCompareView:         ViewCompare,
+	cfCommitRangeView:     ViewCommitRange,
+	cfCommitInfoView:      ViewCommitInfo,
 }
 
 var themeComponents = map[string]ThemeComponentID{
@@ -147,9 +201,12 @@ var themeComponents = map[string]ThemeComponentID{
 
 	cfCommitView + ".Title":                  CmpCommitviewTitle,
 	cfCommitView + ".Footer":                 CmpCommitviewFooter,
+	cfCommitView + ".Oid":                    CmpCommitviewOid,
 	cfCommitView + ".ShortOid":               CmpCommitviewShortOid,
 	cfCommitView + ".Date":                   CmpCommitviewDate,
 	cfCommitView + ".Author":                 CmpCommitviewAuthor,
+	cfCommitView + ".Committer":              CmpCommitviewCommitter,
+	cfCommitView + ".CommitterDate":          CmpCommitviewCommitterDate,
 	cfCommitView + ".Summary":                CmpCommitviewSummary,
 	cfCommitView + ".Tag":                    CmpCommitviewTag,
 	cfCommitView + ".LocalBranch":            CmpCommitviewLocalBranch,
@@ -163,6 +220,9 @@ var themeComponents = map[string]ThemeComponentID{
 	cfCommitView + ".CommitGraphBranch5":     CmpCommitviewGraphBranch5,
 	cfCommitView + ".CommitGraphBranch6":     CmpCommitviewGraphBranch6,
 	cfCommitView + ".CommitGraphBranch7":     CmpCommitviewGraphBranch7,
+	cfCommitView + ".SignatureGood":          CmpCommitviewSignatureGood,
+	cfCommitView + ".SignatureBad":           CmpCommitviewSignatureBad,
+	cfCommitView + ".SignatureUnknown":       CmpCommitviewSignatureUnknown,
 
 	cfDiffView + ".Title":                   CmpDiffviewTitle,
 	cfDiffView + ".Footer":                  CmpDiffviewFooter,
@@ -171,7 +231,10 @@ var themeComponents = map[string]ThemeComponentID{
 	cfDiffView + ".CommitAuthorDate":        CmpDiffviewDifflineDiffCommitAuthorDate,
 	cfDiffView + ".CommitCommitter":         CmpDiffviewDifflineDiffCommitCommitter,
 	cfDiffView + ".CommitCommitterDate":     CmpDiffviewDifflineDiffCommitCommitterDate,
+	cfDiffView + ".CommitSignature":         CmpDiffviewDifflineDiffCommitSignature,
 	cfDiffView + ".CommitMessage":           CmpDiffviewDifflineDiffCommitMessage,
+	cfDiffView + ".CommitNote":              CmpDiffviewDifflineDiffCommitNote,
+	cfDiffView + ".Tag":                     CmpDiffviewDifflineDiffTag,
 	cfDiffView + ".StatsFile":               CmpDiffviewDifflineDiffStatsFile,
 	cfDiffView + ".GitDiffHeader":           CmpDiffviewDifflineGitDiffHeader,
 	cfDiffView + ".GitDiffExtendedHeader":   CmpDiffviewDifflineGitDiffExtendedHeader,
@@ -254,14 +317,54 @@ var themeComponents = map[string]ThemeComponentID{
 	cfGitSummaryView + ".StagedFile":      CmpSummaryViewStagedFile,
 	cfGitSummaryView + ".UnstagedFile":    CmpSummaryViewUnstagedFile,
 	cfGitSummaryView + ".NoModifiedFiles": CmpSummaryViewNoModifiedFiles,
+
+	cfKeyHintView + ".Title":       CmpKeyHintViewTitle,
+	cfKeyHintView + ".Key":         CmpKeyHintViewKey,
+	cfKeyHintView + ".Description": CmpKeyHintViewDescription,
+
+	cfMacroView + ".Title":    CmpMacroViewTitle,
+	cfMacroView + ".Register": CmpMacroViewRegister,
+	cfMacroView + ".Keys":     CmpMacroViewKeys,
+	cfMacroView + ".Footer":   CmpMacroViewFooter,
+
+	cfFuzzyFinderView + ".Title":     CmpFuzzyFinderViewTitle,
+	cfFuzzyFinderView + ".Type":      CmpFuzzyFinderViewType,
+	cfFuzzyFinderView + ".Candidate": CmpFuzzyFinderViewCandidate,
+	cfFuzzyFinderView + ".Match":     CmpFuzzyFinderViewMatch,
+	cfFuzzyFinderView + ".Footer":    CmpFuzzyFinderViewFooter,
+
+	cfCommitMessageView + ".Title":           CmpCommitMessageViewTitle,
+	cfCommitMessageView + ".Summary":         CmpCommitMessageViewSummary,
+	cfCommitMessageView + ".SummaryOverflow": CmpCommitMessageViewSummaryOverflow,
+	cfCommitMessageView + ".Body":            CmpCommitMessageViewBody,
+	cfCommitMessageView + ".Trailer":         CmpCommitMessageViewTrailer,
+	cfCommitMessageView + ".Footer":          CmpCommitMessageViewFooter,
+
+	cfCommitRangeView + ".Title":    CmpCommitRangeViewTitle,
+	cfCommitRangeView + ".Header":   CmpCommitRangeViewHeader,
+	cfCommitRangeView + ".ShortOid": CmpCommitRangeViewShortOid,
+	cfCommitRangeView + ".Date":     CmpCommitRangeViewDate,
+	cfCommitRangeView + ".Author":   CmpCommitRangeViewAuthor,
+	cfCommitRangeView + ".Summary":  CmpCommitRangeViewSummary,
+	cfCommitRangeView + ".Footer":   CmpCommitRangeViewFooter,
+
+	cfCommitInfoView + ".Title":        CmpCommitInfoViewTitle,
+	cfCommitInfoView + ".Header":       CmpCommitInfoViewHeader,
+	cfCommitInfoView + ".Label":        CmpCommitInfoViewLabel,
+	cfCommitInfoView + ".Value":        CmpCommitInfoViewValue,
+	cfCommitInfoView + ".LocalBranch":  CmpCommitInfoViewLocalBranch,
+	cfCommitInfoView + ".RemoteBranch": CmpCommitInfoViewRemoteBranch,
+	cfCommitInfoView + ".Tag":          CmpCommitInfoViewTag,
+	cfCommitInfoView + ".Footer":       CmpCommitInfoViewFooter,
 }
 
 var colorNumberPattern = regexp.MustCompile(`[0-9]{1,3}`)
 var hexColorPattern = regexp.MustCompile(`[a-fA-F0-9]{6}`)
 var systemColorPattern = regexp.MustCompile(`[a-zA-Z]+`)
 
-var commandBodyVariablePattern = regexp.MustCompile(`\$(\d+|\{\d+\}|@|\{@\})`)
+var commandBodyVariablePattern = regexp.MustCompile(`\$(\d+|\{\d+\}|@|\{@\}|[[:alpha:]][[:alnum:]_]*|\{[[:alpha:]][[:alnum:]_]*\})`)
 var argBracketsPattern = regexp.MustCompile(`\{|\}`)
+var variableReferencePattern = regexp.MustCompile(`\$\{([[:alpha:]][[:alnum:]-]*)\}`)
 
 var viewNames = map[ViewID]string{}
 
@@ -332,11 +435,18 @@ type ConfigurationVariable struct {
 type Configuration struct {
 	configVariables map[ConfigVariable]*ConfigurationVariable
 	themes          map[string]MutableTheme
+	keymaps         map[string]*Keymap
 	keyBindings     KeyBindings
 	grvConfigDir    string
 	channels        Channels
 	variables       GRVVariableGetter
-	customCommands  map[string]string
+	customCommands  map[string]*customCommand
+	hooks           map[EventType][]configHook
+	userVariables   map[string]string
+	loadingFiles    map[string]bool
+	loadedFiles     map[string]bool
+	reloading       bool
+	repoConfig      bool
 	inputConsumer   InputConsumer
 }
 
@@ -347,10 +457,21 @@ func NewConfiguration(keyBindings KeyBindings, channels Channels, variables GRVV
 		channels:       channels,
 		variables:      variables,
 		inputConsumer:  inputConsumer,
-		customCommands: map[string]string{},
+		customCommands: map[string]*customCommand{},
+		hooks:          map[EventType][]configHook{},
+		userVariables:  map[string]string{},
+		loadingFiles:   map[string]bool{},
+		loadedFiles:    map[string]bool{},
 		themes: map[string]MutableTheme{
-			cfClassicThemeName:   NewClassicTheme(),
-			cfSolarizedThemeName: NewSolarizedTheme(),
+			cfClassicThemeName:        NewClassicTheme(),
+			cfSolarizedThemeName:      NewSolarizedTheme(),
+			cfSolarizedLightThemeName: NewSolarizedLightTheme(),
+			cfHighContrastThemeName:   NewHighContrastTheme(),
+			cfMonochromeThemeName:     NewMonochromeTheme(),
+		},
+		keymaps: map[string]*Keymap{
+			kmViKeymapName:    NewViKeymap(),
+			kmEmacsKeymapName: NewEmacsKeymap(),
 		},
 	}
 
@@ -423,15 +544,84 @@ func NewConfiguration(keyBindings KeyBindings, channels Channels, variables GRVV
 			},
 			description: `Display "Press any key to continue" after executing external command`,
 		},
+		CfCommitViewColumns: {
+			defaultValue: cfCommitViewColumnsDefaultValue,
+			validator:    &commitViewColumnsValidator{},
+			description:  "Format of the columns displayed in the commit view",
+		},
+		CfKeyHintTimeout: {
+			defaultValue: cfKeyHintTimeoutDefaultValue,
+			validator:    keyHintTimeoutValidator{},
+			description:  "Delay in ms before key binding completions are shown (0 to disable)",
+		},
+		CfKeymap: {
+			defaultValue: cfKeymapDefaultValue,
+			validator: keymapValidator{
+				config: config,
+			},
+			description: "The currently active keymap",
+		},
+		CfMonochrome: {
+			defaultValue: isNoColorSet(),
+			validator: booleanValueValidator{
+				variableName: string(CfMonochrome),
+			},
+			description: "Display without color (default true when NO_COLOR is set)",
+		},
+		CfBuiltinCommitEditor: {
+			defaultValue: cfBuiltinCommitEditorDefaultValue,
+			validator: booleanValueValidator{
+				variableName: string(CfBuiltinCommitEditor),
+			},
+			description: "Enter commit messages in grv instead of an external editor",
+		},
+		CfCommitLintSummaryMaxLength: {
+			defaultValue: cfCommitLintSummaryMaxLengthDefaultValue,
+			validator:    commitLintSummaryMaxLengthValidator{},
+			description:  "Maximum commit summary length (0 to disable)",
+		},
+		CfCommitLintBlankSecondLine: {
+			defaultValue: cfCommitLintBlankSecondLineDefaultValue,
+			validator: booleanValueValidator{
+				variableName: string(CfCommitLintBlankSecondLine),
+			},
+			description: "Require a blank line between the commit summary and body",
+		},
+		CfCommitLintSummaryRegex: {
+			defaultValue: cfCommitLintSummaryRegexDefaultValue,
+			validator:    commitLintSummaryRegexValidator{},
+			description:  "Regular expression commit summaries must match",
+		},
+		CfCommitLintRequiredTrailers: {
+			defaultValue: cfCommitLintRequiredTrailersDefaultValue,
+			description:  "Comma separated list of trailers commit messages must contain",
+		},
+		CfSignatureVerifyCommand: {
+			defaultValue: cfSignatureVerifyCommandDefaultValue,
+			description:  "Command used to verify commit and tag signatures",
+		},
+		CfNotesRefs: {
+			defaultValue: cfNotesRefsDefaultValue,
+			validator:    notesRefsValidator{},
+			description:  "Comma separated list of notes refs to display notes from",
+		},
 	}
 
 	for _, configVariable := range config.configVariables {
 		configVariable.value = configVariable.defaultValue
 	}
 
+	config.AddOnChangeListener(CfKeymap, config)
+
 	return config
 }
 
+// isNoColorSet returns true if the NO_COLOR environment variable is set to a non-empty value
+func isNoColorSet() bool {
+	noColor, noColorSet := os.LookupEnv(cfNoColorEnvVariable)
+	return noColorSet && noColor != ""
+}
+
 // Initialise loads the grvrc config file (if it exists)
 func (config *Configuration) Initialise() []error {
 	configHomeDir, configHomeDirSet := os.LookupEnv("XDG_CONFIG_HOME")
@@ -460,14 +650,25 @@ func (config *Configuration) Initialise() []error {
 
 	config.grvConfigDir = grvConfigDir
 
-	grvConfig := grvConfigDir + cfGrvrcFile
+	return config.LoadConfigFile()
+}
+
+// LoadConfigFile loads the theme files and grvrc file in the config directory (if they exist).
+// Theme files are loaded first so that the themes they define can be activated in the grvrc file
+func (config *Configuration) LoadConfigFile() []error {
+	if config.grvConfigDir == "" {
+		return nil
+	}
+
+	errors := config.LoadThemeFiles()
+	grvConfig := config.grvConfigDir + cfGrvrcFile
 
 	if _, err := os.Stat(grvConfig); os.IsNotExist(err) {
 		log.Infof("No config file found at: %v", grvConfig)
-		return nil
+		return errors
 	}
 
-	errors := config.LoadFile(grvConfig)
+	errors = append(errors, config.LoadFile(grvConfig)...)
 
 	if len(errors) > 0 {
 		log.Infof("Encountered %v error(s) when loading config file", len(errors))
@@ -476,6 +677,117 @@ func (config *Configuration) Initialise() []error {
 	return errors
 }
 
+// LoadedFiles returns the paths of all config files which have been loaded, including sourced files
+func (config *Configuration) LoadedFiles() (filePaths []string) {
+	for filePath := range config.loadedFiles {
+		filePaths = append(filePaths, filePath)
+	}
+
+	sort.Strings(filePaths)
+
+	return
+}
+
+// KeyBindings returns the key bindings the configuration applies map and unmap commands to
+func (config *Configuration) KeyBindings() KeyBindings {
+	return config.keyBindings
+}
+
+// Reload runs the provided loader against a new configuration with default key bindings, themes and variables.
+// The new configuration only replaces the current configuration if the loader returns no errors.
+// Commands which modify the layout or perform actions are not run when reloading
+func (config *Configuration) Reload(loader func(*Configuration) []error) (errs []error) {
+	log.Info("Reloading config")
+
+	reloadedConfig := NewConfiguration(NewKeyBindingManager(), config.channels, config.variables, config.inputConsumer)
+	reloadedConfig.grvConfigDir = config.grvConfigDir
+	reloadedConfig.reloading = true
+
+	if errs = loader(reloadedConfig); len(errs) > 0 {
+		log.Infof("Encountered %v error(s) when reloading config. Retaining current config", len(errs))
+		config.syncCustomCommandDescriptors(reloadedConfig.customCommands)
+		return
+	}
+
+	previousCustomCommands := config.customCommands
+
+	config.keyBindings = reloadedConfig.keyBindings
+	config.themes = reloadedConfig.themes
+	config.keymaps = reloadedConfig.keymaps
+	config.customCommands = reloadedConfig.customCommands
+	config.hooks = reloadedConfig.hooks
+	config.userVariables = reloadedConfig.userVariables
+	config.loadedFiles = reloadedConfig.loadedFiles
+
+	config.syncCustomCommandDescriptors(previousCustomCommands)
+
+	for configVariable, variable := range config.configVariables {
+		oldValue := variable.value
+		variable.value = reloadedConfig.configVariables[configVariable].value
+
+		// Theme definitions may have changed even if the selected theme has not
+		if oldValue != variable.value || configVariable == CfTheme {
+			log.Infof("Value of config variable %v is %v after reload", configVariable, variable.value)
+			config.fireOnChangeListeners(configVariable, variable)
+		}
+	}
+
+	log.Info("Reloaded config")
+
+	return
+}
+
+// syncCustomCommandDescriptors ensures only the current user defined commands can be parsed.
+// Command definitions are shared by all configuration instances
+func (config *Configuration) syncCustomCommandDescriptors(otherCustomCommands map[string]*customCommand) {
+	for commandName := range otherCustomCommands {
+		if _, exists := config.customCommands[commandName]; !exists {
+			if err := UndefineCustomCommand(commandName); err != nil {
+				log.Errorf("Unable to undefine command %v: %v", commandName, err)
+			}
+		}
+	}
+
+	for commandName := range config.customCommands {
+		if err := DefineCustomCommand(commandName); err != nil {
+			log.Errorf("Unable to define command %v: %v", commandName, err)
+		}
+	}
+}
+
+// AppendToConfigFile adds the provided line to the end of the grvrc file in the config directory
+func (config *Configuration) AppendToConfigFile(line string) (err error) {
+	if config.grvConfigDir == "" {
+		return fmt.Errorf("Unable to determine config directory")
+	}
+
+	grvConfig := config.grvConfigDir + cfGrvrcFile
+
+	content, err := ioutil.ReadFile(grvConfig)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+
+	separator := ""
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		separator = "\n"
+	}
+
+	file, err := os.OpenFile(grvConfig, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	if _, err = file.WriteString(separator + line + "\n"); err != nil {
+		return
+	}
+
+	log.Infof("Added \"%v\" to config file %v", line, grvConfig)
+
+	return
+}
+
 // ConfigDir returns the directory grv looks for config in
 func (config *Configuration) ConfigDir() string {
 	return config.grvConfigDir
@@ -489,9 +801,47 @@ func (config *Configuration) LoadFile(filePath string) []error {
 		return []error{err}
 	}
 
-	log.Infof("Loading config file %v", filePath)
+	defer file.Close()
 
-	return config.processCommands(NewConfigParser(file, filePath))
+	return config.LoadReader(file, filePath)
+}
+
+// LoadReader loads configuration from the provided reader.
+// The input source is the file path the configuration was read from
+func (config *Configuration) LoadReader(reader io.Reader, inputSource string) []error {
+	filePath := filepath.Clean(inputSource)
+	if config.loadingFiles[filePath] {
+		return []error{fmt.Errorf("Config file %v is already being loaded", inputSource)}
+	}
+
+	log.Infof("Loading config file %v", inputSource)
+
+	config.loadingFiles[filePath] = true
+	config.loadedFiles[filePath] = true
+	defer delete(config.loadingFiles, filePath)
+
+	return config.processCommands(NewConfigParser(reader, inputSource))
+}
+
+// LoadRepoConfigReader loads configuration from a repository config file.
+// Files sourced by a repository config file are not covered by the trust
+// confirmation, so the source command is not permitted while loading the file
+// or when running the commands and hooks it defines
+func (config *Configuration) LoadRepoConfigReader(reader io.Reader, inputSource string) []error {
+	config.repoConfig = true
+	defer func() { config.repoConfig = false }()
+
+	return config.LoadReader(reader, inputSource)
+}
+
+// evaluateDefinedCommands processes configuration defined by a user defined command or hook.
+// Configuration defined by a repository config file is processed with the same restrictions as the file
+func (config *Configuration) evaluateDefinedCommands(configString string, repoConfig bool) []error {
+	previousRepoConfig := config.repoConfig
+	config.repoConfig = previousRepoConfig || repoConfig
+	defer func() { config.repoConfig = previousRepoConfig }()
+
+	return config.Evaluate(configString)
 }
 
 // Evaluate processes configuration in string format
@@ -508,6 +858,11 @@ func (config *Configuration) Evaluate(configString string) (errs []error) {
 
 func (config *Configuration) processCommands(parser *ConfigParser) []error {
 	var configErrors []error
+	conditionalBlocks := &conditionalBlockStack{}
+
+	parser.SetVariableExpander(func(text string) string {
+		return config.expandVariables(text, true)
+	})
 
 OuterLoop:
 	for {
@@ -515,11 +870,13 @@ OuterLoop:
 
 		switch {
 		case err != nil:
-			configErrors = append(configErrors, err)
+			if conditionalBlocks.active() {
+				configErrors = append(configErrors, err)
+			}
 		case eof:
 			break OuterLoop
 		case command != nil:
-			if err = config.processCommand(command, parser.InputSource()); err != nil {
+			if err = config.processConditionalCommand(command, conditionalBlocks, parser.InputSource()); err != nil {
 				configErrors = append(configErrors, err)
 			}
 		default:
@@ -527,15 +884,57 @@ OuterLoop:
 		}
 	}
 
+	for _, block := range conditionalBlocks.blocks {
+		configErrors = append(configErrors, generateConfigError(parser.InputSource(), block.commandToken, "No %v found for %v", endifCommand, ifCommand))
+	}
+
 	return configErrors
 }
 
+func (config *Configuration) processConditionalCommand(command ConfigCommand, conditionalBlocks *conditionalBlockStack, inputSource string) (err error) {
+	switch command := command.(type) {
+	case *IfCommand:
+		conditionMet := false
+
+		if conditionalBlocks.active() {
+			var errs []error
+			if conditionMet, errs = config.evaluateCondition(command.condition); len(errs) > 0 {
+				config.channels.ReportErrors(errs)
+				err = generateConfigError(inputSource, command.commandToken, "Invalid condition: %v", command.condition)
+			}
+		}
+
+		conditionalBlocks.push(command.commandToken, conditionMet)
+	case *ElseCommand:
+		if !conditionalBlocks.enterElse() {
+			err = generateConfigError(inputSource, command.commandToken, "Unexpected %v", elseCommand)
+		}
+	case *EndIfCommand:
+		if !conditionalBlocks.pop() {
+			err = generateConfigError(inputSource, command.commandToken, "Unexpected %v", endifCommand)
+		}
+	default:
+		if conditionalBlocks.active() {
+			err = config.processCommand(command, inputSource)
+		}
+	}
+
+	return
+}
+
 func (config *Configuration) processCommand(command ConfigCommand, inputSource string) (err error) {
+	if config.reloading && isActionCommand(command) {
+		log.Debugf("Not running command %T when reloading config", command)
+		return
+	}
+
 	switch command := command.(type) {
 	case *SetCommand:
 		err = config.processSetCommand(command, inputSource)
 	case *ThemeCommand:
 		err = config.processThemeCommand(command, inputSource)
+	case *KeymapCommand:
+		err = config.processKeymapCommand(command, inputSource)
 	case *MapCommand:
 		err = config.processMapCommand(command, inputSource)
 	case *UnmapCommand:
@@ -564,8 +963,26 @@ func (config *Configuration) processCommand(command ConfigCommand, inputSource s
 		err = config.processCustomCommand(command)
 	case *EvalKeysCommand:
 		err = config.processEvalKeysCommand(command)
+	case *MacrosCommand:
+		config.processMacrosCommand()
+	case *MacroMapCommand:
+		err = config.processMacroMapCommand(command, inputSource)
 	case *SleepCommand:
 		err = config.processSleepCommand(command)
+	case *ExportCommand:
+		config.processExportCommand(command)
+	case *ExportThemeCommand:
+		config.processExportThemeCommand(command)
+	case *FilterCommand:
+		config.processFilterCommand(command)
+	case *HookCommand:
+		config.processHookCommand(command)
+	case *SourceCommand:
+		err = config.processSourceCommand(command, inputSource)
+	case *LetCommand:
+		err = config.processLetCommand(command, inputSource)
+	case *CompareCommand:
+		config.processCompareCommand(command)
 	default:
 		log.Errorf("Unknown command type %T", command)
 	}
@@ -573,6 +990,18 @@ func (config *Configuration) processCommand(command ConfigCommand, inputSource s
 	return
 }
 
+// isActionCommand returns true for commands which modify the layout or perform an action rather than configure GRV
+func isActionCommand(command ConfigCommand) bool {
+	switch command.(type) {
+	case *QuitCommand, *NewTabCommand, *RemoveTabCommand, *AddViewCommand, *SplitViewCommand, *GitCommand,
+		*HelpCommand, *ShellCommand, *EvalKeysCommand, *SleepCommand, *ExportCommand, *ExportThemeCommand,
+		*FilterCommand, *MacrosCommand, *MacroMapCommand, *CompareCommand:
+		return true
+	}
+
+	return false
+}
+
 func (config *Configuration) processSetCommand(setCommand *SetCommand, inputSource string) error {
 	configVariable := ConfigVariable(setCommand.variable.value)
 	variable, ok := config.configVariables[configVariable]
@@ -607,15 +1036,7 @@ func (config *Configuration) processSetCommand(setCommand *SetCommand, inputSour
 
 	if oldValue != value {
 		log.Infof("Value of config variable %v has changed from %v to %v", configVariable, oldValue, value)
-
-		if len(variable.onChangeListeners) > 0 {
-			log.Debugf("Firing on change listeners for config variable %v", configVariable)
-			for _, listener := range variable.onChangeListeners {
-				listener.onConfigVariableChange(configVariable)
-			}
-		} else {
-			log.Debugf("Config variable %v has no change listeners registered", configVariable)
-		}
+		config.fireOnChangeListeners(configVariable, variable)
 	} else {
 		log.Infof("Value of config variable %v has not changed, therefore no listeners will be notified", configVariable)
 	}
@@ -623,6 +1044,17 @@ func (config *Configuration) processSetCommand(setCommand *SetCommand, inputSour
 	return nil
 }
 
+func (config *Configuration) fireOnChangeListeners(configVariable ConfigVariable, variable *ConfigurationVariable) {
+	if len(variable.onChangeListeners) > 0 {
+		log.Debugf("Firing on change listeners for config variable %v", configVariable)
+		for _, listener := range variable.onChangeListeners {
+			listener.onConfigVariableChange(configVariable)
+		}
+	} else {
+		log.Debugf("Config variable %v has no change listeners registered", configVariable)
+	}
+}
+
 func (config *Configuration) processThemeCommand(themeCommand *ThemeCommand, inputSource string) (err error) {
 	themeComponentID, componentIDExists := themeComponents[themeCommand.component.value]
 
@@ -657,6 +1089,108 @@ func (config *Configuration) processThemeCommand(themeCommand *ThemeCommand, inp
 	return
 }
 
+func (config *Configuration) processKeymapCommand(keymapCommand *KeymapCommand, inputSource string) (err error) {
+	keymapName := keymapCommand.name.value
+	keymap, keymapExists := config.keymaps[keymapName]
+
+	if keymapExists && keymap.builtIn {
+		return generateConfigError(inputSource, keymapCommand.name, "Cannot modify built in keymap %v", keymapName)
+	}
+
+	var viewID ViewID
+	if keymapCommand.view != nil {
+		var viewExists bool
+		if viewID, viewExists = viewIDNames[keymapCommand.view.value]; !viewExists {
+			return generateConfigError(inputSource, keymapCommand.view, "Invalid view: %v", keymapCommand.view.value)
+		}
+	}
+
+	if keymapCommand.base != nil {
+		baseKeymapNames, err := config.keymapNames(keymapCommand.base.value)
+		if err != nil {
+			return generateConfigError(inputSource, keymapCommand.base, "%v", err)
+		}
+
+		for _, baseKeymapName := range baseKeymapNames {
+			if baseKeymapName == keymapName {
+				return generateConfigError(inputSource, keymapCommand.base, "Keymap %v cannot be based on itself", keymapName)
+			}
+		}
+	}
+
+	if !keymapExists {
+		keymap = NewKeymap(keymapName)
+		config.keymaps[keymapName] = keymap
+	}
+
+	if keymapCommand.base != nil {
+		keymap.base = keymapCommand.base.value
+		log.Infof("Set base of keymap %v to %v", keymapName, keymap.base)
+	}
+
+	if keymapCommand.from != nil {
+		if keymapCommand.to != nil {
+			to := mappedKeystring(keymapCommand.to)
+			keymap.SetKeystringBinding(viewID, keymapCommand.from.value, to)
+			log.Infof("Mapped \"%v\" to \"%v\" for view %v in keymap %v", keymapCommand.from.value, to, keymapCommand.view.value, keymapName)
+		} else {
+			keymap.RemoveBinding(viewID, keymapCommand.from.value)
+			log.Infof("Unmapped \"%v\" for view %v in keymap %v", keymapCommand.from.value, keymapCommand.view.value, keymapName)
+		}
+	}
+
+	activeKeymapNames, _ := config.keymapNames(config.GetString(CfKeymap))
+	for _, activeKeymapName := range activeKeymapNames {
+		if activeKeymapName == keymapName {
+			config.applyKeymap()
+			break
+		}
+	}
+
+	return
+}
+
+// keymapNames returns the name of the keymap and all the keymaps it is based on, starting with the vi keymap
+func (config *Configuration) keymapNames(keymapName string) (keymapNames []string, err error) {
+	for keymapName != "" {
+		keymap, exists := config.keymaps[keymapName]
+		if !exists {
+			return nil, fmt.Errorf("No keymap exists with name %v", keymapName)
+		}
+
+		keymapNames = append([]string{keymapName}, keymapNames...)
+		keymapName = keymap.base
+	}
+
+	return
+}
+
+func (config *Configuration) applyKeymap() {
+	keymapName := config.GetString(CfKeymap)
+
+	keymapNames, err := config.keymapNames(keymapName)
+	if err != nil {
+		log.Errorf("Unable to apply keymap %v: %v", keymapName, err)
+		config.channels.ReportError(err)
+		return
+	}
+
+	var keymaps []*Keymap
+	for _, name := range keymapNames {
+		keymaps = append(keymaps, config.keymaps[name])
+	}
+
+	config.keyBindings.SetKeymaps(keymaps)
+	log.Infof("Applied keymap %v", keymapName)
+}
+
+// onConfigVariableChange applies the keymap when the active keymap changes
+func (config *Configuration) onConfigVariableChange(configVariable ConfigVariable) {
+	if configVariable == CfKeymap {
+		config.applyKeymap()
+	}
+}
+
 func getThemeColor(color *ConfigToken, inputSource string) (ThemeColor, error) {
 	switch {
 	case hexColorPattern.MatchString(color.value):
@@ -723,10 +1257,7 @@ func (config *Configuration) processMapCommand(mapCommand *MapCommand, inputSour
 		return generateConfigError(inputSource, mapCommand.to, "to keystring cannot be empty")
 	}
 
-	if (mapCommand.to.tokenType & CtkShellCommand) != 0 {
-		to = "<grv-prompt>" + strings.TrimSuffix(to, "<Enter>") + "<Enter>"
-	}
-
+	to = mappedKeystring(mapCommand.to)
 	config.keyBindings.SetKeystringBinding(viewID, from, to)
 
 	log.Infof("Mapped \"%v\" to \"%v\" for view %v", from, to, view)
@@ -734,6 +1265,26 @@ func (config *Configuration) processMapCommand(mapCommand *MapCommand, inputSour
 	return
 }
 
+// quoteConfigWord quotes the provided value when it would not otherwise be parsed as a single word
+func quoteConfigWord(value string) string {
+	if value == "" || strings.IndexFunc(value, unicode.IsSpace) != -1 ||
+		strings.ContainsAny(value[:1], "#!@\"") || strings.HasPrefix(value, "--") {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+// mappedKeystring returns the key sequence a map target is bound to.
+// Shell commands are run through the command prompt
+func mappedKeystring(to *ConfigToken) string {
+	if (to.tokenType & CtkShellCommand) != 0 {
+		return "<grv-prompt>" + strings.TrimSuffix(to.value, "<Enter>") + "<Enter>"
+	}
+
+	return to.value
+}
+
 func (config *Configuration) processUnmapCommand(unmapCommand *UnmapCommand, inputSource string) (err error) {
 	viewID, ok := viewIDNames[unmapCommand.view.value]
 	if !ok {
@@ -877,13 +1428,88 @@ func (config *Configuration) processShellCommand(shellCommand *ShellCommand, inp
 
 	prefix, _ := utf8.DecodeRuneInString(command)
 	outputType := OutputType(prefix)
-	command = command[1:]
+	command = config.expandVariables(command[1:], false)
 
 	config.runCommand(command, outputType)
 
 	return
 }
 
+type customCommand struct {
+	name               string
+	parameters         []*CustomCommandParameter
+	parametersDeclared bool
+	body               string
+	repoConfig         bool
+}
+
+// usage returns the signature of the command in the form it should be invoked
+func (command *customCommand) usage() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(command.name)
+
+	for _, parameter := range command.parameters {
+		buffer.WriteRune(' ')
+
+		if !parameter.hasDefault {
+			buffer.WriteString(parameter.name)
+			continue
+		}
+
+		defaultValue := parameter.defaultValue
+		if defaultValue == "" || strings.IndexFunc(defaultValue, unicode.IsSpace) != -1 {
+			defaultValue = strconv.Quote(defaultValue)
+		}
+
+		buffer.WriteString(fmt.Sprintf("[%v=%v]", parameter.name, defaultValue))
+	}
+
+	return buffer.String()
+}
+
+func (command *customCommand) requiredArgCount() (requiredArgCount int) {
+	for _, parameter := range command.parameters {
+		if !parameter.hasDefault {
+			requiredArgCount++
+		}
+	}
+
+	return
+}
+
+// bindArgs validates the provided arguments against the declared parameters and
+// fills in default values for any parameters that were not provided
+func (command *customCommand) bindArgs(providedArgs []string) (args []string, namedArgs map[string]string, err error) {
+	args = providedArgs
+
+	if !command.parametersDeclared {
+		return
+	}
+
+	if len(providedArgs) < command.requiredArgCount() {
+		missingParameter := command.parameters[len(providedArgs)]
+		err = fmt.Errorf("Missing argument %v for command %v. Usage: %v", missingParameter.name, command.name, command.usage())
+		return
+	} else if len(providedArgs) > len(command.parameters) {
+		err = fmt.Errorf("Command %v accepts at most %v argument(s) but received %v. Usage: %v",
+			command.name, len(command.parameters), len(providedArgs), command.usage())
+		return
+	}
+
+	args = append([]string{}, providedArgs...)
+	namedArgs = map[string]string{}
+
+	for parameterIndex, parameter := range command.parameters {
+		if parameterIndex >= len(args) {
+			args = append(args, parameter.defaultValue)
+		}
+
+		namedArgs[parameter.name] = args[parameterIndex]
+	}
+
+	return
+}
+
 func (config *Configuration) processDefCommand(defCommand *DefCommand) (err error) {
 	if err = DefineCustomCommand(defCommand.commandName); err != nil {
 		return
@@ -893,7 +1519,13 @@ func (config *Configuration) processDefCommand(defCommand *DefCommand) (err erro
 		log.Debugf("Overriding previous command definition for command %v", defCommand.commandName)
 	}
 
-	config.customCommands[defCommand.commandName] = defCommand.functionBody
+	config.customCommands[defCommand.commandName] = &customCommand{
+		name:               defCommand.commandName,
+		parameters:         defCommand.parameters,
+		parametersDeclared: defCommand.parametersDeclared,
+		body:               defCommand.functionBody,
+		repoConfig:         config.repoConfig,
+	}
 	config.channels.ReportStatus("Defined user comamnd %v", defCommand.commandName)
 
 	return
@@ -915,15 +1547,20 @@ func (config *Configuration) processUndefCommand(undefCommand *UndefCommand, inp
 }
 
 func (config *Configuration) processCustomCommand(customCommand *CustomCommand) (err error) {
-	commandBody, ok := config.customCommands[customCommand.commandName]
+	command, ok := config.customCommands[customCommand.commandName]
 	if !ok {
 		return fmt.Errorf("No command with name %v exists", customCommand.commandName)
 	}
 
-	log.Infof("Executing user defined command %v with args: %v", customCommand.commandName, customCommand.args)
-	commandBody = config.processConfigCommandBody(commandBody, customCommand.args)
+	args, namedArgs, err := command.bindArgs(customCommand.args)
+	if err != nil {
+		return
+	}
+
+	log.Infof("Executing user defined command %v with args: %v", customCommand.commandName, args)
+	commandBody := config.processConfigCommandBody(command.body, args, namedArgs)
 
-	if errs := config.Evaluate(commandBody); len(errs) > 0 {
+	if errs := config.evaluateDefinedCommands(commandBody, command.repoConfig); len(errs) > 0 {
 		config.channels.ReportErrors(errs)
 		err = fmt.Errorf("Command %v generated errors", customCommand.commandName)
 	}
@@ -931,7 +1568,7 @@ func (config *Configuration) processCustomCommand(customCommand *CustomCommand)
 	return
 }
 
-func (config *Configuration) processConfigCommandBody(commandBody string, args []string) string {
+func (config *Configuration) processConfigCommandBody(commandBody string, args []string, namedArgs map[string]string) string {
 	allMatchIndexes := commandBodyVariablePattern.FindAllStringSubmatchIndex(commandBody, -1)
 	if len(allMatchIndexes) == 0 {
 		return commandBody
@@ -949,6 +1586,16 @@ func (config *Configuration) processConfigCommandBody(commandBody string, args [
 			escapeCount++
 		}
 
+		argString := argBracketsPattern.ReplaceAllString(commandBody[matchIndexes[2]:matchIndexes[3]], "")
+		namedArg, isNamedArg := namedArgs[argString]
+
+		if !isNamedArg && argString != "@" && !unicode.IsDigit(rune(argString[0])) {
+			// Not a parameter of this command. Leave it for variable expansion
+			processedCommandBody.WriteString(commandBody[lastMatchIndex:matchEndIndex])
+			lastMatchIndex = matchEndIndex
+			continue
+		}
+
 		processedCommandBody.WriteString(commandBody[lastMatchIndex : matchStartIndex-escapeCount])
 
 		if escapeCount > 0 && escapeCount%2 != 0 {
@@ -959,9 +1606,9 @@ func (config *Configuration) processConfigCommandBody(commandBody string, args [
 				processedCommandBody.WriteString(strings.Repeat("$", escapeCount/2))
 			}
 
-			argString := argBracketsPattern.ReplaceAllString(commandBody[matchIndexes[2]:matchIndexes[3]], "")
-
-			if argString == "@" {
+			if isNamedArg {
+				processedCommandBody.WriteString(namedArg)
+			} else if argString == "@" {
 				processedCommandBody.WriteString(strings.Join(args, " "))
 			} else if argNumber, err := strconv.Atoi(argString); err == nil {
 				if argNumber > 0 && argNumber-1 < len(args) {
@@ -986,6 +1633,39 @@ func (config *Configuration) processEvalKeysCommand(evalKeysCommand *EvalKeysCom
 	return
 }
 
+func (config *Configuration) processMacrosCommand() {
+	config.channels.DoAction(Action{
+		ActionType: ActionShowMacroView,
+	})
+}
+
+func (config *Configuration) processMacroMapCommand(macroMapCommand *MacroMapCommand, inputSource string) (err error) {
+	view := macroMapCommand.view.value
+	if _, ok := viewIDNames[view]; !ok {
+		return generateConfigError(inputSource, macroMapCommand.view, "Invalid view: %v", view)
+	}
+
+	if macroMapCommand.from.value == "" {
+		return generateConfigError(inputSource, macroMapCommand.from, "from keystring cannot be empty")
+	}
+
+	register := macroMapCommand.register.value
+	if !macroRegisterRegex.MatchString(register) {
+		return generateConfigError(inputSource, macroMapCommand.register, "Invalid macro register: %v", register)
+	}
+
+	config.channels.DoAction(Action{
+		ActionType: ActionMapMacro,
+		Args: []interface{}{ActionMapMacroArgs{
+			view:     view,
+			from:     macroMapCommand.from.value,
+			register: register,
+		}},
+	})
+
+	return
+}
+
 func (config *Configuration) processSleepCommand(sleepCommand *SleepCommand) (err error) {
 	config.channels.DoAction(Action{
 		ActionType: ActionSleep,
@@ -995,6 +1675,129 @@ func (config *Configuration) processSleepCommand(sleepCommand *SleepCommand) (er
 	return
 }
 
+func (config *Configuration) processExportCommand(exportCommand *ExportCommand) {
+	config.channels.DoAction(Action{
+		ActionType: ActionExport,
+		Args:       []interface{}{exportCommand.filePath, exportCommand.format},
+	})
+}
+
+func (config *Configuration) processCompareCommand(compareCommand *CompareCommand) {
+	config.channels.DoAction(Action{
+		ActionType: ActionShowCompareView,
+		Args:       []interface{}{compareCommand.refA, compareCommand.refB},
+	})
+}
+
+func (config *Configuration) processExportThemeCommand(exportThemeCommand *ExportThemeCommand) {
+	if err := config.ExportTheme(exportThemeCommand.name, exportThemeCommand.filePath); err != nil {
+		config.channels.ReportError(fmt.Errorf("Unable to export theme: %v", err))
+		return
+	}
+
+	config.channels.ReportStatus("Exported theme %v", exportThemeCommand.name)
+}
+
+func (config *Configuration) processFilterCommand(filterCommand *FilterCommand) {
+	config.channels.DoAction(Action{
+		ActionType: ActionAddFilter,
+		Args:       []interface{}{filterCommand.query},
+	})
+}
+
+type configHook struct {
+	command    string
+	repoConfig bool
+}
+
+func (config *Configuration) processHookCommand(hookCommand *HookCommand) {
+	log.Debugf("Adding %v hook: %v", HookEventName(hookCommand.eventType), hookCommand.command)
+	config.hooks[hookCommand.eventType] = append(config.hooks[hookCommand.eventType], configHook{
+		command:    hookCommand.command,
+		repoConfig: config.repoConfig,
+	})
+}
+
+func (config *Configuration) runHooks(eventType EventType) {
+	for _, hook := range config.hooks[eventType] {
+		log.Infof("Running %v hook: %v", HookEventName(eventType), hook.command)
+
+		if errs := config.evaluateDefinedCommands(hook.command, hook.repoConfig); len(errs) > 0 {
+			config.channels.ReportErrors(errs)
+		}
+	}
+}
+
+func (config *Configuration) processSourceCommand(sourceCommand *SourceCommand, inputSource string) (err error) {
+	if config.repoConfig {
+		return generateConfigError(inputSource, sourceCommand.filePath, "The source command is not permitted in repository config files")
+	}
+
+	filePath, err := resolveSourceFilePath(sourceCommand.filePath.value, inputSource)
+	if err != nil {
+		return generateConfigError(inputSource, sourceCommand.filePath, "%v", err)
+	}
+
+	if errs := config.LoadFile(filePath); len(errs) > 0 {
+		config.channels.ReportErrors(errs)
+		err = generateConfigError(inputSource, sourceCommand.filePath, "Errors occurred when sourcing %v", filePath)
+	}
+
+	return
+}
+
+// resolveSourceFilePath expands a leading ~ to the home directory.
+// Relative paths are resolved against the directory of the file
+// containing the source command, or the working directory otherwise
+func resolveSourceFilePath(filePath, inputSource string) (string, error) {
+	if filePath == "~" || strings.HasPrefix(filePath, "~/") {
+		home, homeSet := os.LookupEnv("HOME")
+		if !homeSet {
+			return "", fmt.Errorf("Unable to expand ~ as HOME is not set")
+		}
+
+		filePath = filepath.Join(home, filePath[1:])
+	}
+
+	if !filepath.IsAbs(filePath) && inputSource != "" {
+		filePath = filepath.Join(filepath.Dir(inputSource), filePath)
+	}
+
+	return filepath.Abs(filePath)
+}
+
+func (config *Configuration) processLetCommand(letCommand *LetCommand, inputSource string) (err error) {
+	name := letCommand.name.value
+
+	if _, isGRVVariable := LookupGRVVariable(name); isGRVVariable {
+		return generateConfigError(inputSource, letCommand.name, "Cannot set the value of GRV variable %v", name)
+	} else if _, isConfigVariable := config.configVariables[ConfigVariable(name)]; isConfigVariable {
+		return generateConfigError(inputSource, letCommand.name, "Config variable %v must be set using the %v command", name, setCommand)
+	}
+
+	log.Debugf("Setting user variable %v to %v", name, letCommand.value)
+	config.userVariables[name] = letCommand.value
+
+	return
+}
+
+// expandVariables replaces references of the form ${name} with the value of the user variable with that name.
+// GRV variables are also expanded if requested. References to unknown variables are left unchanged
+func (config *Configuration) expandVariables(text string, includeGRVVariables bool) string {
+	return variableReferencePattern.ReplaceAllStringFunc(text, func(reference string) string {
+		name := variableReferencePattern.FindStringSubmatch(reference)[1]
+
+		if value, exists := config.userVariables[name]; exists {
+			return value
+		} else if variable, exists := LookupGRVVariable(name); exists && includeGRVVariables {
+			value, _ := config.variables.VariableValue(variable)
+			return value
+		}
+
+		return reference
+	})
+}
+
 func (config *Configuration) runCommand(command string, outputType ShellCommandOutputType) {
 	NewShellCommandProcessor(config.channels, config.variables, command, outputType).Execute()
 }
@@ -1057,9 +1860,14 @@ func (config *Configuration) GetFloat(configVariable ConfigVariable) float64 {
 	panic(fmt.Sprintf("ConfigVariable with ID %v does not have a floating point value", configVariable))
 }
 
-// GetTheme returns the currently active theme
+// GetTheme returns the currently active theme.
+// The monochrome theme is always returned when monochrome mode is enabled
 func (config *Configuration) GetTheme() Theme {
 	themeName := config.GetString(CfTheme)
+	if config.GetBool(CfMonochrome) {
+		themeName = cfMonochromeThemeName
+	}
+
 	theme, ok := config.themes[themeName]
 
 	if !ok {
@@ -1091,6 +1899,10 @@ func (config *Configuration) HandleEvent(event Event) (err error) {
 				config.removeOnChangeListener(listener)
 			}
 		}
+	default:
+		if IsHookEvent(event.EventType) {
+			config.runHooks(event.EventType)
+		}
 	}
 
 	return
@@ -1113,11 +1925,54 @@ func (config *Configuration) GenerateHelpSections() (helpSections []*HelpSection
 
 	helpSections = append(helpSections, config.generateConfigVariableHelpSection())
 
+	helpSections = append(helpSections, GenerateCommitViewColumnsHelpSection(config))
+
+	if helpSection := config.generateCustomCommandHelpSection(); helpSection != nil {
+		helpSections = append(helpSections, helpSection)
+	}
+
 	helpSections = append(helpSections, GenerateConfigCommandHelpSections(config)...)
 
 	return helpSections
 }
 
+func (config *Configuration) generateCustomCommandHelpSection() (helpSection *HelpSection) {
+	if os.Getenv(MnGenerateDocumentationEnv) != "" || len(config.customCommands) == 0 {
+		return
+	}
+
+	headers := []TableHeader{
+		{text: "Command", themeComponentID: CmpHelpViewSectionTableHeader},
+		{text: "Usage", themeComponentID: CmpHelpViewSectionTableHeader},
+	}
+
+	tableFormatter := NewTableFormatterWithHeaders(headers, config)
+	tableFormatter.SetGridLines(true)
+
+	commandNames := []string{}
+	for commandName := range config.customCommands {
+		commandNames = append(commandNames, commandName)
+	}
+
+	sort.Strings(commandNames)
+
+	tableFormatter.Resize(uint(len(commandNames)))
+
+	for rowIndex, commandName := range commandNames {
+		tableFormatter.SetCellWithStyle(uint(rowIndex), 0, CmpHelpViewSectionTableRow, "%v", commandName)
+		tableFormatter.SetCellWithStyle(uint(rowIndex), 1, CmpHelpViewSectionTableRow, "%v", config.customCommands[commandName].usage())
+	}
+
+	return &HelpSection{
+		title: HelpSectionText{text: "User Defined Commands"},
+		description: []HelpSectionText{
+			{text: "The following commands have been defined using the def command."},
+			{text: "Parameters shown in square brackets are optional and show their default value"},
+		},
+		tableFormatter: tableFormatter,
+	}
+}
+
 func (config *Configuration) generateConfigVariableHelpSection() (helpSection *HelpSection) {
 	isDocFile := os.Getenv(MnGenerateDocumentationEnv) != ""
 
@@ -1207,6 +2062,36 @@ func (themeValidator themeValidator) validate(value string) (processedValue inte
 	return
 }
 
+func (themeValidator themeValidator) validValues() (values []string) {
+	for themeName := range themeValidator.config.themes {
+		values = append(values, themeName)
+	}
+
+	return
+}
+
+type keymapValidator struct {
+	config *Configuration
+}
+
+func (keymapValidator keymapValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, ok := keymapValidator.config.keymaps[value]; !ok {
+		err = fmt.Errorf("No keymap exists with name %v", value)
+	} else {
+		processedValue = value
+	}
+
+	return
+}
+
+func (keymapValidator keymapValidator) validValues() (values []string) {
+	for keymapName := range keymapValidator.config.keymaps {
+		values = append(values, keymapName)
+	}
+
+	return
+}
+
 type booleanValueValidator struct {
 	variableName string
 }
@@ -1224,6 +2109,10 @@ func (booleanValueValidator booleanValueValidator) validate(value string) (proce
 	return
 }
 
+func (booleanValueValidator booleanValueValidator) validValues() []string {
+	return []string{"true", "false"}
+}
+
 type mouseScrollRowsValidator struct{}
 
 func (mouseScrollRowsValidator mouseScrollRowsValidator) validate(value string) (processedValue interface{}, err error) {
@@ -1256,13 +2145,74 @@ func (promptHistorySizeValidator promptHistorySizeValidator) validate(value stri
 	return
 }
 
+type keyHintTimeoutValidator struct{}
+
+func (keyHintTimeoutValidator keyHintTimeoutValidator) validate(value string) (processedValue interface{}, err error) {
+	var keyHintTimeout int
+
+	if keyHintTimeout, err = strconv.Atoi(value); err != nil {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfKeyHintTimeout)
+	} else if keyHintTimeout < 0 {
+		err = fmt.Errorf("%v must be greater than or equal to 0", CfKeyHintTimeout)
+	} else {
+		processedValue = keyHintTimeout
+	}
+
+	return
+}
+
+type commitLintSummaryMaxLengthValidator struct{}
+
+func (commitLintSummaryMaxLengthValidator commitLintSummaryMaxLengthValidator) validate(value string) (processedValue interface{}, err error) {
+	var summaryMaxLength int
+
+	if summaryMaxLength, err = strconv.Atoi(value); err != nil {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfCommitLintSummaryMaxLength)
+	} else if summaryMaxLength < 0 {
+		err = fmt.Errorf("%v must be greater than or equal to 0", CfCommitLintSummaryMaxLength)
+	} else {
+		processedValue = summaryMaxLength
+	}
+
+	return
+}
+
+type commitLintSummaryRegexValidator struct{}
+
+func (commitLintSummaryRegexValidator commitLintSummaryRegexValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = regexp.Compile(value); err != nil {
+		err = fmt.Errorf("%v must be a valid regular expression: %v", CfCommitLintSummaryRegex, err)
+	} else {
+		processedValue = value
+	}
+
+	return
+}
+
+type notesRefsValidator struct{}
+
+func (notesRefsValidator notesRefsValidator) validate(value string) (processedValue interface{}, err error) {
+	for _, notesRef := range strings.Split(value, ",") {
+		if notesRef = strings.TrimSpace(notesRef); notesRef != "" && !strings.HasPrefix(notesRef, "refs/notes/") {
+			err = fmt.Errorf("%v must only contain refs under refs/notes/ but found %v", CfNotesRefs, notesRef)
+			return
+		}
+	}
+
+	processedValue = value
+
+	return
+}
+
 type defaultViewValidator struct {
 	config *Configuration
 }
 
 func (defaultViewValidator *defaultViewValidator) validate(value string) (processedValue interface{}, err error) {
-	if _, exists := defaultViewValidator.config.customCommands[value]; !exists {
+	if command, exists := defaultViewValidator.config.customCommands[value]; !exists {
 		err = fmt.Errorf("No user defined command with name \"%v\" exists", value)
+	} else if command.requiredArgCount() > 0 {
+		err = fmt.Errorf("User defined command \"%v\" cannot be used as it requires arguments. Usage: %v", value, command.usage())
 	} else {
 		processedValue = value
 	}
@@ -1270,6 +2220,16 @@ func (defaultViewValidator *defaultViewValidator) validate(value string) (proces
 	return
 }
 
+func (defaultViewValidator *defaultViewValidator) validValues() (values []string) {
+	for commandName, command := range defaultViewValidator.config.customCommands {
+		if command.requiredArgCount() == 0 {
+			values = append(values, commandName)
+		}
+	}
+
+	return
+}
+
 type diffDisplayValidator struct{}
 
 func (diffDisplayValidator *diffDisplayValidator) validate(value string) (processedValue interface{}, err error) {
@@ -1281,3 +2241,11 @@ func (diffDisplayValidator *diffDisplayValidator) validate(value string) (proces
 
 	return
 }
+
+func (diffDisplayValidator *diffDisplayValidator) validValues() (values []string) {
+	for diffProcessorName := range diffProcessorNames {
+		values = append(values, diffProcessorName)
+	}
+
+	return
+}
@@ -22,10 +22,19 @@ func GenerateSetCommandHelpSections(config Config) (helpSections []*HelpSection)
 		{text: "GRV currently has the following themes available:"},
 		{},
 		{text: " - solarized"},
+		{text: " - solarized-light"},
 		{text: " - classic"},
+		{text: " - high-contrast"},
+		{text: " - monochrome"},
 		{},
 		{text: "The solarized theme is the default theme for GRV and does not respect the terminals colour palette."},
+		{text: "The solarized-light theme is the light variant of the solarized theme."},
 		{text: "The classic theme respects the terminals colour palette."},
+		{text: "The high-contrast theme uses bright colours on a black background."},
+		{text: "The monochrome theme uses text attributes such as bold, underline and reverse instead of colour."},
+		{text: "It is always used when the monochrome config variable is true, which is the default when the NO_COLOR"},
+		{text: "environment variable is set. In monochrome mode refs in the RefView are enclosed in the same markers as"},
+		{text: "in the CommitView ({local}, [remote] and <tag>) and diffs are displayed with +/- prefixes."},
 	}
 
 	return []*HelpSection{
@@ -59,7 +68,15 @@ func GenerateThemeCommandHelpSections(config Config) (helpSections []*HelpSectio
 		{text: "theme --name mytheme --component StatusBarView.Normal --bgcolor None --fgcolor f14a98", themeComponentID: CmpHelpViewSectionCodeBlock},
 		{text: "set theme mytheme", themeComponentID: CmpHelpViewSectionCodeBlock},
 		{},
+		{text: "Themes can also be defined in theme files. GRV loads all files with a .grvtheme extension in the directory"},
+		{text: "themes in the GRV config directory before loading the grvrc file, for example:"},
+		{},
+		{text: "$HOME/.config/grv/themes/mytheme.grvtheme", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "Theme files may only contain theme commands. The exporttheme command can be used to create a theme file."},
+		{},
 		{text: "GRV supports 256 colors (when available). Provided colors will be mapped to the nearest available color."},
+		{text: "Hex colors are mapped to the nearest of the 16 or 8 basic colors on terminals which support fewer than 256 colors."},
 		{text: "The allowed color values are:"},
 		{},
 		{text: "System colors:"},
@@ -105,6 +122,50 @@ func GenerateThemeCommandHelpSections(config Config) (helpSections []*HelpSectio
 	}
 }
 
+// GenerateKeymapCommandHelpSections generates help documentation for the keymap command
+func GenerateKeymapCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "keymap", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The keymap command allows a custom keymap to be defined."},
+		{text: "This keymap can then be activated using the keymap config variable described above."},
+		{text: "The forms of the keymap command are:"},
+		{},
+		{text: "keymap --name [KeymapName] --base [BaseKeymapName]", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "keymap --name [KeymapName] --view [View] --from [FromKeys] --to [ToKeys]", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "keymap --name [KeymapName] --view [View] --from [FromKeys]", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: " - KeymapName: The name of the keymap to be created/updated."},
+		{text: " - BaseKeymapName: The keymap whose bindings are changed by this keymap. The default is vi."},
+		{text: " - View: The view the binding applies to. All can be used for bindings which apply to all views."},
+		{text: " - FromKeys: The key sequence to bind. If no --to option is provided then the binding is removed."},
+		{text: " - ToKeys: The action, key sequence or shell command the key sequence is bound to, as with the map command."},
+		{},
+		{text: "For example, to define a keymap \"mykeys\" based on the emacs keymap and set it as the active keymap:"},
+		{},
+		{text: "keymap --name mykeys --base emacs", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "keymap --name mykeys --view All --from <C-j> --to <grv-next-line>", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "keymap --name mykeys --view All --from <C-k> --to <grv-prev-line>", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "keymap --name mykeys --view All --from <C-l>", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "set keymap mykeys", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "GRV currently has the following keymaps available:"},
+		{},
+		{text: " - vi"},
+		{text: " - emacs"},
+		{},
+		{text: "The vi keymap is the default keymap for GRV."},
+		{text: "The emacs keymap uses keys such as <C-n>, <C-p>, <C-v> and <C-x>o for movement and view navigation."},
+		{text: "Bindings set using the map and unmap commands apply on top of the active keymap."},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
 // GenerateMapCommandHelpSections generates help documentation for the map command
 func GenerateMapCommandHelpSections(config Config) (helpSections []*HelpSection) {
 	description := []HelpSectionText{
@@ -427,6 +488,22 @@ func GenerateDefCommandHelpSections(config Config) (helpSections []*HelpSection)
 		{},
 		{text: "Argument placeholders can be escaped by prepending a dollar sign."},
 		{text: "For example, to specify the literal string $1 in a command body specify $$1."},
+		{},
+		{text: "Parameters can be declared after the command name. Declared parameters can be referenced by name"},
+		{text: "in the command body using the placeholders $name or ${name}, as well as by position."},
+		{text: "A parameter can be given a default value which is used when no argument is provided for it."},
+		{text: "Parameters with default values must follow all parameters without default values."},
+		{text: "For example, the following command opens a tab for a ref and lists the commits on it by an author:"},
+		{},
+		{text: "def review(ref, author=me) {", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "\taddtab \"Review $ref\"", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "\taddview CommitView $ref", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "\t!git log --oneline --author=$author $ref", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "}", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "When parameters are declared the number of arguments is validated on invocation and an error"},
+		{text: "showing the usage of the command is displayed if too few or too many arguments are provided."},
+		{text: "The usage of all user defined commands is listed in the help view."},
 	}
 
 	return []*HelpSection{
@@ -480,6 +557,55 @@ func GenerateEvalKeysCommandHelpSections(config Config) (helpSections []*HelpSec
 	}
 }
 
+// GenerateMacrosCommandHelpSections generates help documentation for the macros command
+func GenerateMacrosCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "macros", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The macros command opens a tab listing the key sequences recorded in each macro register."},
+		{text: "Recording is started by pressing Q followed by a register (a-z, A-Z or 0-9) and stopped by pressing Q again."},
+		{text: "A macro is replayed by pressing @ followed by its register and can be preceded by a count, e.g. 3@a"},
+		{text: "@@ replays the last macro that was replayed. Recorded macros are saved in the grv config directory."},
+		{text: "Input submitted at a prompt is recorded as part of the macro. Prompts which are cancelled are not recorded."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "macros", themeComponentID: CmpHelpViewSectionCodeBlock},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
+// GenerateMacroMapCommandHelpSections generates help documentation for the macromap command
+func GenerateMacroMapCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "macromap", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The macromap command maps a key sequence to the keys recorded in a macro register."},
+		{text: "The equivalent map command is added to the end of the grvrc file so that the mapping is retained."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "macromap view fromkeys register", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "For example, if register a contains the keys 'jj<Enter>' then the following:"},
+		{},
+		{text: "macromap CommitView <C-j> a", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "adds the following line to grvrc:"},
+		{},
+		{text: "map CommitView <C-j> jj<Enter>", themeComponentID: CmpHelpViewSectionCodeBlock},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
 // GenerateSleepCommandHelpSections generates help documentation for the addtab command
 func GenerateSleepCommandHelpSections(config Config) (helpSections []*HelpSection) {
 	description := []HelpSectionText{
@@ -501,3 +627,235 @@ func GenerateSleepCommandHelpSections(config Config) (helpSections []*HelpSectio
 		},
 	}
 }
+
+// GenerateExportCommandHelpSections generates help documentation for the export command
+func GenerateExportCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "export", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The export command writes the rows currently loaded in the active view to a file."},
+		{text: "Only rows matching any applied filters are exported. Each row contains the fields available to filter queries,"},
+		{text: "except for the commit fields signature and lintviolations which are expensive to determine for every commit."},
+		{text: "Commits also include the refs pointing to them and branches include their ahead and behind counts."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "export file [csv|json]", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "If no format is specified then json is used for files with a .json extension, otherwise csv is used."},
+		{text: "Specifying - as the file writes the rows to standard output, which is useful in batch mode."},
+		{text: "For example, running the following in the commit view will export the loaded commits as json:"},
+		{},
+		{text: "export commits.json", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "The export command is supported by the CommitView and RefView."},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
+// GenerateExportThemeCommandHelpSections generates help documentation for the exporttheme command
+func GenerateExportThemeCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "exporttheme", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The exporttheme command writes the active theme to a theme file as a sequence of theme commands."},
+		{text: "The theme commands define a theme with the provided name, which can be activated immediately."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "exporttheme name [file]", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "The name must start with a letter or digit and may only contain letters, digits, _, - and single dots."},
+		{text: "If no file is specified then the theme is written to the themes directory in the GRV config directory"},
+		{text: "and is loaded automatically on start up. For example, to create a theme based on the solarized theme:"},
+		{},
+		{text: "set theme solarized", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "exporttheme mytheme", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "The file $HOME/.config/grv/themes/mytheme.grvtheme can then be edited to customise the theme."},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
+// GenerateFilterCommandHelpSections generates help documentation for the filter command
+func GenerateFilterCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "filter", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The filter command applies a filter query to the active view."},
+		{text: "It has the same effect as entering the query at the filter prompt."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "filter query", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "For example, to only display commits authored by John in the commit view the following can be used:"},
+		{},
+		{text: "filter authorname = \"John\"", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "See the Filter Query Language section for details of the query syntax."},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
+// GenerateHookCommandHelpSections generates help documentation for the hook command
+func GenerateHookCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "hook", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The hook command runs a command whenever an event occurs."},
+		{text: "The command can be a shell command or any other configuration command, including user defined commands."},
+		{text: "GRV variables are substituted into the command in the same way as for shell commands."},
+		{text: "Multiple hooks can be registered for the same event and are run in the order they were defined."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "hook event command", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "For example, to push after each commit and display the new HEAD whenever it changes:"},
+		{},
+		{text: "hook post-commit @git push", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "hook head-changed !git log -1 ${head}", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "The events hooks can be registered for are:"},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+		GenerateHookEventsHelpSection(config),
+	}
+}
+
+// GenerateSourceCommandHelpSections generates help documentation for the source command
+func GenerateSourceCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "source", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The source command processes the configuration commands in a file."},
+		{text: "Relative paths are resolved against the directory of the file containing the source command."},
+		{text: "When run from the command prompt they are resolved against the current working directory."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "source file", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "For example, to load a file of shared user defined commands from the home directory:"},
+		{},
+		{text: "source ~/.grv/commands", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "The source command cannot be used in a repository .grvrc file, or in the commands and hooks it defines,"},
+		{text: "as the sourced files would be processed without asking for confirmation."},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
+// GenerateLetCommandHelpSections generates help documentation for the let command
+func GenerateLetCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "let", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The let command sets the value of a user variable."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "let name = value", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "Variable names must begin with a letter and can contain letters, numbers and hyphens."},
+		{text: "User variables and GRV variables can be substituted into the arguments of commands using the syntax ${name}."},
+		{text: "For example, the following opens a tab displaying the commits on the branch stored in a variable:"},
+		{},
+		{text: "let mainline = develop", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "addtab ${mainline}", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "addview CommitView ${mainline}", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "User variables are also substituted into shell commands."},
+		{text: "Variables in the map, unmap, def and hook commands are substituted when the mapped keys or defined commands are run."},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
+// GenerateIfCommandHelpSections generates help documentation for the if, else and endif commands
+func GenerateIfCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "if", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The if command only processes the commands up to the matching else or endif command when a condition is true."},
+		{text: "Commands following an else command are processed when the condition is false. Blocks can be nested."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "if condition", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "    commands", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "else", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "    commands", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "endif", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "Conditions use the same syntax as filter queries."},
+		{text: "GRV variables, config variables and user variables can be tested by their names with hyphens removed."},
+		{text: "For example, the following hides the commit graph on narrow terminals and sets a commit limit for one repository:"},
+		{},
+		{text: "if terminalcols < 120", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "    set commit-graph false", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "else", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "    set commit-graph true", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "endif", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "if repopath GLOB \"*/linux/*\" AND head != \"refs/heads/master\"", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "    set commit-limit 10000", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{text: "endif", themeComponentID: CmpHelpViewSectionCodeBlock},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
+
+// GenerateCompareCommandHelpSections generates help documentation for the compare command
+func GenerateCompareCommandHelpSections(config Config) (helpSections []*HelpSection) {
+	description := []HelpSectionText{
+		{text: "compare", themeComponentID: CmpHelpViewSectionSubTitle},
+		{},
+		{text: "The compare command opens a tab listing the commits in one ref but not the other side by side."},
+		{text: "The first row of each list shows the merge base of the refs and how many commits each ref is ahead and behind."},
+		{text: "Selecting this row displays the cumulative diff between the merge base and the ref in the diff view below."},
+		{text: "The comparison reflects the refs when the tab was opened and is not updated when the refs change."},
+		{text: "The format of the command is:"},
+		{},
+		{text: "compare ref [ref]", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "If only one ref is specified then it is compared with HEAD. For example:"},
+		{},
+		{text: "compare master feature", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "will list the commits only in master alongside the commits only in feature."},
+		{text: "A ref can also be compared with HEAD by pressing C in the RefView."},
+	}
+
+	return []*HelpSection{
+		{
+			description: description,
+		},
+	}
+}
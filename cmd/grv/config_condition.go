@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var numericGRVVariables = map[GRVVariable]bool{
+	VarLineNumer:    true,
+	VarLineCount:    true,
+	VarTerminalRows: true,
+	VarTerminalCols: true,
+}
+
+type conditionalBlock struct {
+	commandToken *ConfigToken
+	parentActive bool
+	conditionMet bool
+	inElse       bool
+}
+
+func (block *conditionalBlock) active() bool {
+	return block.parentActive && block.conditionMet != block.inElse
+}
+
+// conditionalBlockStack tracks the if blocks enclosing the command currently being processed
+type conditionalBlockStack struct {
+	blocks []*conditionalBlock
+}
+
+func (stack *conditionalBlockStack) active() bool {
+	if len(stack.blocks) == 0 {
+		return true
+	}
+
+	return stack.blocks[len(stack.blocks)-1].active()
+}
+
+func (stack *conditionalBlockStack) push(commandToken *ConfigToken, conditionMet bool) {
+	stack.blocks = append(stack.blocks, &conditionalBlock{
+		commandToken: commandToken,
+		parentActive: stack.active(),
+		conditionMet: conditionMet,
+	})
+}
+
+func (stack *conditionalBlockStack) enterElse() bool {
+	if len(stack.blocks) == 0 {
+		return false
+	}
+
+	block := stack.blocks[len(stack.blocks)-1]
+	if block.inElse {
+		return false
+	}
+
+	block.inElse = true
+
+	return true
+}
+
+func (stack *conditionalBlockStack) pop() bool {
+	if len(stack.blocks) == 0 {
+		return false
+	}
+
+	stack.blocks = stack.blocks[:len(stack.blocks)-1]
+
+	return true
+}
+
+type conditionField struct {
+	fieldType FieldType
+	value     string
+}
+
+// conditionFieldDescriptor exposes GRV variables, config variables and user variables as fields
+// which can be tested by if conditions. Field names are variable names with hyphens removed
+type conditionFieldDescriptor struct {
+	fields map[string]conditionField
+}
+
+func newConditionFieldDescriptor(config *Configuration) *conditionFieldDescriptor {
+	fields := map[string]conditionField{}
+
+	for name, value := range config.userVariables {
+		fields[conditionFieldName(name)] = conditionField{fieldType: FtString, value: value}
+	}
+
+	for configVariable, variable := range config.configVariables {
+		field := conditionField{fieldType: FtString, value: fmt.Sprintf("%v", variable.value)}
+
+		switch variable.value.(type) {
+		case int, float64:
+			field.fieldType = FtNumber
+		}
+
+		fields[conditionFieldName(string(configVariable))] = field
+	}
+
+	for _, variableDescriptor := range variableDescriptors {
+		field := conditionField{fieldType: FtString}
+		field.value, _ = config.variables.VariableValue(variableDescriptor.variable)
+
+		if numericGRVVariables[variableDescriptor.variable] {
+			field.fieldType = FtNumber
+		}
+
+		fields[conditionFieldName(variableDescriptor.name)] = field
+	}
+
+	return &conditionFieldDescriptor{
+		fields: fields,
+	}
+}
+
+func conditionFieldName(variableName string) string {
+	return strings.ToLower(strings.Replace(variableName, "-", "", -1))
+}
+
+func (fieldDescriptor *conditionFieldDescriptor) FieldType(fieldName string) (fieldType FieldType, fieldExists bool) {
+	field, fieldExists := fieldDescriptor.fields[strings.ToLower(fieldName)]
+	fieldType = field.fieldType
+
+	return
+}
+
+func (fieldDescriptor *conditionFieldDescriptor) FieldValue(inputValue interface{}, fieldName string) interface{} {
+	field := fieldDescriptor.fields[strings.ToLower(fieldName)]
+
+	if field.fieldType == FtNumber {
+		number, _ := strconv.ParseFloat(field.value, 64)
+		return number
+	}
+
+	return field.value
+}
+
+// evaluateCondition evaluates a query against the current values of all variables
+func (config *Configuration) evaluateCondition(condition string) (conditionMet bool, errs []error) {
+	filter, errs := CreateFilter(condition, newConditionFieldDescriptor(config))
+	if len(errs) > 0 || filter == nil {
+		return
+	}
+
+	conditionMet = filter(nil)
+
+	return
+}
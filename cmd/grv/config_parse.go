@@ -16,6 +16,7 @@ import (
 const (
 	setCommand            = "set"
 	themeCommand          = "theme"
+	keymapCommand         = "keymap"
 	mapCommand            = "map"
 	unmapCommand          = "unmap"
 	quitCommand           = "q"
@@ -31,15 +32,35 @@ const (
 	defCommand            = "def"
 	undefCommand          = "undef"
 	evalkeysCommand       = "evalkeys"
+	macrosCommand         = "macros"
+	macromapCommand       = "macromap"
 	sleepCommand          = "sleep"
+	exportCommand         = "export"
+	exportthemeCommand    = "exporttheme"
+	filterCommand         = "filter"
+	hookCommand           = "hook"
+	sourceCommand         = "source"
+	letCommand            = "let"
+	ifCommand             = "if"
+	elseCommand           = "else"
+	endifCommand          = "endif"
+	compareCommand        = "compare"
 )
 
 const (
-	openingBrace = "{"
-	closingBrace = "}"
+	openingBrace       = "{"
+	closingBrace       = "}"
+	letAssignment      = "="
+	openingParenthesis = "("
+	closingParenthesis = ")"
+	parameterSeparator = ","
+	parameterDefault   = "="
 )
 
 var isIdentifier = regexp.MustCompile(`[[:alnum:]]+`).MatchString
+var isVariableName = regexp.MustCompile(`^[[:alpha:]][[:alnum:]-]*$`).MatchString
+var isParameterName = regexp.MustCompile(`^[[:alpha:]][[:alnum:]_]*$`).MatchString
+var parameterDelimiterPattern = regexp.MustCompile(`[(),=]`)
 var commentTokens = map[ConfigTokenType]bool{
 	CtkComment: true,
 }
@@ -78,6 +99,17 @@ type ThemeCommand struct {
 
 func (themeCommand *ThemeCommand) configCommand() {}
 
+// KeymapCommand contains state for defining a keymap or changing a binding in a keymap
+type KeymapCommand struct {
+	name *ConfigToken
+	base *ConfigToken
+	view *ConfigToken
+	from *ConfigToken
+	to   *ConfigToken
+}
+
+func (keymapCommand *KeymapCommand) configCommand() {}
+
 // MapCommand contains state for mapping a key sequence to another
 type MapCommand struct {
 	view *ConfigToken
@@ -153,10 +185,19 @@ type ShellCommand struct {
 
 func (shellCommand *ShellCommand) configCommand() {}
 
+// CustomCommandParameter is a parameter declared in the signature of a user defined command
+type CustomCommandParameter struct {
+	name         string
+	defaultValue string
+	hasDefault   bool
+}
+
 // DefCommand represents a function definition command
 type DefCommand struct {
-	commandName  string
-	functionBody string
+	commandName        string
+	parameters         []*CustomCommandParameter
+	parametersDeclared bool
+	functionBody       string
 }
 
 func (defCommand *DefCommand) configCommand() {}
@@ -183,6 +224,20 @@ type EvalKeysCommand struct {
 
 func (evalKeysCommand *EvalKeysCommand) configCommand() {}
 
+// MacrosCommand represents the command to show the macro view
+type MacrosCommand struct{}
+
+func (macrosCommand *MacrosCommand) configCommand() {}
+
+// MacroMapCommand represents the command to map a key sequence to a recorded macro
+type MacroMapCommand struct {
+	view     *ConfigToken
+	from     *ConfigToken
+	register *ConfigToken
+}
+
+func (macroMapCommand *MacroMapCommand) configCommand() {}
+
 // SleepCommand represents a command to sleep
 type SleepCommand struct {
 	sleepSeconds float64
@@ -190,15 +245,92 @@ type SleepCommand struct {
 
 func (sleepCommand *SleepCommand) configCommand() {}
 
+// ExportCommand represents a command to export the rows of the active view to a file
+type ExportCommand struct {
+	filePath string
+	format   string
+}
+
+func (exportCommand *ExportCommand) configCommand() {}
+
+// ExportThemeCommand represents a command to write the active theme to a theme file
+type ExportThemeCommand struct {
+	name     string
+	filePath string
+}
+
+func (exportThemeCommand *ExportThemeCommand) configCommand() {}
+
+// FilterCommand represents a command to apply a filter to the active view
+type FilterCommand struct {
+	query string
+}
+
+func (filterCommand *FilterCommand) configCommand() {}
+
+// HookCommand represents a command to run a command when an event occurs
+type HookCommand struct {
+	eventType EventType
+	command   string
+}
+
+func (hookCommand *HookCommand) configCommand() {}
+
+// SourceCommand represents a command to load configuration from a file
+type SourceCommand struct {
+	filePath *ConfigToken
+}
+
+func (sourceCommand *SourceCommand) configCommand() {}
+
+// LetCommand represents a command to set the value of a user variable
+type LetCommand struct {
+	name  *ConfigToken
+	value string
+}
+
+func (letCommand *LetCommand) configCommand() {}
+
+// IfCommand represents the start of a block of commands which are only processed when the condition is true
+type IfCommand struct {
+	commandToken *ConfigToken
+	condition    string
+}
+
+func (ifCommand *IfCommand) configCommand() {}
+
+// ElseCommand represents the start of a block of commands which are processed when the preceding if condition is false
+type ElseCommand struct {
+	commandToken *ConfigToken
+}
+
+func (elseCommand *ElseCommand) configCommand() {}
+
+// EndIfCommand represents the end of an if block
+type EndIfCommand struct {
+	commandToken *ConfigToken
+}
+
+func (endIfCommand *EndIfCommand) configCommand() {}
+
+// CompareCommand represents a command to show the commits unique to each of two refs
+type CompareCommand struct {
+	refA string
+	refB string
+}
+
+func (compareCommand *CompareCommand) configCommand() {}
+
 type commandHelpGenerator func(config Config) []*HelpSection
 type commandCustomParser func(parser *ConfigParser) (tokens []*ConfigToken, err error)
 
 type commandDescriptor struct {
-	tokenTypes           []ConfigTokenType
-	constructor          commandConstructor
-	commandHelpGenerator commandHelpGenerator
-	customParser         commandCustomParser
-	userDefined          bool
+	tokenTypes             []ConfigTokenType
+	constructor            commandConstructor
+	commandHelpGenerator   commandHelpGenerator
+	customParser           commandCustomParser
+	userDefined            bool
+	deferVariableExpansion bool
 }
 
 // DefineCustomCommand allows a custom command to be parsed
@@ -238,15 +370,23 @@ var commandDescriptors = map[string]*commandDescriptor{
 		constructor:          themeCommandConstructor,
 		commandHelpGenerator: GenerateThemeCommandHelpSections,
 	},
+	keymapCommand: {
+		customParser:           parseVarArgsCommand(),
+		constructor:            keymapCommandConstructor,
+		commandHelpGenerator:   GenerateKeymapCommandHelpSections,
+		deferVariableExpansion: true,
+	},
 	mapCommand: {
-		tokenTypes:           []ConfigTokenType{CtkWord, CtkWord, CtkWord | CtkShellCommand},
-		constructor:          mapCommandConstructor,
-		commandHelpGenerator: GenerateMapCommandHelpSections,
+		tokenTypes:             []ConfigTokenType{CtkWord, CtkWord, CtkWord | CtkShellCommand},
+		constructor:            mapCommandConstructor,
+		commandHelpGenerator:   GenerateMapCommandHelpSections,
+		deferVariableExpansion: true,
 	},
 	unmapCommand: {
-		tokenTypes:           []ConfigTokenType{CtkWord, CtkWord},
-		constructor:          unmapCommandConstructor,
-		commandHelpGenerator: GenerateUnmapCommandHelpSections,
+		tokenTypes:             []ConfigTokenType{CtkWord, CtkWord},
+		constructor:            unmapCommandConstructor,
+		commandHelpGenerator:   GenerateUnmapCommandHelpSections,
+		deferVariableExpansion: true,
 	},
 	quitCommand: {
 		constructor:          quitCommandConstructor,
@@ -297,9 +437,10 @@ var commandDescriptors = map[string]*commandDescriptor{
 		commandHelpGenerator: GenerateHelpCommandHelpSections,
 	},
 	defCommand: {
-		customParser:         parseDefCommand,
-		constructor:          defCommandConstructor,
-		commandHelpGenerator: GenerateDefCommandHelpSections,
+		customParser:           parseDefCommand,
+		constructor:            defCommandConstructor,
+		commandHelpGenerator:   GenerateDefCommandHelpSections,
+		deferVariableExpansion: true,
 	},
 	undefCommand: {
 		tokenTypes:           []ConfigTokenType{CtkWord},
@@ -311,11 +452,68 @@ var commandDescriptors = map[string]*commandDescriptor{
 		constructor:          evalKeysCommandConstructor,
 		commandHelpGenerator: GenerateEvalKeysCommandHelpSections,
 	},
+	macrosCommand: {
+		constructor:          macrosCommandConstructor,
+		commandHelpGenerator: GenerateMacrosCommandHelpSections,
+	},
+	macromapCommand: {
+		tokenTypes:             []ConfigTokenType{CtkWord, CtkWord, CtkWord},
+		constructor:            macroMapCommandConstructor,
+		commandHelpGenerator:   GenerateMacroMapCommandHelpSections,
+		deferVariableExpansion: true,
+	},
 	sleepCommand: {
 		tokenTypes:           []ConfigTokenType{CtkWord},
 		constructor:          sleepCommandConstructor,
 		commandHelpGenerator: GenerateSleepCommandHelpSections,
 	},
+	exportCommand: {
+		customParser:         parseVarArgsCommand(),
+		constructor:          exportCommandConstructor,
+		commandHelpGenerator: GenerateExportCommandHelpSections,
+	},
+	exportthemeCommand: {
+		customParser:         parseVarArgsCommand(),
+		constructor:          exportThemeCommandConstructor,
+		commandHelpGenerator: GenerateExportThemeCommandHelpSections,
+	},
+	filterCommand: {
+		customParser:         parseVarArgsParserGenerator(false),
+		constructor:          filterCommandConstructor,
+		commandHelpGenerator: GenerateFilterCommandHelpSections,
+	},
+	hookCommand: {
+		customParser:           parseVarArgsParserGenerator(false),
+		constructor:            hookCommandConstructor,
+		commandHelpGenerator:   GenerateHookCommandHelpSections,
+		deferVariableExpansion: true,
+	},
+	sourceCommand: {
+		tokenTypes:           []ConfigTokenType{CtkWord},
+		constructor:          sourceCommandConstructor,
+		commandHelpGenerator: GenerateSourceCommandHelpSections,
+	},
+	letCommand: {
+		tokenTypes:           []ConfigTokenType{CtkWord, CtkWord, CtkWord},
+		constructor:          letCommandConstructor,
+		commandHelpGenerator: GenerateLetCommandHelpSections,
+	},
+	ifCommand: {
+		customParser:         parseVarArgsParserGenerator(false),
+		constructor:          ifCommandConstructor,
+		commandHelpGenerator: GenerateIfCommandHelpSections,
+	},
+	elseCommand: {
+		constructor: elseCommandConstructor,
+	},
+	endifCommand: {
+		constructor: endIfCommandConstructor,
+	},
+	compareCommand: {
+		customParser:         parseVarArgsCommand(),
+		constructor:          compareCommandConstructor,
+		commandHelpGenerator: GenerateCompareCommandHelpSections,
+	},
 }
 
 // GenerateConfigCommandHelpSections generates help documentation for all configuration commands
@@ -330,7 +528,15 @@ func GenerateConfigCommandHelpSections(config Config) (helpSections []*HelpSecti
 			{text: " - $HOME/.config/grv/grvrc", themeComponentID: CmpHelpViewSectionCodeBlock},
 			{},
 			{text: "GRV will attempt to process the first file which exists."},
+			{text: "A .grvrc file in the root of the work tree is processed afterwards, allowing configuration to be set per repository."},
+			{text: "GRV asks for confirmation before processing a repository config file it has not seen before or which has changed."},
+			{text: "Config files are reloaded automatically when they are modified. Open tabs and views are retained and"},
+			{text: "commands which modify the layout, such as addtab, are not run again. If any errors are found when"},
+			{text: "reloading then they are displayed and the existing configuration remains active."},
 			{text: "Commands can also be specified within GRV using the command prompt :"},
+			{text: "Pressing <Tab> at the command prompt completes command names, config variable names and values,"},
+			{text: "view names, ref names, theme names, action names and variable names following ${."},
+			{text: "When more than one completion is possible, pressing <Tab> again lists them in the help bar."},
 			{},
 			{text: "Below are the set of configuration commands supported:"},
 		},
@@ -355,10 +561,14 @@ func GenerateConfigCommandHelpSections(config Config) (helpSections []*HelpSecti
 	return
 }
 
+// VariableExpander replaces variable references in the provided text with their values
+type VariableExpander func(text string) string
+
 // ConfigParser is a component capable of parsing config into commands
 type ConfigParser struct {
-	scanner     *ConfigScanner
-	inputSource string
+	scanner          *ConfigScanner
+	inputSource      string
+	variableExpander VariableExpander
 }
 
 // NewConfigParser creates a new ConfigParser which will read input from the provided reader
@@ -412,6 +622,21 @@ func (parser *ConfigParser) InputSource() string {
 	return parser.inputSource
 }
 
+// SetVariableExpander sets the expander used to substitute variables into command arguments.
+// Commands which store other commands to be run later have their variables expanded when they are run
+func (parser *ConfigParser) SetVariableExpander(variableExpander VariableExpander) {
+	parser.variableExpander = variableExpander
+}
+
+func (parser *ConfigParser) expandVariables(tokens []*ConfigToken) {
+	for _, token := range tokens {
+		if token.tokenType == CtkWord {
+			token.value = parser.variableExpander(token.value)
+			token.rawValue = parser.variableExpander(token.rawValue)
+		}
+	}
+}
+
 func (parser *ConfigParser) scanAndIgnore(ignoreTokens map[ConfigTokenType]bool) (token *ConfigToken, err error) {
 	for {
 		token, err = parser.scanner.Scan()
@@ -511,6 +736,10 @@ func (parser *ConfigParser) parseCommand(commandToken *ConfigToken) (command Con
 		}
 	}
 
+	if parser.variableExpander != nil && !commandDescriptor.deferVariableExpansion {
+		parser.expandVariables(tokens)
+	}
+
 	command, err = commandDescriptor.constructor(parser, commandToken, tokens)
 
 	return
@@ -561,18 +790,41 @@ func parseDefCommand(parser *ConfigParser) (tokens []*ConfigToken, err error) {
 		return
 	} else if commandNameToken.tokenType != CtkWord {
 		return tokens, parser.generateParseError(commandNameToken, "Expected function name but found %v", commandNameToken.value)
-	} else if !isIdentifier(commandNameToken.value) {
-		return tokens, parser.generateParseError(commandNameToken, "Invalid function identifier %v", commandNameToken.value)
+	} else if commandName := defCommandName(commandNameToken); !isIdentifier(commandName) {
+		return tokens, parser.generateParseError(commandNameToken, "Invalid function identifier %v", commandName)
 	}
 
 	tokens = append(tokens, commandNameToken)
+	signatureOpen := strings.Contains(commandNameToken.value, openingParenthesis)
+	signatureClosed := signatureOpen && strings.Contains(commandNameToken.value, closingParenthesis)
 
-	openingBraceToken, err := parser.scanIgnoringTerminators()
-	if err != nil {
-		return
-	} else if err = openingBraceToken.err; err != nil {
-		return
-	} else if openingBraceToken.tokenType != CtkWord || openingBraceToken.rawValue != openingBrace {
+	var openingBraceToken *ConfigToken
+
+	for {
+		if openingBraceToken, err = parser.scanIgnoringTerminators(); err != nil {
+			return
+		} else if err = openingBraceToken.err; err != nil {
+			return
+		}
+
+		isSignatureToken := openingBraceToken.tokenType == CtkWord && !isQuotedToken(openingBraceToken)
+
+		if !signatureOpen && isSignatureToken && strings.HasPrefix(openingBraceToken.value, openingParenthesis) {
+			signatureOpen = true
+		} else if !signatureOpen || signatureClosed {
+			break
+		} else if openingBraceToken.tokenType == CtkEOF {
+			return tokens, parser.generateParseError(openingBraceToken, "Expected %v but reached EOF", closingParenthesis)
+		}
+
+		if isSignatureToken && strings.Contains(openingBraceToken.value, closingParenthesis) {
+			signatureClosed = true
+		}
+
+		tokens = append(tokens, openingBraceToken)
+	}
+
+	if openingBraceToken.tokenType != CtkWord || openingBraceToken.rawValue != openingBrace {
 		return tokens, parser.generateParseError(openingBraceToken, "Expected %v but found %v", openingBrace, openingBraceToken.value)
 	}
 
@@ -610,6 +862,18 @@ func parseDefCommand(parser *ConfigParser) (tokens []*ConfigToken, err error) {
 	return
 }
 
+func defCommandName(commandNameToken *ConfigToken) string {
+	if index := strings.Index(commandNameToken.value, openingParenthesis); index != -1 {
+		return commandNameToken.value[:index]
+	}
+
+	return commandNameToken.value
+}
+
+func isQuotedToken(token *ConfigToken) bool {
+	return strings.HasPrefix(token.rawValue, `"`)
+}
+
 func (parser *ConfigParser) shellCommand(commandToken *ConfigToken) *ShellCommand {
 	return &ShellCommand{
 		command: commandToken,
@@ -648,6 +912,44 @@ func themeCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, to
 	return themeCommand, nil
 }
 
+func keymapCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	keymapCommand := &KeymapCommand{}
+
+	optionSetters := map[string]func(*ConfigToken){
+		"--name": func(name *ConfigToken) { keymapCommand.name = name },
+		"--base": func(base *ConfigToken) { keymapCommand.base = base },
+		"--view": func(view *ConfigToken) { keymapCommand.view = view },
+		"--from": func(from *ConfigToken) { keymapCommand.from = from },
+		"--to":   func(to *ConfigToken) { keymapCommand.to = to },
+	}
+
+	for i := 0; i < len(tokens); i += 2 {
+		optionToken := tokens[i]
+
+		optionSetter, ok := optionSetters[optionToken.value]
+		if optionToken.tokenType != CtkOption || !ok {
+			return nil, parser.generateParseError(optionToken, "Invalid option for keymap command: \"%v\"", optionToken.value)
+		} else if i+1 >= len(tokens) || tokens[i+1].tokenType&(CtkWord|CtkShellCommand) == 0 {
+			return nil, parser.generateParseError(optionToken, "Expected value for option %v", optionToken.value)
+		}
+
+		optionSetter(tokens[i+1])
+	}
+
+	switch {
+	case keymapCommand.name == nil:
+		return nil, parser.generateParseError(commandToken, "The keymap command requires the --name option")
+	case keymapCommand.from == nil && keymapCommand.base == nil:
+		return nil, parser.generateParseError(commandToken, "The keymap command requires either the --base or --from option")
+	case keymapCommand.from == nil && (keymapCommand.view != nil || keymapCommand.to != nil):
+		return nil, parser.generateParseError(commandToken, "The --view and --to options require the --from option")
+	case keymapCommand.from != nil && keymapCommand.view == nil:
+		return nil, parser.generateParseError(commandToken, "The --from option requires the --view option")
+	}
+
+	return keymapCommand, nil
+}
+
 func mapCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
 	return &MapCommand{
 		view: tokens[0],
@@ -740,18 +1042,151 @@ func defCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, toke
 		return
 	}
 
-	commandName := tokens[0].value
+	openingBraceIndex := 1
+	for openingBraceIndex < len(tokens) && (tokens[openingBraceIndex].rawValue != openingBrace || isQuotedToken(tokens[openingBraceIndex])) {
+		openingBraceIndex++
+	}
+
+	if len(tokens)-openingBraceIndex < 2 {
+		err = parser.generateParseError(commandToken, "Too few tokens (%v) for function definition", len(tokens))
+		return
+	}
+
+	parameters, parametersDeclared, err := parseDefCommandParameters(parser, tokens[:openingBraceIndex])
+	if err != nil {
+		return
+	}
+
 	var functionBodyBuffer bytes.Buffer
 
-	for i := 2; i < len(tokens)-1; i++ {
+	for i := openingBraceIndex + 1; i < len(tokens)-1; i++ {
 		functionBodyBuffer.WriteString(tokens[i].rawValue)
 	}
 
 	functionBody := functionBodyBuffer.String()
 
 	configCommand = &DefCommand{
-		commandName:  commandName,
-		functionBody: functionBody,
+		commandName:        defCommandName(tokens[0]),
+		parameters:         parameters,
+		parametersDeclared: parametersDeclared,
+		functionBody:       functionBody,
+	}
+
+	return
+}
+
+type defSignatureElement struct {
+	token     *ConfigToken
+	value     string
+	delimiter bool
+}
+
+// splitDefSignature breaks the signature tokens of a def command into parameter names, default values and delimiters.
+// Quoted values are never split
+func splitDefSignature(tokens []*ConfigToken) (elements []defSignatureElement) {
+	for tokenIndex, token := range tokens {
+		value := token.value
+
+		if tokenIndex == 0 {
+			index := strings.Index(value, openingParenthesis)
+			if index == -1 {
+				continue
+			}
+
+			value = value[index:]
+		}
+
+		if isQuotedToken(token) {
+			elements = append(elements, defSignatureElement{token: token, value: value})
+			continue
+		}
+
+		lastIndex := 0
+		for _, delimiterIndexes := range parameterDelimiterPattern.FindAllStringIndex(value, -1) {
+			if delimiterIndexes[0] > lastIndex {
+				elements = append(elements, defSignatureElement{token: token, value: value[lastIndex:delimiterIndexes[0]]})
+			}
+
+			elements = append(elements, defSignatureElement{token: token, value: value[delimiterIndexes[0]:delimiterIndexes[1]], delimiter: true})
+			lastIndex = delimiterIndexes[1]
+		}
+
+		if lastIndex < len(value) {
+			elements = append(elements, defSignatureElement{token: token, value: value[lastIndex:]})
+		}
+	}
+
+	return
+}
+
+func parseDefCommandParameters(parser *ConfigParser, tokens []*ConfigToken) (parameters []*CustomCommandParameter, parametersDeclared bool, err error) {
+	elements := splitDefSignature(tokens)
+	if len(elements) == 0 {
+		return
+	}
+
+	parametersDeclared = true
+	parameterNames := map[string]bool{}
+	elementIndex := 1
+
+	nextElement := func() (element defSignatureElement, ok bool) {
+		if elementIndex < len(elements) {
+			element, ok = elements[elementIndex], true
+			elementIndex++
+		} else {
+			element.token = tokens[len(tokens)-1]
+		}
+
+		return
+	}
+
+	for {
+		element, ok := nextElement()
+		if !ok {
+			err = parser.generateParseError(element.token, "Expected %v after parameter list", closingParenthesis)
+			return
+		} else if element.delimiter && element.value == closingParenthesis && len(parameters) == 0 {
+			break
+		} else if element.delimiter || !isParameterName(element.value) {
+			err = parser.generateParseError(element.token, "Invalid parameter name %v", element.value)
+			return
+		} else if parameterNames[element.value] {
+			err = parser.generateParseError(element.token, "Duplicate parameter %v", element.value)
+			return
+		}
+
+		parameter := &CustomCommandParameter{name: element.value}
+		parameterToken := element.token
+		parameterNames[parameter.name] = true
+		parameters = append(parameters, parameter)
+
+		if element, ok = nextElement(); ok && element.delimiter && element.value == parameterDefault {
+			if element, ok = nextElement(); !ok || element.delimiter {
+				err = parser.generateParseError(element.token, "Expected default value for parameter %v", parameter.name)
+				return
+			}
+
+			parameter.defaultValue = element.value
+			parameter.hasDefault = true
+			element, ok = nextElement()
+		} else if len(parameters) > 1 && parameters[len(parameters)-2].hasDefault {
+			err = parser.generateParseError(parameterToken, "Parameter %v must have a default value as it follows a parameter with a default value", parameter.name)
+			return
+		}
+
+		if !ok {
+			err = parser.generateParseError(element.token, "Expected %v after parameter list", closingParenthesis)
+			return
+		} else if !element.delimiter || (element.value != parameterSeparator && element.value != closingParenthesis) {
+			err = parser.generateParseError(element.token, "Expected %v or %v but found %v", parameterSeparator, closingParenthesis, element.value)
+			return
+		} else if element.value == closingParenthesis {
+			break
+		}
+	}
+
+	if element, ok := nextElement(); ok {
+		err = parser.generateParseError(element.token, "Unexpected %v after parameter list", element.value)
 	}
 
 	return
@@ -794,6 +1229,18 @@ func evalKeysCommandConstructor(parser *ConfigParser, commandToken *ConfigToken,
 	}, nil
 }
 
+func macrosCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &MacrosCommand{}, nil
+}
+
+func macroMapCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &MacroMapCommand{
+		view:     tokens[0],
+		from:     tokens[1],
+		register: tokens[2],
+	}, nil
+}
+
 func sleepCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
 	if len(tokens) < 1 {
 		return nil, parser.generateParseError(commandToken, "No sleep time specified")
@@ -809,3 +1256,165 @@ func sleepCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, to
 		sleepSeconds: sleepSeconds,
 	}, nil
 }
+
+func exportCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	if len(tokens) < 1 || len(tokens) > 2 {
+		return nil, parser.generateParseError(commandToken, "Invalid %[1]v command. Usage: %[1]v FILE [csv|json]", exportCommand)
+	}
+
+	filePath := tokens[0].value
+	format := DetermineExportFormat(filePath)
+
+	if len(tokens) > 1 {
+		formatToken := tokens[1]
+		if !IsValidExportFormat(formatToken.value) {
+			return nil, parser.generateParseError(formatToken, "Invalid export format: %v. Must be one of %v or %v",
+				formatToken.value, exportFormatCSV, exportFormatJSON)
+		}
+
+		format = formatToken.value
+	}
+
+	return &ExportCommand{
+		filePath: filePath,
+		format:   format,
+	}, nil
+}
+
+func exportThemeCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	if len(tokens) < 1 || len(tokens) > 2 {
+		return nil, parser.generateParseError(commandToken, "Invalid %[1]v command. Usage: %[1]v NAME [FILE]", exportthemeCommand)
+	}
+
+	exportThemeCommand := &ExportThemeCommand{
+		name: tokens[0].value,
+	}
+
+	if len(tokens) > 1 {
+		exportThemeCommand.filePath = tokens[1].value
+	}
+
+	return exportThemeCommand, nil
+}
+
+func filterCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	var buffer bytes.Buffer
+
+	for _, token := range tokens {
+		buffer.WriteString(token.rawValue)
+	}
+
+	query := strings.TrimSpace(buffer.String())
+	if query == "" {
+		return nil, parser.generateParseError(commandToken, "No query specified for %v command", filterCommand)
+	}
+
+	return &FilterCommand{
+		query: query,
+	}, nil
+}
+
+func hookCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	for len(tokens) > 0 && tokens[0].tokenType == CtkWhiteSpace {
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) == 0 || tokens[0].tokenType != CtkWord {
+		return nil, parser.generateParseError(commandToken, "Invalid %[1]v command. Usage: %[1]v EVENT COMMAND", hookCommand)
+	}
+
+	eventToken := tokens[0]
+	eventType, exists := LookupHookEvent(eventToken.value)
+	if !exists {
+		return nil, parser.generateParseError(eventToken, "Invalid hook event: %v", eventToken.value)
+	}
+
+	var buffer bytes.Buffer
+
+	for _, token := range tokens[1:] {
+		buffer.WriteString(token.rawValue)
+	}
+
+	command := strings.TrimSpace(buffer.String())
+	if command == "" {
+		return nil, parser.generateParseError(eventToken, "No command specified for %v hook", eventToken.value)
+	}
+
+	return &HookCommand{
+		eventType: eventType,
+		command:   command,
+	}, nil
+}
+
+func sourceCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	if tokens[0].value == "" {
+		return nil, parser.generateParseError(tokens[0], "No file specified for %v command", sourceCommand)
+	}
+
+	return &SourceCommand{
+		filePath: tokens[0],
+	}, nil
+}
+
+func letCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	nameToken := tokens[0]
+	if !isVariableName(nameToken.value) {
+		return nil, parser.generateParseError(nameToken, "Invalid variable name %v", nameToken.value)
+	}
+
+	if assignmentToken := tokens[1]; assignmentToken.value != letAssignment {
+		return nil, parser.generateParseError(assignmentToken, "Expected %v but found %v", letAssignment, assignmentToken.value)
+	}
+
+	return &LetCommand{
+		name:  nameToken,
+		value: tokens[2].value,
+	}, nil
+}
+
+func ifCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	var buffer bytes.Buffer
+
+	for _, token := range tokens {
+		buffer.WriteString(token.rawValue)
+	}
+
+	condition := strings.TrimSpace(buffer.String())
+	if condition == "" {
+		return nil, parser.generateParseError(commandToken, "No condition specified for %v command", ifCommand)
+	}
+
+	return &IfCommand{
+		commandToken: commandToken,
+		condition:    condition,
+	}, nil
+}
+
+func elseCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	return &ElseCommand{
+		commandToken: commandToken,
+	}, nil
+}
+
+func endIfCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	return &EndIfCommand{
+		commandToken: commandToken,
+	}, nil
+}
+
+func compareCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (configCommand ConfigCommand, err error) {
+	if len(tokens) < 1 || len(tokens) > 2 {
+		return nil, parser.generateParseError(commandToken, "Invalid %[1]v command. Usage: %[1]v REF [REF]", compareCommand)
+	}
+
+	command := &CompareCommand{
+		refA: tokens[0].value,
+		refB: "HEAD",
+	}
+
+	if len(tokens) > 1 {
+		command.refB = tokens[1].value
+	}
+
+	return command, nil
+}
@@ -64,6 +64,64 @@ func (themeCommandValues *ThemeCommandValues) Equal(command ConfigCommand) bool
 		themeCommandValues.fgcolour == other.fgcolor.value
 }
 
+type KeymapCommandValues struct {
+	name string
+	base string
+	view string
+	from string
+	to   string
+}
+
+func (keymapCommandValues *KeymapCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*KeymapCommand)
+	if !ok {
+		return false
+	}
+
+	tokenValue := func(token *ConfigToken) string {
+		if token == nil {
+			return ""
+		}
+
+		return token.value
+	}
+
+	return keymapCommandValues.name == tokenValue(other.name) &&
+		keymapCommandValues.base == tokenValue(other.base) &&
+		keymapCommandValues.view == tokenValue(other.view) &&
+		keymapCommandValues.from == tokenValue(other.from) &&
+		keymapCommandValues.to == tokenValue(other.to)
+}
+
+type MacroMapCommandValues struct {
+	view     string
+	from     string
+	register string
+}
+
+func (macroMapCommandValues *MacroMapCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*MacroMapCommand)
+	if !ok {
+		return false
+	}
+
+	if other.view == nil || other.from == nil || other.register == nil {
+		return false
+	}
+
+	return macroMapCommandValues.view == other.view.value &&
+		macroMapCommandValues.from == other.from.value &&
+		macroMapCommandValues.register == other.register.value
+}
+
 type MapCommandValues struct {
 	view string
 	from string
@@ -261,8 +319,10 @@ func (shellCommandValues *ShellCommandValues) Equal(command ConfigCommand) bool
 }
 
 type DefCommandValues struct {
-	commandName  string
-	functionBody string
+	commandName        string
+	parameters         []*CustomCommandParameter
+	parametersDeclared bool
+	functionBody       string
 }
 
 func (defCommandValues *DefCommandValues) Equal(command ConfigCommand) bool {
@@ -276,6 +336,8 @@ func (defCommandValues *DefCommandValues) Equal(command ConfigCommand) bool {
 	}
 
 	return defCommandValues.commandName == other.commandName &&
+		reflect.DeepEqual(defCommandValues.parameters, other.parameters) &&
+		defCommandValues.parametersDeclared == other.parametersDeclared &&
 		defCommandValues.functionBody == other.functionBody
 }
 
@@ -332,6 +394,97 @@ func (sleepCommandValues *SleepCommandValues) Equal(command ConfigCommand) bool
 	return sleepCommandValues.sleepSeconds == other.sleepSeconds
 }
 
+type ExportCommandValues struct {
+	filePath string
+	format   string
+}
+
+func (exportCommandValues *ExportCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*ExportCommand)
+	if !ok {
+		return false
+	}
+
+	return exportCommandValues.filePath == other.filePath &&
+		exportCommandValues.format == other.format
+}
+
+type FilterCommandValues struct {
+	query string
+}
+
+func (filterCommandValues *FilterCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*FilterCommand)
+	if !ok {
+		return false
+	}
+
+	return filterCommandValues.query == other.query
+}
+
+type HookCommandValues struct {
+	eventType EventType
+	command   string
+}
+
+func (hookCommandValues *HookCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*HookCommand)
+	if !ok {
+		return false
+	}
+
+	return hookCommandValues.eventType == other.eventType &&
+		hookCommandValues.command == other.command
+}
+
+type SourceCommandValues struct {
+	filePath string
+}
+
+func (sourceCommandValues *SourceCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*SourceCommand)
+	if !ok {
+		return false
+	}
+
+	return sourceCommandValues.filePath == other.filePath.value
+}
+
+type CompareCommandValues struct {
+	refA string
+	refB string
+}
+
+func (compareCommandValues *CompareCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*CompareCommand)
+	if !ok {
+		return false
+	}
+
+	return compareCommandValues.refA == other.refA &&
+		compareCommandValues.refB == other.refB
+}
+
 func TestParseSingleCommand(t *testing.T) {
 	var singleCommandTests = []struct {
 		input           string
@@ -353,6 +506,38 @@ func TestParseSingleCommand(t *testing.T) {
 				fgcolour:  "YELLOW",
 			},
 		},
+		{
+			input: "keymap --name mykeys --base emacs",
+			expectedCommand: &KeymapCommandValues{
+				name: "mykeys",
+				base: "emacs",
+			},
+		},
+		{
+			input: "keymap --name mykeys --view All --from <C-j> --to <grv-next-line>",
+			expectedCommand: &KeymapCommandValues{
+				name: "mykeys",
+				view: "All",
+				from: "<C-j>",
+				to:   "<grv-next-line>",
+			},
+		},
+		{
+			input: "keymap --name mykeys --view CommitView --from <C-x>",
+			expectedCommand: &KeymapCommandValues{
+				name: "mykeys",
+				view: "CommitView",
+				from: "<C-x>",
+			},
+		},
+		{
+			input: "macromap CommitView <C-j> a",
+			expectedCommand: &MacroMapCommandValues{
+				view:     "CommitView",
+				from:     "<C-j>",
+				register: "a",
+			},
+		},
 		{
 			input: "map All <C-c> <grv-prompt>q<Enter>",
 			expectedCommand: &MapCommandValues{
@@ -471,6 +656,38 @@ func TestParseSingleCommand(t *testing.T) {
 				functionBody: " addtab \\\n\t\"Test Tab\" ",
 			},
 		},
+		{
+			input: "def review(ref, author=me) { addtab $ref }",
+			expectedCommand: &DefCommandValues{
+				commandName: "review",
+				parameters: []*CustomCommandParameter{
+					{name: "ref"},
+					{name: "author", defaultValue: "me", hasDefault: true},
+				},
+				parametersDeclared: true,
+				functionBody:       " addtab $ref ",
+			},
+		},
+		{
+			input: "def review ( ref , title = \"My Review\" ) { addtab $title }",
+			expectedCommand: &DefCommandValues{
+				commandName: "review",
+				parameters: []*CustomCommandParameter{
+					{name: "ref"},
+					{name: "title", defaultValue: "My Review", hasDefault: true},
+				},
+				parametersDeclared: true,
+				functionBody:       " addtab $title ",
+			},
+		},
+		{
+			input: "def nop() { }",
+			expectedCommand: &DefCommandValues{
+				commandName:        "nop",
+				parametersDeclared: true,
+				functionBody:       " ",
+			},
+		},
 		{
 			input: "def\n myFunc \n{ addtab Main }",
 			expectedCommand: &DefCommandValues{
@@ -497,6 +714,73 @@ func TestParseSingleCommand(t *testing.T) {
 				sleepSeconds: 0.5,
 			},
 		},
+		{
+			input: "export commits.csv",
+			expectedCommand: &ExportCommandValues{
+				filePath: "commits.csv",
+				format:   "csv",
+			},
+		},
+		{
+			input: "export commits.JSON",
+			expectedCommand: &ExportCommandValues{
+				filePath: "commits.JSON",
+				format:   "json",
+			},
+		},
+		{
+			input: "export \"my refs\" json",
+			expectedCommand: &ExportCommandValues{
+				filePath: "my refs",
+				format:   "json",
+			},
+		},
+		{
+			input: "filter authorname = \"John Smith\" AND summary GLOB \"*fix*\"",
+			expectedCommand: &FilterCommandValues{
+				query: "authorname = \"John Smith\" AND summary GLOB \"*fix*\"",
+			},
+		},
+		{
+			input: "hook post-commit @git push origin ${branch}",
+			expectedCommand: &HookCommandValues{
+				eventType: PostCommitEvent,
+				command:   "@git push origin ${branch}",
+			},
+		},
+		{
+			input: "hook head-changed addview CommitView \"${head}\"",
+			expectedCommand: &HookCommandValues{
+				eventType: HeadChangedEvent,
+				command:   "addview CommitView \"${head}\"",
+			},
+		},
+		{
+			input: "source ~/.grv/commands",
+			expectedCommand: &SourceCommandValues{
+				filePath: "~/.grv/commands",
+			},
+		},
+		{
+			input: "source \"repo config\"",
+			expectedCommand: &SourceCommandValues{
+				filePath: "repo config",
+			},
+		},
+		{
+			input: "compare master feature",
+			expectedCommand: &CompareCommandValues{
+				refA: "master",
+				refB: "feature",
+			},
+		},
+		{
+			input: "compare origin/master",
+			expectedCommand: &CompareCommandValues{
+				refA: "origin/master",
+				refB: "HEAD",
+			},
+		},
 	}
 
 	for _, singleCommandTest := range singleCommandTests {
@@ -643,7 +927,23 @@ func TestErrorsAreReceivedForInvalidConfigTokenSequences(t *testing.T) {
 		},
 		{
 			input:                "def myfunc (",
-			expectedErrorMessage: ConfigFile + ":1:12 Expected { but found (",
+			expectedErrorMessage: ConfigFile + ":1:12 Expected ) but reached EOF",
+		},
+		{
+			input:                "def myfunc [ { }",
+			expectedErrorMessage: ConfigFile + ":1:12 Expected { but found [",
+		},
+		{
+			input:                "def review(ref=master, author) { }",
+			expectedErrorMessage: ConfigFile + ":1:24 Parameter author must have a default value as it follows a parameter with a default value",
+		},
+		{
+			input:                "def review(ref, ref) { }",
+			expectedErrorMessage: ConfigFile + ":1:17 Duplicate parameter ref",
+		},
+		{
+			input:                "def review(1ref) { }",
+			expectedErrorMessage: ConfigFile + ":1:5 Invalid parameter name 1ref",
 		},
 		{
 			input:                "def myfunc { addview RefView ",
@@ -653,6 +953,74 @@ func TestErrorsAreReceivedForInvalidConfigTokenSequences(t *testing.T) {
 			input:                "sleep -5",
 			expectedErrorMessage: ConfigFile + ":1:7 Invalid sleep time: -5. Must be a positive integer",
 		},
+		{
+			input:                "export",
+			expectedErrorMessage: ConfigFile + ":1:1 Invalid export command. Usage: export FILE [csv|json]",
+		},
+		{
+			input:                "export commits.txt xml",
+			expectedErrorMessage: ConfigFile + ":1:20 Invalid export format: xml. Must be one of csv or json",
+		},
+		{
+			input:                "filter",
+			expectedErrorMessage: ConfigFile + ":1:1 No query specified for filter command",
+		},
+		{
+			input:                "hook",
+			expectedErrorMessage: ConfigFile + ":1:1 Invalid hook command. Usage: hook EVENT COMMAND",
+		},
+		{
+			input:                "hook pre-commit @make test",
+			expectedErrorMessage: ConfigFile + ":1:6 Invalid hook event: pre-commit",
+		},
+		{
+			input:                "hook post-push",
+			expectedErrorMessage: ConfigFile + ":1:6 No command specified for post-push hook",
+		},
+		{
+			input:                "source \"\"",
+			expectedErrorMessage: ConfigFile + ":1:8 No file specified for source command",
+		},
+		{
+			input:                "let 1layout = wide",
+			expectedErrorMessage: ConfigFile + ":1:5 Invalid variable name 1layout",
+		},
+		{
+			input:                "let layout =",
+			expectedErrorMessage: ConfigFile + ":1:12 Unexpected EOF when parsing let command",
+		},
+		{
+			input:                "let layout is wide",
+			expectedErrorMessage: ConfigFile + ":1:12 Expected = but found is",
+		},
+		{
+			input:                "if",
+			expectedErrorMessage: ConfigFile + ":1:1 No condition specified for if command",
+		},
+		{
+			input:                "compare",
+			expectedErrorMessage: ConfigFile + ":1:1 Invalid compare command. Usage: compare REF [REF]",
+		},
+		{
+			input:                "compare master feature develop",
+			expectedErrorMessage: ConfigFile + ":1:1 Invalid compare command. Usage: compare REF [REF]",
+		},
+		{
+			input:                "keymap --base emacs",
+			expectedErrorMessage: ConfigFile + ":1:1 The keymap command requires the --name option",
+		},
+		{
+			input:                "keymap --name mykeys",
+			expectedErrorMessage: ConfigFile + ":1:1 The keymap command requires either the --base or --from option",
+		},
+		{
+			input:                "keymap --name mykeys --from j",
+			expectedErrorMessage: ConfigFile + ":1:1 The --from option requires the --view option",
+		},
+		{
+			input:                "keymap --name mykeys --view All --form j",
+			expectedErrorMessage: ConfigFile + ":1:33 Invalid option for keymap command: \"--form\"",
+		},
 	}
 
 	for _, errorTest := range errorTests {
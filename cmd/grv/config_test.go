@@ -1,6 +1,11 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -83,9 +88,415 @@ func TestCommandBodyArgumentsAreExpanded(t *testing.T) {
 	$$do
 	`
 
-	actualProcessedCommandBody := config.processConfigCommandBody(commandBody, args)
+	actualProcessedCommandBody := config.processConfigCommandBody(commandBody, args, nil)
 
 	if expectedProcessedCommandBody != actualProcessedCommandBody {
 		t.Errorf("Command body did not match expected value. Expected: %v, Actual: %v", expectedProcessedCommandBody, actualProcessedCommandBody)
 	}
 }
+
+func TestCommandBodyNamedArgumentsAreExpanded(t *testing.T) {
+	config := NewConfiguration(&MockKeyBindings{}, &MockChannels{}, &MockGRVVariableSetter{}, &MockInputConsumer{})
+
+	args := []string{"master", "me"}
+	namedArgs := map[string]string{
+		"ref":    "master",
+		"author": "me",
+	}
+
+	commandBody := `
+	$ref
+	"${author}s"
+	$1 $author
+	$refs
+	${branch}
+	$$ref
+	$$$author
+	$$unknown
+	`
+	expectedProcessedCommandBody := `
+	master
+	"mes"
+	master me
+	$refs
+	${branch}
+	$ref
+	$me
+	$$unknown
+	`
+
+	actualProcessedCommandBody := config.processConfigCommandBody(commandBody, args, namedArgs)
+
+	if expectedProcessedCommandBody != actualProcessedCommandBody {
+		t.Errorf("Command body did not match expected value. Expected: %v, Actual: %v", expectedProcessedCommandBody, actualProcessedCommandBody)
+	}
+}
+
+func TestCustomCommandArgumentsAreValidatedAgainstDeclaredParameters(t *testing.T) {
+	channels := &MockChannels{}
+	channels.On("ReportStatus", mock.Anything, mock.Anything).Return()
+	channels.On("DoAction", Action{ActionType: ActionNewTab, Args: []interface{}{"master-me"}}).Return()
+	channels.On("DoAction", Action{ActionType: ActionNewTab, Args: []interface{}{"develop-you"}}).Return()
+
+	config := NewConfiguration(&MockKeyBindings{}, channels, &MockGRVVariableSetter{}, &MockInputConsumer{})
+
+	if errs := config.Evaluate("def review(ref, author=me) { addtab $ref-$2 }"); len(errs) > 0 {
+		t.Fatalf("Evaluate failed with errors: %v", errs)
+	}
+
+	var evaluateTests = []struct {
+		input                string
+		expectedErrorMessage string
+	}{
+		{
+			input: "review master",
+		},
+		{
+			input: "review develop you",
+		},
+		{
+			input:                "review",
+			expectedErrorMessage: "Missing argument ref for command review. Usage: review ref [author=me]",
+		},
+		{
+			input:                "review master me extra",
+			expectedErrorMessage: "Command review accepts at most 2 argument(s) but received 3. Usage: review ref [author=me]",
+		},
+	}
+
+	for _, evaluateTest := range evaluateTests {
+		errs := config.Evaluate(evaluateTest.input)
+
+		if evaluateTest.expectedErrorMessage == "" {
+			if len(errs) > 0 {
+				t.Errorf("Evaluate of %q failed with errors: %v", evaluateTest.input, errs)
+			}
+		} else if len(errs) != 1 || !strings.HasSuffix(errs[0].Error(), evaluateTest.expectedErrorMessage) {
+			t.Errorf("Error for %q does not match expected value. Expected: %v, Actual: %v", evaluateTest.input, evaluateTest.expectedErrorMessage, errs)
+		}
+	}
+
+	channels.AssertExpectations(t)
+}
+
+func TestHooksAreRunWithVariablesExpandedWhenEventOccurs(t *testing.T) {
+	channels := &MockChannels{}
+	variables := &MockGRVVariableSetter{}
+	config := NewConfiguration(&MockKeyBindings{}, channels, variables, &MockInputConsumer{})
+
+	variables.On("VariableValue", VarBranch).Return("master", true)
+	channels.On("DoAction", Action{ActionType: ActionNewTab, Args: []interface{}{"master"}}).Return()
+
+	if errs := config.Evaluate("hook refs-changed addtab ${branch}"); len(errs) > 0 {
+		t.Fatalf("Evaluate failed with errors: %v", errs)
+	}
+
+	if err := config.HandleEvent(Event{EventType: HeadChangedEvent}); err != nil {
+		t.Fatalf("HandleEvent failed with error: %v", err)
+	}
+
+	channels.AssertNotCalled(t, "DoAction", mock.Anything)
+
+	if err := config.HandleEvent(Event{EventType: RefsChangedEvent}); err != nil {
+		t.Fatalf("HandleEvent failed with error: %v", err)
+	}
+
+	channels.AssertExpectations(t)
+}
+
+func TestCommandsAreOnlyProcessedWhenEnclosingConditionsAreMet(t *testing.T) {
+	channels := &MockChannels{}
+	channels.On("ReportStatus", mock.Anything, mock.Anything).Return()
+
+	variables := NewGRVVariables()
+	variables.SetVariable(VarTerminalCols, "220")
+	variables.SetVariable(VarHead, "refs/heads/master")
+
+	config := NewConfiguration(&MockKeyBindings{}, channels, variables, &MockInputConsumer{})
+
+	errs := config.Evaluate(`
+		let layout = wide
+		if terminalcols < 120
+			let layout = narrow
+			set commit-graph false
+		else
+			set commit-graph true
+			if head = "refs/heads/develop"
+				set tabwidth 2
+			else
+				set tabwidth 4
+			endif
+		endif
+		if layout = "narrow"
+			if head = "refs/heads/master"
+				set mouse true
+			else
+				set mouse true
+			endif
+		endif
+		set git-binary-file-path /opt/${layout}/bin/git
+	`)
+
+	if len(errs) > 0 {
+		t.Fatalf("Evaluate failed with errors: %v", errs)
+	}
+
+	if !config.GetBool(CfCommitGraph) {
+		t.Errorf("Expected commit-graph to be set in the else block")
+	}
+
+	if tabWidth := config.GetInt(CfTabWidth); tabWidth != 4 {
+		t.Errorf("Expected tabwidth to be set by the nested else block. Expected: 4, Actual: %v", tabWidth)
+	}
+
+	if config.GetBool(CfMouse) {
+		t.Errorf("Expected mouse not to be set as the enclosing condition was not met")
+	}
+
+	if gitBinary := config.GetString(CfGitBinaryFilePath); gitBinary != "/opt/wide/bin/git" {
+		t.Errorf("Expected user variable to be substituted. Expected: /opt/wide/bin/git, Actual: %v", gitBinary)
+	}
+}
+
+func TestUnbalancedConditionalCommandsGenerateErrors(t *testing.T) {
+	var errorTests = []struct {
+		input                 string
+		expectedErrorMessages []string
+	}{
+		{
+			input:                 "if tabwidth = 8",
+			expectedErrorMessages: []string{ConfigFile + ":1:1 No endif found for if"},
+		},
+		{
+			input:                 "if tabwidth = 8\nelse\nelse\nendif",
+			expectedErrorMessages: []string{ConfigFile + ":3:1 Unexpected else"},
+		},
+		{
+			input:                 "endif",
+			expectedErrorMessages: []string{ConfigFile + ":1:1 Unexpected endif"},
+		},
+		{
+			input:                 "let commit = abc123",
+			expectedErrorMessages: []string{ConfigFile + ":1:5 Cannot set the value of GRV variable commit"},
+		},
+	}
+
+	for _, errorTest := range errorTests {
+		config := NewConfiguration(&MockKeyBindings{}, &MockChannels{}, NewGRVVariables(), &MockInputConsumer{})
+		errs := config.LoadReader(strings.NewReader(errorTest.input), ConfigFile)
+
+		var errorMessages []string
+		for _, err := range errs {
+			errorMessages = append(errorMessages, err.Error())
+		}
+
+		if !reflect.DeepEqual(errorTest.expectedErrorMessages, errorMessages) {
+			t.Errorf("Error messages do not match expected values for input %q. Expected: %v, Actual: %v", errorTest.input, errorTest.expectedErrorMessages, errorMessages)
+		}
+	}
+}
+
+func TestMacroMapCommandRequestsMacroToBeMapped(t *testing.T) {
+	channels := &MockChannels{}
+	channels.On("ReportStatus", mock.Anything, mock.Anything).Return()
+	channels.On("DoAction", Action{
+		ActionType: ActionMapMacro,
+		Args: []interface{}{ActionMapMacroArgs{
+			view:     "CommitView",
+			from:     "<C-j>",
+			register: "a",
+		}},
+	}).Return()
+
+	config := NewConfiguration(&MockKeyBindings{}, channels, &MockGRVVariableSetter{}, &MockInputConsumer{})
+
+	if errs := config.Evaluate("macromap CommitView <C-j> a"); len(errs) > 0 {
+		t.Fatalf("Evaluate failed with errors: %v", errs)
+	}
+
+	if errs := config.Evaluate("macromap CommitView <C-j> <Enter>"); len(errs) != 1 {
+		t.Errorf("Expected an error for an invalid macro register but received: %v", errs)
+	}
+
+	channels.AssertExpectations(t)
+}
+
+func TestConfigWordsAreQuotedWhenRequired(t *testing.T) {
+	quoteTests := map[string]string{
+		"jj<Enter>":    "jj<Enter>",
+		"":             `""`,
+		"/fix bug":     `"/fix bug"`,
+		"@a":           `"@a"`,
+		"#1":           `"#1"`,
+		"--name":       `"--name"`,
+		`"quoted"`:     `"\"quoted\""`,
+		"-j":           "-j",
+		"!<Enter>":     `"!<Enter>"`,
+		`path\to\file`: `path\to\file`,
+	}
+
+	for value, expectedValue := range quoteTests {
+		if quotedValue := quoteConfigWord(value); quotedValue != expectedValue {
+			t.Errorf("Quoted value does not match expected value for %q. Expected: %v, Actual: %v", value, expectedValue, quotedValue)
+		}
+	}
+}
+
+type MockConfigVariableOnChangeListener struct {
+	mock.Mock
+}
+
+func (listener *MockConfigVariableOnChangeListener) onConfigVariableChange(configVariable ConfigVariable) {
+	listener.Called(configVariable)
+}
+
+func TestConfigIsOnlyReplacedWhenReloadGeneratesNoErrors(t *testing.T) {
+	channels := &MockChannels{}
+	channels.On("ReportStatus", mock.Anything, mock.Anything).Return()
+
+	listener := &MockConfigVariableOnChangeListener{}
+	listener.On("onConfigVariableChange", mock.Anything).Return()
+
+	config := NewConfiguration(NewKeyBindingManager(), channels, NewGRVVariables(), &MockInputConsumer{})
+	config.AddOnChangeListener(CfTabWidth, listener)
+
+	if errs := config.Evaluate("set tabwidth 4\ndef oldcmd { addtab Old }"); len(errs) > 0 {
+		t.Fatalf("Evaluate failed with errors: %v", errs)
+	}
+
+	errs := config.Reload(func(reloadedConfig *Configuration) []error {
+		return reloadedConfig.Evaluate("set tabwidth 2\nundef oldcmd\nset invalid-variable true")
+	})
+
+	if len(errs) != 1 {
+		t.Errorf("Expected reload to generate 1 error but found: %v", errs)
+	}
+
+	if tabWidth := config.GetInt(CfTabWidth); tabWidth != 4 {
+		t.Errorf("Expected tabwidth to be retained after failed reload. Expected: 4, Actual: %v", tabWidth)
+	}
+
+	if _, exists := commandDescriptors["oldcmd"]; !exists {
+		t.Errorf("Expected oldcmd to be retained after failed reload")
+	}
+
+	errs = config.Reload(func(reloadedConfig *Configuration) []error {
+		return reloadedConfig.Evaluate("set mouse true\naddtab New\ndef newcmd { addtab New }")
+	})
+
+	if len(errs) > 0 {
+		t.Fatalf("Reload failed with errors: %v", errs)
+	}
+
+	if tabWidth := config.GetInt(CfTabWidth); tabWidth != cfTabWidthDefaultValue {
+		t.Errorf("Expected tabwidth to be reset by reload. Expected: %v, Actual: %v", cfTabWidthDefaultValue, tabWidth)
+	}
+
+	if !config.GetBool(CfMouse) {
+		t.Errorf("Expected mouse to be set by reload")
+	}
+
+	if _, exists := commandDescriptors["oldcmd"]; exists {
+		t.Errorf("Expected oldcmd to be removed by reload")
+	}
+
+	if _, exists := config.customCommands["newcmd"]; !exists {
+		t.Errorf("Expected newcmd to be defined by reload")
+	}
+
+	channels.AssertNotCalled(t, "DoAction", mock.Anything)
+	listener.AssertNumberOfCalls(t, "onConfigVariableChange", 2)
+
+	UndefineCustomCommand("newcmd")
+}
+
+func TestRepoConfigCannotSourceOtherFiles(t *testing.T) {
+	repoDir, err := ioutil.TempDir("", "grv-repo-config")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(repoDir)
+
+	sourcedFilePath := filepath.Join(repoDir, "sourced.grvrc")
+	if err = ioutil.WriteFile(sourcedFilePath, []byte("set tabwidth 2\n"), 0644); err != nil {
+		t.Fatalf("Unable to write sourced config file: %v", err)
+	}
+
+	channels := &MockChannels{}
+	channels.On("ReportStatus", mock.Anything, mock.Anything).Return()
+	channels.On("ReportErrors", mock.Anything).Return()
+
+	config := NewConfiguration(NewKeyBindingManager(), channels, NewGRVVariables(), &MockInputConsumer{})
+	repoConfig := "source sourced.grvrc\ndef sourcecmd { source " + sourcedFilePath + " }"
+
+	if errs := config.LoadRepoConfigReader(strings.NewReader(repoConfig), RepoConfigFilePath(repoDir)); len(errs) != 1 {
+		t.Errorf("Expected source command in repository config to generate 1 error but found: %v", errs)
+	}
+
+	if errs := config.Evaluate("sourcecmd"); len(errs) != 1 {
+		t.Errorf("Expected command defined by repository config to fail to source file but found errors: %v", errs)
+	}
+
+	if tabWidth := config.GetInt(CfTabWidth); tabWidth != cfTabWidthDefaultValue {
+		t.Errorf("Expected sourced file not to be loaded. Expected tabwidth: %v, Actual: %v", cfTabWidthDefaultValue, tabWidth)
+	}
+
+	if errs := config.Evaluate("source " + sourcedFilePath); len(errs) > 0 {
+		t.Errorf("Expected source command outside repository config to succeed but found errors: %v", errs)
+	}
+
+	if tabWidth := config.GetInt(CfTabWidth); tabWidth != 2 {
+		t.Errorf("Expected sourced file to be loaded. Expected tabwidth: 2, Actual: %v", tabWidth)
+	}
+
+	UndefineCustomCommand("sourcecmd")
+}
+
+func TestMonochromeThemeIsActiveWhenNoColorIsSet(t *testing.T) {
+	noColor, noColorSet := os.LookupEnv(cfNoColorEnvVariable)
+	defer func() {
+		if noColorSet {
+			os.Setenv(cfNoColorEnvVariable, noColor)
+		} else {
+			os.Unsetenv(cfNoColorEnvVariable)
+		}
+	}()
+
+	os.Setenv(cfNoColorEnvVariable, "1")
+	config := NewConfiguration(&MockKeyBindings{}, &MockChannels{}, &MockGRVVariableSetter{}, &MockInputConsumer{})
+
+	if !config.GetBool(CfMonochrome) {
+		t.Errorf("Expected monochrome to be enabled when %v is set", cfNoColorEnvVariable)
+	}
+
+	if config.GetTheme() != config.themes[cfMonochromeThemeName] {
+		t.Errorf("Expected monochrome theme to be active")
+	}
+
+	os.Setenv(cfNoColorEnvVariable, "")
+	config = NewConfiguration(&MockKeyBindings{}, &MockChannels{}, &MockGRVVariableSetter{}, &MockInputConsumer{})
+
+	if config.GetBool(CfMonochrome) {
+		t.Errorf("Expected monochrome to be disabled when %v is empty", cfNoColorEnvVariable)
+	}
+}
+
+func TestNotesRefsAreParsedAndValidated(t *testing.T) {
+	channels := &MockChannels{}
+	channels.On("ReportStatus", mock.Anything, mock.Anything).Return()
+
+	config := NewConfiguration(NewKeyBindingManager(), channels, NewGRVVariables(), &MockInputConsumer{})
+
+	if errs := config.Evaluate(`set notes-refs "refs/notes/commits, refs/notes/review,"`); len(errs) > 0 {
+		t.Fatalf("Evaluate failed with errors: %v", errs)
+	}
+
+	expectedNotesRefs := []string{"refs/notes/commits", "refs/notes/review"}
+	if notesRefs := NotesRefs(config); !reflect.DeepEqual(notesRefs, expectedNotesRefs) {
+		t.Errorf("NotesRefs does not match expected value. Expected: %v, Actual: %v", expectedNotesRefs, notesRefs)
+	}
+
+	if errs := config.Evaluate(`set notes-refs "refs/notes/commits,refs/heads/master"`); len(errs) != 1 {
+		t.Errorf("Expected notes ref outside of refs/notes/ to generate 1 error but found: %v", errs)
+	}
+}
@@ -449,6 +449,93 @@ func (containerView *ContainerView) Title() string {
 	return containerView.title
 }
 
+// SessionState returns the layout of this container and the state of its child views
+func (containerView *ContainerView) SessionState() *SessionViewState {
+	containerView.lock.Lock()
+	defer containerView.lock.Unlock()
+
+	sessionViewState := &SessionViewState{
+		View:            ViewName(containerView.viewID),
+		Title:           containerView.title,
+		Orientation:     containerView.orientation,
+		ActiveViewIndex: containerView.activeViewIndex,
+		FullScreen:      containerView.fullScreen,
+	}
+
+	for childIndex, childView := range containerView.childViews {
+		childViewState := GenerateViewSessionState(childView)
+		if childViewState == nil {
+			log.Debugf("Unable to generate session state for view %T", childView)
+
+			if uint(childIndex) < containerView.activeViewIndex {
+				sessionViewState.ActiveViewIndex--
+			}
+
+			continue
+		}
+
+		sessionViewState.ChildViews = append(sessionViewState.ChildViews, childViewState)
+	}
+
+	if len(sessionViewState.ChildViews) == 0 {
+		return nil
+	}
+
+	return sessionViewState
+}
+
+// RestoreSessionState restores the layout of this container and
+// the state of any child views present in the session state
+func (containerView *ContainerView) RestoreSessionState(sessionViewState *SessionViewState) (err error) {
+	if !sessionViewState.isContainer() {
+		log.Debugf("Ignoring session state for view %v", sessionViewState.View)
+		return
+	}
+
+	containerView.lock.Lock()
+	defer containerView.lock.Unlock()
+
+	containerView.orientation = sessionViewState.Orientation
+	containerView.fullScreen = sessionViewState.FullScreen
+
+	// Child views without session state are omitted when the state is saved,
+	// so each child is matched to the next state generated by a view of the same type
+	childStateIndex := 0
+
+	for childIndex, childView := range containerView.childViews {
+		viewName := ViewName(childView.ViewID())
+
+		matchIndex := childStateIndex
+		for matchIndex < len(sessionViewState.ChildViews) && sessionViewState.ChildViews[matchIndex].View != viewName {
+			matchIndex++
+		}
+
+		if matchIndex >= len(sessionViewState.ChildViews) {
+			log.Debugf("No session state found for child view %v", viewName)
+			continue
+		}
+
+		childStateIndex = matchIndex + 1
+
+		if uint(matchIndex) == sessionViewState.ActiveViewIndex {
+			containerView.activeViewIndex = uint(childIndex)
+		}
+
+		provider, ok := childView.(SessionStateProvider)
+		if !ok {
+			continue
+		}
+
+		if err = provider.RestoreSessionState(sessionViewState.ChildViews[matchIndex]); err != nil {
+			return
+		}
+	}
+
+	containerView.onStateChange(containerView.viewState)
+
+	return
+}
+
 // IsEmpty returns true if this container view has no child views
 func (containerView *ContainerView) IsEmpty() bool {
 	containerView.lock.Lock()
@@ -256,7 +256,7 @@ func selectContextMenuEntry(contextMenuView *ContextMenuView, action Action) (er
 	contextMenuView.channels.DoAction(Action{ActionType: ActionRemoveView})
 
 	if contextMenuView.contextMenuConfig.OnSelect != nil {
-		go contextMenuView.contextMenuConfig.OnSelect(selectedEntry, selectedIndex)
+		pendingBackgroundWork.Go(func() { contextMenuView.contextMenuConfig.OnSelect(selectedEntry, selectedIndex) })
 	}
 
 	return
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	csGetCommand       = "get"
+	csResponseOK       = "OK"
+	csResponseError    = "ERROR"
+	csSocketPermission = 0600
+	csSocketUmask      = 0177
+	csMaxRequestSize   = 64 * 1024
+)
+
+// ControlSocket listens on a unix domain socket for requests from other processes.
+// Each request is a single line and receives a single line response.
+// Requests are either configuration commands, which are evaluated as if they
+// were entered at the command prompt, or queries for the values of GRV variables
+type ControlSocket struct {
+	socketPath  string
+	listener    net.Listener
+	channels    Channels
+	variables   GRVVariableGetter
+	connections map[net.Conn]bool
+	closeCh     chan bool
+	closeOnce   sync.Once
+	waitGroup   sync.WaitGroup
+	lock        sync.Mutex
+}
+
+// NewControlSocket creates a control socket listening at the provided path.
+// A stale socket file left behind by a previous instance is replaced
+func NewControlSocket(socketPath string, channels Channels, variables GRVVariableGetter) (controlSocket *ControlSocket, err error) {
+	listener, err := listenOnUnixSocket(socketPath)
+	if err != nil {
+		err = fmt.Errorf("Unable to listen on control socket %v: %v", socketPath, err)
+		return
+	}
+
+	if err = os.Chmod(socketPath, csSocketPermission); err != nil {
+		listener.Close()
+		err = fmt.Errorf("Unable to set permissions on control socket %v: %v", socketPath, err)
+		return
+	}
+
+	log.Infof("Listening on control socket %v", socketPath)
+
+	controlSocket = &ControlSocket{
+		socketPath:  socketPath,
+		listener:    listener,
+		channels:    channels,
+		variables:   variables,
+		connections: make(map[net.Conn]bool),
+		closeCh:     make(chan bool),
+	}
+
+	return
+}
+
+func listenOnUnixSocket(socketPath string) (listener net.Listener, err error) {
+	if listener, err = listenOnPrivateUnixSocket(socketPath); err == nil || !isAddressInUse(err) {
+		return
+	}
+
+	if conn, dialErr := net.Dial("unix", socketPath); dialErr == nil {
+		conn.Close()
+		return
+	}
+
+	log.Infof("Removing stale control socket %v", socketPath)
+
+	if err = os.Remove(socketPath); err != nil {
+		return
+	}
+
+	return listenOnPrivateUnixSocket(socketPath)
+}
+
+// listenOnPrivateUnixSocket creates the socket file with owner only permissions so that
+// no other user is able to connect before the socket permissions are explicitly set
+func listenOnPrivateUnixSocket(socketPath string) (net.Listener, error) {
+	previousUmask := syscall.Umask(csSocketUmask)
+	defer syscall.Umask(previousUmask)
+
+	return net.Listen("unix", socketPath)
+}
+
+func isAddressInUse(err error) bool {
+	if opErr, ok := err.(*net.OpError); ok {
+		if syscallErr, ok := opErr.Err.(*os.SyscallError); ok {
+			return syscallErr.Err == syscall.EADDRINUSE
+		}
+	}
+
+	return false
+}
+
+// Run accepts connections until the control socket is closed
+func (controlSocket *ControlSocket) Run(waitGroup *sync.WaitGroup) {
+	defer waitGroup.Done()
+	defer log.Info("Control socket loop stopping")
+	log.Info("Starting control socket loop")
+
+	for {
+		conn, err := controlSocket.listener.Accept()
+		if err != nil {
+			if !controlSocket.isClosed() {
+				log.Errorf("Error accepting control socket connection: %v", err)
+			}
+
+			break
+		}
+
+		if !controlSocket.addConnection(conn) {
+			conn.Close()
+			break
+		}
+
+		controlSocket.waitGroup.Add(1)
+		go controlSocket.handleConnection(conn)
+	}
+
+	controlSocket.waitGroup.Wait()
+}
+
+// Close stops listening for connections and closes any open connections
+func (controlSocket *ControlSocket) Close() {
+	controlSocket.closeOnce.Do(func() {
+		log.Infof("Closing control socket %v", controlSocket.socketPath)
+
+		controlSocket.lock.Lock()
+		defer controlSocket.lock.Unlock()
+
+		close(controlSocket.closeCh)
+
+		if err := controlSocket.listener.Close(); err != nil {
+			log.Errorf("Error closing control socket: %v", err)
+		}
+
+		for conn := range controlSocket.connections {
+			conn.Close()
+		}
+	})
+}
+
+func (controlSocket *ControlSocket) isClosed() bool {
+	select {
+	case <-controlSocket.closeCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (controlSocket *ControlSocket) addConnection(conn net.Conn) bool {
+	controlSocket.lock.Lock()
+	defer controlSocket.lock.Unlock()
+
+	if controlSocket.isClosed() {
+		return false
+	}
+
+	controlSocket.connections[conn] = true
+
+	return true
+}
+
+func (controlSocket *ControlSocket) removeConnection(conn net.Conn) {
+	controlSocket.lock.Lock()
+	defer controlSocket.lock.Unlock()
+
+	delete(controlSocket.connections, conn)
+}
+
+func (controlSocket *ControlSocket) handleConnection(conn net.Conn) {
+	defer controlSocket.waitGroup.Done()
+	defer controlSocket.removeConnection(conn)
+	defer conn.Close()
+
+	log.Debug("Accepted control socket connection")
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), csMaxRequestSize)
+
+	for scanner.Scan() {
+		request := strings.TrimSpace(scanner.Text())
+		if request == "" {
+			continue
+		}
+
+		response := controlSocket.processRequest(request)
+
+		if _, err := io.WriteString(conn, response+"\n"); err != nil {
+			log.Errorf("Unable to write control socket response: %v", err)
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil && !controlSocket.isClosed() {
+		log.Errorf("Error reading from control socket connection: %v", err)
+	}
+}
+
+func (controlSocket *ControlSocket) processRequest(request string) string {
+	log.Debugf("Processing control socket request: %v", request)
+
+	if fields := strings.Fields(request); fields[0] == csGetCommand {
+		return controlSocket.processGetRequest(fields[1:])
+	}
+
+	return controlSocket.processCommandRequest(request)
+}
+
+func (controlSocket *ControlSocket) processGetRequest(args []string) string {
+	if len(args) != 1 {
+		return errorResponse(fmt.Errorf("Invalid %[1]v request. Usage: %[1]v VARIABLE", csGetCommand))
+	}
+
+	variable, exists := LookupGRVVariable(args[0])
+	if !exists {
+		return errorResponse(fmt.Errorf("Invalid variable: %v", args[0]))
+	}
+
+	value, _ := controlSocket.variables.VariableValue(variable)
+
+	return csResponseOK + " " + strings.Replace(value, "\n", " ", -1)
+}
+
+func (controlSocket *ControlSocket) processCommandRequest(command string) string {
+	resultCh := make(chan []error, 1)
+
+	controlSocket.channels.DoAction(Action{
+		ActionType: ActionEvaluateCommand,
+		Args: []interface{}{ActionEvaluateCommandArgs{
+			command: command,
+			onComplete: func(errs []error) {
+				resultCh <- errs
+			},
+		}},
+	})
+
+	select {
+	case errs := <-resultCh:
+		if len(errs) > 0 {
+			return errorResponse(errs...)
+		}
+
+		return csResponseOK
+	case <-controlSocket.closeCh:
+		return errorResponse(fmt.Errorf("GRV is exiting"))
+	}
+}
+
+func errorResponse(errs ...error) string {
+	messages := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		messages = append(messages, strings.Replace(err.Error(), "\n", " ", -1))
+	}
+
+	return csResponseError + " " + strings.Join(messages, "; ")
+}
+
+// GenerateControlSocketHelpSections generates help documentation for the control socket
+func GenerateControlSocketHelpSections(config Config) []*HelpSection {
+	description := []HelpSectionText{
+		{text: "GRV can be controlled by other processes when started with the -controlSocket argument."},
+		{text: "A unix domain socket is created at the path provided which accepts newline terminated requests."},
+		{text: "Each request receives a single line response beginning with OK or ERROR."},
+		{text: "Requests are evaluated in the same way as commands entered at the command prompt."},
+		{text: "For example, the following will open a new tab displaying the commits on master:"},
+		{},
+		{text: "printf 'addtab master\\naddview CommitView master\\n' | nc -U /tmp/grv.sock", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "Key sequences can be sent using the evalkeys command:"},
+		{},
+		{text: "evalkeys <grv-next-tab>", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "The value of a GRV variable can be queried using a get request. For example:"},
+		{},
+		{text: "get commit", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "will return the oid of the currently selected commit in the form:"},
+		{},
+		{text: "OK 4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
+		{text: "See the Shell Commands section for the list of variables available."},
+	}
+
+	return []*HelpSection{
+		{
+			title:       HelpSectionText{text: "Control Socket"},
+			description: description,
+		},
+	}
+}
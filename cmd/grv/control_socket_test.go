@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestControlSocketRequestsReceiveResponses(t *testing.T) {
+	socketDir, err := ioutil.TempDir("", "grv-control-socket")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(socketDir)
+
+	variables := NewGRVVariables()
+	variables.SetVariable(VarCommit, "4a5b6c7d")
+
+	channels := &MockChannels{}
+	channels.On("DoAction", mock.Anything).Run(func(args mock.Arguments) {
+		arg := args.Get(0).(Action).Args[0].(ActionEvaluateCommandArgs)
+
+		if arg.command == "addtab Test" {
+			arg.onComplete(nil)
+		} else {
+			arg.onComplete([]error{fmt.Errorf("Invalid command %v", arg.command)})
+		}
+	})
+
+	socketPath := filepath.Join(socketDir, "grv.sock")
+	controlSocket, err := NewControlSocket(socketPath, channels, variables)
+	if err != nil {
+		t.Fatalf("NewControlSocket failed with error: %v", err)
+	}
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(1)
+	go controlSocket.Run(&waitGroup)
+
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Unable to connect to control socket: %v", err)
+	}
+	defer conn.Close()
+
+	var tests = []struct {
+		request          string
+		expectedResponse string
+	}{
+		{
+			request:          "get commit",
+			expectedResponse: "OK 4a5b6c7d",
+		},
+		{
+			request:          "get unknown",
+			expectedResponse: "ERROR Invalid variable: unknown",
+		},
+		{
+			request:          "get",
+			expectedResponse: "ERROR Invalid get request. Usage: get VARIABLE",
+		},
+		{
+			request:          "addtab Test",
+			expectedResponse: "OK",
+		},
+		{
+			request:          "badcommand",
+			expectedResponse: "ERROR Invalid command badcommand",
+		},
+	}
+
+	reader := bufio.NewReader(conn)
+
+	for _, test := range tests {
+		if _, err = fmt.Fprintf(conn, "%v\n", test.request); err != nil {
+			t.Fatalf("Unable to write request: %v", err)
+		}
+
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Unable to read response to request %v: %v", test.request, err)
+		}
+
+		if response != test.expectedResponse+"\n" {
+			t.Errorf("Response does not match expected response for request %v. Expected: %q, Actual: %q", test.request, test.expectedResponse+"\n", response)
+		}
+	}
+
+	controlSocket.Close()
+	waitGroup.Wait()
+
+	if _, err = os.Stat(socketPath); !os.IsNotExist(err) {
+		t.Errorf("Expected socket file %v to be removed", socketPath)
+	}
+}
@@ -22,7 +22,10 @@ const (
 	dltDiffCommitAuthorDate
 	dltDiffCommitCommitter
 	dltDiffCommitCommitterDate
+	dltDiffCommitSignature
 	dltDiffCommitMessage
+	dltDiffCommitNote
+	dltDiffTag
 	dltDiffStatsFile
 	dltDiffStatsSummary
 	dltGitDiffHeaderDiff
@@ -45,6 +48,7 @@ const (
 const (
 	dvDateFormat                 = "Mon Jan 2 15:04:05 2006 -0700"
 	dvDiffLoadRequestChannelSize = 100
+	dvDefaultNotesRef            = "refs/notes/commits"
 )
 
 var diffStatsSummaryRegex = regexp.MustCompile(`^\d+\sfiles?\schanged,`)
@@ -100,6 +104,7 @@ type diffLines struct {
 	lines    []*diffLineData
 	diffType diffProcessorType
 	viewPos  ViewPos
+	request  diffLoadRequest
 }
 
 type diffLoadRequest interface {
@@ -114,6 +119,15 @@ func (commitDiffLoadRequest *commitDiffLoadRequest) diffID() diffID {
 	return diffID(commitDiffLoadRequest.commit.oid.String())
 }
 
+type commitRangeDiffLoadRequest struct {
+	from *Commit
+	to   *Commit
+}
+
+func (commitRangeDiffLoadRequest *commitRangeDiffLoadRequest) diffID() diffID {
+	return diffID(fmt.Sprintf("%v..%v", commitRangeDiffLoadRequest.from.oid, commitRangeDiffLoadRequest.to.oid))
+}
+
 type fileDiffLoadRequest struct {
 	statusType StatusType
 	filePath   string
@@ -180,6 +194,7 @@ type DiffView struct {
 	lastViewDimension ViewDimension
 	handlers          map[ActionType]diffViewHandler
 	diffLoadRequestCh chan diffLoadRequest
+	pendingDiffState  *DiffViewSessionState
 	variables         GRVVariableSetter
 	waitGroup         sync.WaitGroup
 	lock              sync.Mutex
@@ -216,6 +231,7 @@ func (diffView *DiffView) Initialise() (err error) {
 	go diffView.processDiffLoadRequests()
 
 	diffView.config.AddOnChangeListener(CfDiffDisplay, diffView)
+	diffView.config.AddOnChangeListener(CfMonochrome, diffView)
 
 	return
 }
@@ -397,6 +413,30 @@ func (diffView *DiffView) switchToDiffIfExists(diffID diffID) (exists bool) {
 	return
 }
 
+// OnCommitRangeSelected loads/fetches the cumulative diff between the two commits and refreshes the display
+func (diffView *DiffView) OnCommitRangeSelected(from, to *Commit) (err error) {
+	log.Debugf("DiffView loading diff between commits %v and %v", from.oid, to.oid)
+
+	request := &commitRangeDiffLoadRequest{
+		from: from,
+		to:   to,
+	}
+
+	diffView.lock.Lock()
+	diffView.lastRequestedDiff = request.diffID()
+
+	if diffView.switchToDiffIfExists(request.diffID()) {
+		diffView.lock.Unlock()
+		return
+	}
+
+	diffView.lock.Unlock()
+
+	diffView.addDiffLoadRequest(request)
+
+	return
+}
+
 // OnFileSelected loads/fetches the diff for the selected file and refreshes the display
 func (diffView *DiffView) OnFileSelected(statusType StatusType, filePath string) {
 	log.Debugf("DiffView loading diff for file %v", filePath)
@@ -439,8 +479,49 @@ func (diffView *DiffView) OnNoEntrySelected() {
 	diffView.channels.UpdateDisplay()
 }
 
+// SessionState returns the active diff and the position within it
+func (diffView *DiffView) SessionState() *SessionViewState {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	sessionViewState := &SessionViewState{
+		View: ViewName(diffView.ViewID()),
+	}
+
+	if diffView.activeDiff != "" {
+		sessionViewState.DiffView = &DiffViewSessionState{
+			Diff:           string(diffView.activeDiff),
+			ActiveRowIndex: diffView.activeViewPos.ActiveRowIndex(),
+		}
+	}
+
+	return sessionViewState
+}
+
+// RestoreSessionState restores the position within the stored diff.
+// If the diff hasn't been loaded yet the position is applied once it is
+func (diffView *DiffView) RestoreSessionState(sessionViewState *SessionViewState) (err error) {
+	diffViewState := sessionViewState.DiffView
+	if diffViewState == nil {
+		return
+	}
+
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	if diffLines, exists := diffView.diffs[diffID(diffViewState.Diff)]; exists {
+		diffLines.viewPos.SetActiveRowIndex(diffViewState.ActiveRowIndex)
+		diffView.channels.UpdateDisplay()
+	} else {
+		diffView.pendingDiffState = diffViewState
+	}
+
+	return
+}
+
 func (diffView *DiffView) addDiffLoadRequest(request diffLoadRequest) {
 	if diffView.diffLoadRequestCh != nil {
+		pendingBackgroundWork.Start()
 		diffView.diffLoadRequestCh <- request
 	}
 }
@@ -455,6 +536,8 @@ func (diffView *DiffView) processDiffLoadRequests() {
 		switch req := request.(type) {
 		case *commitDiffLoadRequest:
 			err = diffView.loadCommitDiffAndMakeActive(req)
+		case *commitRangeDiffLoadRequest:
+			err = diffView.loadCommitRangeDiffAndMakeActive(req)
 		case *fileDiffLoadRequest:
 			err = diffView.loadFileDiffAndMakeActive(req)
 		case *stageDiffLoadRequest:
@@ -468,6 +551,7 @@ func (diffView *DiffView) processDiffLoadRequests() {
 		}
 
 		diffView.channels.UpdateDisplay()
+		pendingBackgroundWork.Complete()
 	}
 }
 
@@ -477,6 +561,8 @@ func (diffView *DiffView) retrieveLatestDiffLoadRequest(request diffLoadRequest)
 	for requestFound {
 		select {
 		case request = <-diffView.diffLoadRequestCh:
+			// The superseded request is complete as it will not be processed
+			pendingBackgroundWork.Complete()
 		default:
 			requestFound = false
 		}
@@ -494,7 +580,30 @@ func (diffView *DiffView) loadCommitDiffAndMakeActive(request *commitDiffLoadReq
 		return
 	}
 
-	diffView.storeDiff(request.diffID(), lines)
+	diffView.storeDiff(request, lines)
+
+	return
+}
+
+func (diffView *DiffView) loadCommitRangeDiffAndMakeActive(request *commitRangeDiffLoadRequest) (err error) {
+	diff, err := diffView.repoData.DiffCommits(request.from, request.to)
+	if err != nil {
+		log.Errorf("Unable to load diff between commits %v and %v: %v", request.from.oid, request.to.oid, err)
+		return
+	}
+
+	lines, err := diffView.generateDiffLinesForDiff(diff)
+	if err != nil {
+		log.Errorf("Unable to store commit range diff: %v", err)
+		return
+	}
+
+	header := []*diffLineData{
+		newNormalDiffLineData(fmt.Sprintf("Changes from %v to %v", request.from.oid.ShortID(), request.to.oid.ShortID())),
+		newEmptyDiffLineData(),
+	}
+
+	diffView.storeDiff(request, append(header, lines...))
 
 	return
 }
@@ -515,7 +624,7 @@ func (diffView *DiffView) loadFileDiffAndMakeActive(request *fileDiffLoadRequest
 		return
 	}
 
-	diffView.storeDiff(request.diffID(), lines)
+	diffView.storeDiff(request, lines)
 
 	return
 }
@@ -535,15 +644,17 @@ func (diffView *DiffView) loadStageDiffAndMakeActive(request *stageDiffLoadReque
 		return
 	}
 
-	diffView.storeDiff(request.diffID(), lines)
+	diffView.storeDiff(request, lines)
 
 	return
 }
 
-func (diffView *DiffView) storeDiff(diffID diffID, lines []*diffLineData) {
+func (diffView *DiffView) storeDiff(request diffLoadRequest, lines []*diffLineData) {
 	diffView.lock.Lock()
 	defer diffView.lock.Unlock()
 
+	diffID := request.diffID()
+
 	rawLines := lines
 	diffProcessor, diffType := diffView.currentDiffProcessor()
 	lines, err := diffProcessor.processDiff(lines)
@@ -557,10 +668,16 @@ func (diffView *DiffView) storeDiff(diffID diffID, lines []*diffLineData) {
 		lines:    lines,
 		diffType: diffType,
 		viewPos:  NewViewPosition(),
+		request:  request,
 	}
 
 	diffView.diffs[diffID] = diffLines
 
+	if pendingDiffState := diffView.pendingDiffState; pendingDiffState != nil && string(diffID) == pendingDiffState.Diff {
+		diffLines.viewPos.SetActiveRowIndex(pendingDiffState.ActiveRowIndex)
+		diffView.pendingDiffState = nil
+	}
+
 	if diffID != diffView.lastRequestedDiff {
 		return
 	}
@@ -572,7 +689,13 @@ func (diffView *DiffView) storeDiff(diffID diffID, lines []*diffLineData) {
 	return
 }
 
+// currentDiffProcessorType returns the configured diff format.
+// The git format is always used in monochrome mode as it retains the +/- prefix of changed lines
 func (diffView *DiffView) currentDiffProcessorType() diffProcessorType {
+	if diffView.config.GetBool(CfMonochrome) {
+		return dptGit
+	}
+
 	diffDisplay := diffView.config.GetString(CfDiffDisplay)
 	if diffType, exists := diffProcessorNames[diffDisplay]; exists {
 		return diffType
@@ -590,6 +713,8 @@ func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*d
 	author := commit.commit.Author()
 	committer := commit.commit.Committer()
 
+	lines = append(lines, diffView.generateDiffLinesForTags(commit)...)
+
 	lines = append(lines,
 		newDiffLineData(
 			fmt.Sprintf("Author:\t%v <%v>", author.Name, author.Email),
@@ -611,9 +736,18 @@ func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*d
 			dltDiffCommitCommitterDate,
 			CmpDiffviewDifflineDiffCommitCommitterDate,
 		),
-		newEmptyDiffLineData(),
 	)
 
+	if verification := diffView.repoData.VerifySignature(commit.oid); verification.Status() != SignatureNone {
+		lines = append(lines, newDiffLineData(
+			fmt.Sprintf("Signature:\t%v", signatureDescription(verification)),
+			dltDiffCommitSignature,
+			CmpDiffviewDifflineDiffCommitSignature,
+		))
+	}
+
+	lines = append(lines, newEmptyDiffLineData())
+
 	commitMessageScanner := bufio.NewScanner(strings.NewReader(commit.commit.Message()))
 
 	for commitMessageScanner.Scan() {
@@ -626,6 +760,15 @@ func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*d
 
 	lines = append(lines, newEmptyDiffLineData())
 
+	if notes, notesErr := diffView.repoData.Notes(commit.oid); notesErr != nil {
+		diffView.channels.ReportError(fmt.Errorf("Unable to load notes for commit %v: %v", commit.oid.ShortID(), notesErr))
+	} else {
+		for _, note := range notes {
+			lines = append(lines, diffView.generateDiffLinesForNote(note)...)
+			lines = append(lines, newEmptyDiffLineData())
+		}
+	}
+
 	diff, err := diffView.repoData.DiffCommit(commit)
 	if err != nil {
 		return
@@ -641,6 +784,80 @@ func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*d
 	return
 }
 
+// generateDiffLinesForTags describes the annotated tags which point to the commit
+func (diffView *DiffView) generateDiffLinesForTags(commit *Commit) (lines []*diffLineData) {
+	tags, _ := diffView.repoData.Tags()
+
+	for _, tag := range tags {
+		if !tag.IsAnnotated() || !tag.annotation.target.Equal(commit.oid) {
+			continue
+		}
+
+		lines = append(lines, newDiffLineData(fmt.Sprintf("Tag:\t%v", tag.Shorthand()), dltDiffTag, CmpDiffviewDifflineDiffTag))
+
+		if tagger := tag.annotation.tagger; tagger != nil {
+			lines = append(lines,
+				newDiffLineData(fmt.Sprintf("Tagger:\t%v <%v>", tagger.Name, tagger.Email), dltDiffTag, CmpDiffviewDifflineDiffTag),
+				newDiffLineData(fmt.Sprintf("TaggerDate:\t%v", tagger.When.Format(dvDateFormat)), dltDiffTag, CmpDiffviewDifflineDiffTag),
+			)
+		}
+
+		if verification := diffView.repoData.VerifySignature(tag.Oid()); verification.Status() != SignatureNone {
+			lines = append(lines, newDiffLineData(
+				fmt.Sprintf("Signature:\t%v", signatureDescription(verification)),
+				dltDiffTag,
+				CmpDiffviewDifflineDiffCommitSignature,
+			))
+		}
+
+		lines = append(lines, newEmptyDiffLineData())
+
+		tagMessageScanner := bufio.NewScanner(strings.NewReader(tag.annotation.message))
+
+		for tagMessageScanner.Scan() {
+			lines = append(lines, newDiffLineData(
+				tagMessageScanner.Text(),
+				dltDiffTag,
+				CmpDiffviewDifflineDiffCommitMessage),
+			)
+		}
+
+		lines = append(lines, newEmptyDiffLineData())
+	}
+
+	return
+}
+
+func signatureDescription(verification *SignatureVerification) string {
+	if signer := verification.Signer(); signer != "" {
+		return fmt.Sprintf("%v (%v)", verification.Status(), signer)
+	}
+
+	return verification.Status().String()
+}
+
+// generateDiffLinesForNote formats the note in the same way as git log
+func (diffView *DiffView) generateDiffLinesForNote(note *CommitNote) (lines []*diffLineData) {
+	title := "Notes:"
+	if note.ref != dvDefaultNotesRef {
+		title = fmt.Sprintf("Notes (%v):", strings.TrimPrefix(note.ref, "refs/notes/"))
+	}
+
+	lines = append(lines, newDiffLineData(title, dltDiffCommitNote, CmpDiffviewDifflineDiffCommitNote))
+
+	noteScanner := bufio.NewScanner(strings.NewReader(note.message))
+
+	for noteScanner.Scan() {
+		lines = append(lines, newDiffLineData(
+			"    "+noteScanner.Text(),
+			dltDiffCommitNote,
+			CmpDiffviewDifflineDiffCommitNote),
+		)
+	}
+
+	return
+}
+
 func (diffView *DiffView) generateDiffLinesForDiff(diff *Diff) (lines []*diffLineData, err error) {
 	scanner := bufio.NewScanner(&diff.stats)
 
@@ -762,11 +979,53 @@ func (diffView *DiffView) generateDiffLinesForDiff(diff *Diff) (lines []*diffLin
 	return
 }
 
-// HandleEvent does nothing
+// HandleEvent discards cached commit diffs which may contain outdated notes or tags
+// and reloads the displayed diff if it was discarded
 func (diffView *DiffView) HandleEvent(event Event) (err error) {
+	switch event.EventType {
+	case NotesChangedEvent:
+		if len(event.Args) > 0 {
+			if oid, isOid := event.Args[0].(*Oid); isOid {
+				diffView.discardCommitDiffs(func(commit *Commit) bool {
+					return commit.oid.Equal(oid)
+				})
+			}
+		}
+	case RefsChangedEvent:
+		diffView.discardCommitDiffs(func(*Commit) bool {
+			return true
+		})
+	}
+
 	return
 }
 
+func (diffView *DiffView) discardCommitDiffs(shouldDiscard func(*Commit) bool) {
+	diffView.lock.Lock()
+
+	var reloadRequest diffLoadRequest
+
+	for diffID, diffLines := range diffView.diffs {
+		if request, isCommitDiff := diffLines.request.(*commitDiffLoadRequest); isCommitDiff && shouldDiscard(request.commit) {
+			delete(diffView.diffs, diffID)
+
+			if diffID == diffView.lastRequestedDiff {
+				reloadRequest = request
+				diffView.pendingDiffState = &DiffViewSessionState{
+					Diff:           string(diffID),
+					ActiveRowIndex: diffLines.viewPos.ActiveRowIndex(),
+				}
+			}
+		}
+	}
+
+	diffView.lock.Unlock()
+
+	if reloadRequest != nil {
+		diffView.addDiffLoadRequest(reloadRequest)
+	}
+}
+
 func (diffView *DiffView) viewPos() ViewPos {
 	return diffView.activeViewPos
 }
@@ -776,7 +1035,7 @@ func (diffView *DiffView) onConfigVariableChange(configVariable ConfigVariable)
 	defer diffView.lock.Unlock()
 
 	switch configVariable {
-	case CfDiffDisplay:
+	case CfDiffDisplay, CfMonochrome:
 		diffView.switchToDiffIfExists(diffView.activeDiff)
 	}
 }
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+	exportStdout     = "-"
+)
+
+// RecordWriter streams records consisting of a fixed set of fields to an output
+type RecordWriter interface {
+	WriteRecord(values []interface{}) error
+	Close() error
+}
+
+// NewRecordWriter creates a RecordWriter for the provided format which writes to the provided writer
+func NewRecordWriter(writer io.Writer, format string, fields []string) (recordWriter RecordWriter, err error) {
+	switch format {
+	case exportFormatCSV:
+		recordWriter, err = newCSVRecordWriter(writer, fields)
+	case exportFormatJSON:
+		recordWriter, err = newJSONRecordWriter(writer, fields)
+	default:
+		err = fmt.Errorf("Unsupported export format: %v", format)
+	}
+
+	return
+}
+
+// IsValidExportFormat returns true if records can be exported in the provided format
+func IsValidExportFormat(format string) bool {
+	return format == exportFormatCSV || format == exportFormatJSON
+}
+
+// DetermineExportFormat determines the export format from the extension of the provided file path
+func DetermineExportFormat(filePath string) string {
+	if strings.EqualFold(filepath.Ext(filePath), "."+exportFormatJSON) {
+		return exportFormatJSON
+	}
+
+	return exportFormatCSV
+}
+
+type csvRecordWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVRecordWriter(writer io.Writer, fields []string) (recordWriter *csvRecordWriter, err error) {
+	recordWriter = &csvRecordWriter{
+		writer: csv.NewWriter(writer),
+	}
+
+	err = recordWriter.writer.Write(fields)
+
+	return
+}
+
+func (recordWriter *csvRecordWriter) WriteRecord(values []interface{}) (err error) {
+	record := make([]string, len(values))
+
+	for index, value := range values {
+		record[index] = csvValue(value)
+	}
+
+	return recordWriter.writer.Write(record)
+}
+
+func (recordWriter *csvRecordWriter) Close() error {
+	recordWriter.writer.Flush()
+	return recordWriter.writer.Error()
+}
+
+func csvValue(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return typedValue.Format(time.RFC3339)
+	case []string:
+		return strings.Join(typedValue, " ")
+	default:
+		return fmt.Sprintf("%v", typedValue)
+	}
+}
+
+// jsonRecordWriter writes records as an array of objects.
+// Records are written as they are received so the full set never needs to be held in memory
+type jsonRecordWriter struct {
+	writer      io.Writer
+	fieldNames  [][]byte
+	recordCount uint
+}
+
+func newJSONRecordWriter(writer io.Writer, fields []string) (recordWriter *jsonRecordWriter, err error) {
+	recordWriter = &jsonRecordWriter{
+		writer: writer,
+	}
+
+	for _, field := range fields {
+		var fieldName []byte
+		if fieldName, err = json.Marshal(field); err != nil {
+			return
+		}
+
+		recordWriter.fieldNames = append(recordWriter.fieldNames, fieldName)
+	}
+
+	_, err = io.WriteString(writer, "[")
+
+	return
+}
+
+func (recordWriter *jsonRecordWriter) WriteRecord(values []interface{}) (err error) {
+	if len(values) != len(recordWriter.fieldNames) {
+		return fmt.Errorf("Expected %v values but received %v", len(recordWriter.fieldNames), len(values))
+	}
+
+	var buffer bytes.Buffer
+
+	if recordWriter.recordCount > 0 {
+		buffer.WriteString(",")
+	}
+
+	buffer.WriteString("\n\t{")
+
+	for index, value := range values {
+		var jsonValue []byte
+		if jsonValue, err = json.Marshal(value); err != nil {
+			return
+		}
+
+		if index > 0 {
+			buffer.WriteString(", ")
+		}
+
+		buffer.Write(recordWriter.fieldNames[index])
+		buffer.WriteString(": ")
+		buffer.Write(jsonValue)
+	}
+
+	buffer.WriteString("}")
+
+	if _, err = recordWriter.writer.Write(buffer.Bytes()); err != nil {
+		return
+	}
+
+	recordWriter.recordCount++
+
+	return
+}
+
+func (recordWriter *jsonRecordWriter) Close() (err error) {
+	if recordWriter.recordCount > 0 {
+		_, err = io.WriteString(recordWriter.writer, "\n]\n")
+	} else {
+		_, err = io.WriteString(recordWriter.writer, "]\n")
+	}
+
+	return
+}
+
+// RecordGenerator writes records to the provided RecordWriter
+type RecordGenerator func(recordWriter RecordWriter) (recordNum uint, err error)
+
+// ExportToFile creates the file at the provided path and writes the records produced by the generator to it.
+// Records are written to stdout if the file path is -
+func ExportToFile(filePath, format string, fields []string, recordGenerator RecordGenerator) (recordNum uint, err error) {
+	log.Infof("Exporting records in %v format to %v", format, filePath)
+
+	if filePath == exportStdout {
+		return exportToWriter(os.Stdout, format, fields, recordGenerator)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	if recordNum, err = exportToWriter(file, format, fields, recordGenerator); err != nil {
+		return
+	}
+
+	err = file.Close()
+
+	return
+}
+
+func exportToWriter(writer io.Writer, format string, fields []string, recordGenerator RecordGenerator) (recordNum uint, err error) {
+	bufferedWriter := bufio.NewWriter(writer)
+
+	recordWriter, err := NewRecordWriter(bufferedWriter, format, fields)
+	if err != nil {
+		return
+	}
+
+	if recordNum, err = recordGenerator(recordWriter); err != nil {
+		return
+	}
+
+	if err = recordWriter.Close(); err != nil {
+		return
+	}
+
+	err = bufferedWriter.Flush()
+
+	return
+}
+
+// exportActionArgs extracts the file path and format from an export action
+func exportActionArgs(action Action) (filePath, format string, err error) {
+	if len(action.Args) != 2 {
+		err = fmt.Errorf("Expected file path and format arguments")
+		return
+	}
+
+	var ok bool
+	if filePath, ok = action.Args[0].(string); !ok {
+		err = fmt.Errorf("Expected file path argument to have type string")
+		return
+	}
+
+	if format, ok = action.Args[1].(string); !ok {
+		err = fmt.Errorf("Expected format argument to have type string")
+	}
+
+	return
+}
+
+// runExport performs the export on a separate go routine and reports the outcome
+func runExport(channels Channels, description, filePath, format string, fields []string, recordGenerator RecordGenerator) {
+	channels.ReportStatus("Exporting %v to %v", description, filePath)
+
+	pendingBackgroundWork.Start()
+
+	go func() {
+		defer pendingBackgroundWork.Complete()
+
+		recordNum, err := ExportToFile(filePath, format, fields, recordGenerator)
+		if err != nil {
+			channels.ReportError(fmt.Errorf("Export to %v failed: %v", filePath, err))
+			return
+		}
+
+		channels.ReportStatus("Exported %v %v to %v", recordNum, description, filePath)
+	}()
+}
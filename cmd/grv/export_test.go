@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestRecordsAreWrittenInCSVFormat(t *testing.T) {
+	var buffer bytes.Buffer
+
+	recordWriter, err := NewRecordWriter(&buffer, exportFormatCSV, []string{"name", "date", "refs", "count"})
+	if err != nil {
+		t.Fatalf("Unable to create record writer: %v", err)
+	}
+
+	records := [][]interface{}{
+		{"John, Smith", time.Date(2018, 07, 12, 20, 25, 45, 0, time.UTC), []string{"master", "v1.0"}, float64(2)},
+		{"Jane", time.Date(2018, 07, 13, 8, 0, 0, 0, time.UTC), []string{}, nil},
+	}
+
+	for _, record := range records {
+		if err = recordWriter.WriteRecord(record); err != nil {
+			t.Fatalf("Unable to write record: %v", err)
+		}
+	}
+
+	if err = recordWriter.Close(); err != nil {
+		t.Fatalf("Unable to close record writer: %v", err)
+	}
+
+	expectedOutput := "name,date,refs,count\n" +
+		"\"John, Smith\",2018-07-12T20:25:45Z,master v1.0,2\n" +
+		"Jane,2018-07-13T08:00:00Z,,\n"
+
+	if actualOutput := buffer.String(); actualOutput != expectedOutput {
+		t.Errorf("CSV output does not match expected value. Expected: %q, Actual: %q", expectedOutput, actualOutput)
+	}
+}
+
+func TestRecordsAreWrittenInJSONFormat(t *testing.T) {
+	var buffer bytes.Buffer
+
+	recordWriter, err := NewRecordWriter(&buffer, exportFormatJSON, []string{"name", "refs", "ahead"})
+	if err != nil {
+		t.Fatalf("Unable to create record writer: %v", err)
+	}
+
+	records := [][]interface{}{
+		{"master", []string{"origin/master"}, uint(1)},
+		{"v1.0", []string{}, nil},
+	}
+
+	for _, record := range records {
+		if err = recordWriter.WriteRecord(record); err != nil {
+			t.Fatalf("Unable to write record: %v", err)
+		}
+	}
+
+	if err = recordWriter.Close(); err != nil {
+		t.Fatalf("Unable to close record writer: %v", err)
+	}
+
+	expectedOutput := "[\n" +
+		"\t{\"name\": \"master\", \"refs\": [\"origin/master\"], \"ahead\": 1},\n" +
+		"\t{\"name\": \"v1.0\", \"refs\": [], \"ahead\": null}\n" +
+		"]\n"
+
+	if actualOutput := buffer.String(); actualOutput != expectedOutput {
+		t.Errorf("JSON output does not match expected value. Expected: %q, Actual: %q", expectedOutput, actualOutput)
+	}
+}
+
+func TestEmptyJSONExportIsAnEmptyArray(t *testing.T) {
+	var buffer bytes.Buffer
+
+	recordWriter, err := NewRecordWriter(&buffer, exportFormatJSON, []string{"name"})
+	if err != nil {
+		t.Fatalf("Unable to create record writer: %v", err)
+	}
+
+	if err = recordWriter.Close(); err != nil {
+		t.Fatalf("Unable to close record writer: %v", err)
+	}
+
+	if actualOutput := buffer.String(); actualOutput != "[]\n" {
+		t.Errorf("JSON output does not match expected value. Expected: %q, Actual: %q", "[]\n", actualOutput)
+	}
+}
+
+func TestExportFormatIsDeterminedFromFileExtension(t *testing.T) {
+	var tests = []struct {
+		filePath       string
+		expectedFormat string
+	}{
+		{filePath: "commits.json", expectedFormat: exportFormatJSON},
+		{filePath: "/tmp/refs.JSON", expectedFormat: exportFormatJSON},
+		{filePath: "commits.csv", expectedFormat: exportFormatCSV},
+		{filePath: "commits", expectedFormat: exportFormatCSV},
+	}
+
+	for _, test := range tests {
+		if actualFormat := DetermineExportFormat(test.filePath); actualFormat != test.expectedFormat {
+			t.Errorf("Export format for %v does not match expected value. Expected: %v, Actual: %v", test.filePath, test.expectedFormat, actualFormat)
+		}
+	}
+}
+
+func TestExportedCommitFieldsExcludeExpensiveFields(t *testing.T) {
+	fieldNames := exportedCommitFieldNames()
+
+	for _, fieldName := range fieldNames {
+		if commitFields[fieldName].expensive {
+			t.Errorf("Expected expensive field %v not to be exported", fieldName)
+		}
+	}
+
+	if expectedFieldNum := len(commitFields) - 2; len(fieldNames) != expectedFieldNum {
+		t.Errorf("Exported field count does not match expected value. Expected: %v, Actual: %v", expectedFieldNum, len(fieldNames))
+	}
+}
@@ -351,6 +351,11 @@ func GenerateFilterQueryLanguageHelpSections(config Config) (helpSections []*Hel
 		{},
 		{text: "As shown above, expressions can be grouped using parentheses."},
 		{},
+		{text: "The lintviolations field contains the number of commit message rules, configured using the commit-lint-*"},
+		{text: "configuration variables, that a commit message violates. For example, to list commits which violate a rule:"},
+		{},
+		{text: "lintviolations > 0", themeComponentID: CmpHelpViewSectionCodeBlock},
+		{},
 		{text: "The list of (case-insensitive) fields that can be used in the Commit View is:"},
 	}
 
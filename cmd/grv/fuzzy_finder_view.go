@@ -0,0 +1,450 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+	slice "github.com/bradfitz/slice"
+)
+
+const (
+	ffViewRows     = 20
+	ffViewCols     = 100
+	ffMaxCommitNum = 10000
+	ffTypeWidth    = 8
+)
+
+type fuzzyFinderCandidateType int
+
+// Candidates are displayed in this order when no query has been entered
+const (
+	fctRef fuzzyFinderCandidateType = iota
+	fctCommit
+	fctFile
+	fctCommand
+	fctAction
+	fctCount
+)
+
+var fuzzyFinderCandidateTypeNames = map[fuzzyFinderCandidateType]string{
+	fctRef:     "ref",
+	fctCommit:  "commit",
+	fctFile:    "file",
+	fctCommand: "command",
+	fctAction:  "action",
+}
+
+type fuzzyFinderCandidate struct {
+	candidateType fuzzyFinderCandidateType
+	text          string
+	value         interface{}
+}
+
+type fuzzyFinderMatch struct {
+	candidate  *fuzzyFinderCandidate
+	fuzzyMatch FuzzyMatch
+}
+
+type fuzzyFinderViewHandler func(*FuzzyFinderView, Action) error
+
+// FuzzyFinderView incrementally matches a query against refs, commits, files,
+// commands and actions and performs the natural action for the selected candidate
+type FuzzyFinderView struct {
+	*AbstractWindowView
+	repoData          RepoData
+	candidates        map[fuzzyFinderCandidateType][]*fuzzyFinderCandidate
+	matches           []*fuzzyFinderMatch
+	query             string
+	activeViewPos     ViewPos
+	lastViewDimension ViewDimension
+	handlers          map[ActionType]fuzzyFinderViewHandler
+	lock              sync.Mutex
+}
+
+// NewFuzzyFinderView creates a new instance
+func NewFuzzyFinderView(repoData RepoData, channels Channels, config Config, variables GRVVariableSetter) *FuzzyFinderView {
+	fuzzyFinderView := &FuzzyFinderView{
+		repoData:      repoData,
+		candidates:    make(map[fuzzyFinderCandidateType][]*fuzzyFinderCandidate),
+		activeViewPos: NewViewPosition(),
+		handlers: map[ActionType]fuzzyFinderViewHandler{
+			ActionSelect: selectFuzzyFinderMatch,
+		},
+	}
+
+	fuzzyFinderView.AbstractWindowView = NewAbstractWindowView(fuzzyFinderView, channels, config, variables, &fuzzyFinderView.lock, "candidate")
+
+	fuzzyFinderView.candidates[fctRef] = fuzzyFinderView.refCandidates()
+	fuzzyFinderView.candidates[fctCommand] = commandCandidates()
+	fuzzyFinderView.candidates[fctAction] = actionCandidates()
+	fuzzyFinderView.filterCandidates()
+
+	pendingBackgroundWork.Start()
+	go fuzzyFinderView.loadCommitCandidates()
+	pendingBackgroundWork.Start()
+	go fuzzyFinderView.loadFileCandidates()
+
+	return fuzzyFinderView
+}
+
+func (fuzzyFinderView *FuzzyFinderView) refCandidates() (candidates []*fuzzyFinderCandidate) {
+	localBranches, remoteBranches, _ := fuzzyFinderView.repoData.Branches()
+	tags, _ := fuzzyFinderView.repoData.Tags()
+
+	var refs []Ref
+	for _, branch := range localBranches {
+		refs = append(refs, branch)
+	}
+	for _, branch := range remoteBranches {
+		refs = append(refs, branch)
+	}
+	for _, tag := range tags {
+		refs = append(refs, tag)
+	}
+
+	for _, ref := range refs {
+		candidates = append(candidates, &fuzzyFinderCandidate{
+			candidateType: fctRef,
+			text:          ref.Shorthand(),
+			value:         ref,
+		})
+	}
+
+	return
+}
+
+func (fuzzyFinderView *FuzzyFinderView) loadCommitCandidates() {
+	defer pendingBackgroundWork.Complete()
+
+	head := fuzzyFinderView.repoData.Head()
+	commitNum := MinUInt(fuzzyFinderView.repoData.CommitSetState(head).commitNum, ffMaxCommitNum)
+
+	commitCh, err := fuzzyFinderView.repoData.Commits(head, 0, commitNum)
+	if err != nil {
+		fuzzyFinderView.channels.ReportError(fmt.Errorf("Unable to load commits for fuzzy finder: %v", err))
+		return
+	}
+
+	var candidates []*fuzzyFinderCandidate
+	for commit := range commitCh {
+		candidates = append(candidates, &fuzzyFinderCandidate{
+			candidateType: fctCommit,
+			text:          fmt.Sprintf("%v %v", commit.oid.ShortID(), commit.commit.Summary()),
+			value:         commit,
+		})
+	}
+
+	fuzzyFinderView.lock.Lock()
+	defer fuzzyFinderView.lock.Unlock()
+
+	fuzzyFinderView.candidates[fctCommit] = candidates
+	fuzzyFinderView.filterCandidates()
+
+	log.Debugf("Loaded %v commit(s) for fuzzy finder", len(candidates))
+
+	fuzzyFinderView.channels.UpdateDisplay()
+}
+
+func commandCandidates() (candidates []*fuzzyFinderCandidate) {
+	for _, commandName := range commandNames() {
+		candidates = append(candidates, &fuzzyFinderCandidate{
+			candidateType: fctCommand,
+			text:          commandName,
+			value:         commandName,
+		})
+	}
+
+	slice.Sort(candidates, func(i, j int) bool {
+		return candidates[i].text < candidates[j].text
+	})
+
+	return
+}
+
+func actionCandidates() (candidates []*fuzzyFinderCandidate) {
+	for actionType, actionDescriptor := range actionDescriptors {
+		if actionDescriptor.actionKey == "" || actionDescriptor.macroAction || actionType == ActionFuzzyFinderPrompt {
+			continue
+		}
+
+		candidates = append(candidates, &fuzzyFinderCandidate{
+			candidateType: fctAction,
+			text:          fmt.Sprintf("%v %v", actionDescriptor.description, actionDescriptor.actionKey),
+			value:         actionType,
+		})
+	}
+
+	slice.Sort(candidates, func(i, j int) bool {
+		return candidates[i].text < candidates[j].text
+	})
+
+	return
+}
+
+func (fuzzyFinderView *FuzzyFinderView) loadFileCandidates() {
+	defer pendingBackgroundWork.Complete()
+
+	head := fuzzyFinderView.repoData.Head()
+
+	commit, err := fuzzyFinderView.repoData.Commit(head.Oid())
+	if err != nil {
+		fuzzyFinderView.channels.ReportError(fmt.Errorf("Unable to load files for fuzzy finder: %v", err))
+		return
+	}
+
+	paths, err := fuzzyFinderView.repoData.CommitFiles(commit)
+	if err != nil {
+		fuzzyFinderView.channels.ReportError(fmt.Errorf("Unable to load files for fuzzy finder: %v", err))
+		return
+	}
+
+	var candidates []*fuzzyFinderCandidate
+	for _, path := range paths {
+		candidates = append(candidates, &fuzzyFinderCandidate{
+			candidateType: fctFile,
+			text:          path,
+			value:         path,
+		})
+	}
+
+	fuzzyFinderView.lock.Lock()
+	defer fuzzyFinderView.lock.Unlock()
+
+	fuzzyFinderView.candidates[fctFile] = candidates
+	fuzzyFinderView.filterCandidates()
+
+	log.Debugf("Loaded %v file(s) for fuzzy finder", len(candidates))
+
+	fuzzyFinderView.channels.UpdateDisplay()
+}
+
+// filterCandidates matches the query against all candidates and orders the matches by score.
+// Matches with equal scores are ordered by candidate type and then by length
+func (fuzzyFinderView *FuzzyFinderView) filterCandidates() {
+	var matches []*fuzzyFinderMatch
+
+	for candidateType := fctRef; candidateType < fctCount; candidateType++ {
+		for _, candidate := range fuzzyFinderView.candidates[candidateType] {
+			if fuzzyMatch, matched := FuzzyMatchString(fuzzyFinderView.query, candidate.text); matched {
+				matches = append(matches, &fuzzyFinderMatch{
+					candidate:  candidate,
+					fuzzyMatch: fuzzyMatch,
+				})
+			}
+		}
+	}
+
+	if fuzzyFinderView.query != "" {
+		sort.Stable(slice.SortInterface(matches, func(i, j int) bool {
+			if matches[i].fuzzyMatch.Score != matches[j].fuzzyMatch.Score {
+				return matches[i].fuzzyMatch.Score > matches[j].fuzzyMatch.Score
+			}
+
+			return len(matches[i].candidate.text) < len(matches[j].candidate.text)
+		}))
+	}
+
+	fuzzyFinderView.matches = matches
+	fuzzyFinderView.viewPos().SetActiveRowIndex(0)
+}
+
+// OnPromptInputChanged updates the query candidates are matched against
+func (fuzzyFinderView *FuzzyFinderView) OnPromptInputChanged(input string) {
+	fuzzyFinderView.lock.Lock()
+	defer fuzzyFinderView.lock.Unlock()
+
+	if input == fuzzyFinderView.query {
+		return
+	}
+
+	fuzzyFinderView.query = input
+	fuzzyFinderView.filterCandidates()
+	fuzzyFinderView.channels.UpdateDisplay()
+}
+
+// OnPromptEntryMoved moves the selection by the provided number of matches
+func (fuzzyFinderView *FuzzyFinderView) OnPromptEntryMoved(offset int) {
+	fuzzyFinderView.lock.Lock()
+	defer fuzzyFinderView.lock.Unlock()
+
+	action := Action{ActionType: ActionNextLine}
+	if offset < 0 {
+		action.ActionType = ActionPrevLine
+		offset = -offset
+	}
+
+	for ; offset > 0; offset-- {
+		if _, err := fuzzyFinderView.AbstractWindowView.HandleAction(action); err != nil {
+			log.Errorf("Unable to move fuzzy finder selection: %v", err)
+			return
+		}
+	}
+}
+
+// Render generates and writes the fuzzy finder view to the provided window
+func (fuzzyFinderView *FuzzyFinderView) Render(win RenderWindow) (err error) {
+	fuzzyFinderView.lock.Lock()
+	defer fuzzyFinderView.lock.Unlock()
+
+	fuzzyFinderView.lastViewDimension = win.ViewDimensions()
+
+	winRows := win.Rows() - 2
+	viewRows := fuzzyFinderView.rows()
+
+	viewPos := fuzzyFinderView.viewPos()
+	viewPos.DetermineViewStartRow(winRows, viewRows)
+	viewRowIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	win.ApplyStyle(CmpFuzzyFinderViewCandidate)
+
+	var lineBuilder *LineBuilder
+	for rowIndex := uint(0); rowIndex < winRows && viewRowIndex < viewRows; rowIndex++ {
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		fuzzyFinderView.renderMatch(lineBuilder, fuzzyFinderView.matches[viewRowIndex])
+		viewRowIndex++
+	}
+
+	if viewRows > 0 {
+		if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, ViewStateActive); err != nil {
+			return
+		}
+	}
+
+	win.DrawBorderWithStyle(CmpFuzzyFinderViewCandidate)
+
+	if err = win.SetTitle(CmpFuzzyFinderViewTitle, "Find"); err != nil {
+		return
+	}
+
+	if viewRows == 0 {
+		err = win.SetFooter(CmpFuzzyFinderViewFooter, "No matches")
+	} else {
+		err = win.SetFooter(CmpFuzzyFinderViewFooter, "Match %v of %v", viewPos.ActiveRowIndex()+1, viewRows)
+	}
+
+	return
+}
+
+func (fuzzyFinderView *FuzzyFinderView) renderMatch(lineBuilder *LineBuilder, match *fuzzyFinderMatch) {
+	typeName := fuzzyFinderCandidateTypeNames[match.candidate.candidateType]
+	lineBuilder.AppendWithStyle(CmpFuzzyFinderViewType, " %-*v", ffTypeWidth, typeName)
+
+	for runeIndex, char := range []rune(match.candidate.text) {
+		if match.fuzzyMatch.IsMatchedPosition(runeIndex) {
+			lineBuilder.AppendWithStyle(CmpFuzzyFinderViewMatch, "%c", char)
+		} else {
+			lineBuilder.AppendWithStyle(CmpFuzzyFinderViewCandidate, "%c", char)
+		}
+	}
+}
+
+// RenderHelpBar renders a help message for the fuzzy finder
+func (fuzzyFinderView *FuzzyFinderView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	lineBuilder.AppendWithStyle(CmpHelpbarviewSpecial, "Type to find, <C-n>/<C-p> to move, <Enter> to select")
+	return
+}
+
+// ViewID returns the ViewID for the fuzzy finder view
+func (fuzzyFinderView *FuzzyFinderView) ViewID() ViewID {
+	return ViewFuzzyFinder
+}
+
+func (fuzzyFinderView *FuzzyFinderView) line(lineIndex uint) (line string) {
+	if lineIndex < fuzzyFinderView.rows() {
+		line = fuzzyFinderView.matches[lineIndex].candidate.text
+	}
+
+	return
+}
+
+func (fuzzyFinderView *FuzzyFinderView) viewPos() ViewPos {
+	return fuzzyFinderView.activeViewPos
+}
+
+func (fuzzyFinderView *FuzzyFinderView) rows() uint {
+	return uint(len(fuzzyFinderView.matches))
+}
+
+func (fuzzyFinderView *FuzzyFinderView) viewDimension() ViewDimension {
+	return fuzzyFinderView.lastViewDimension
+}
+
+func (fuzzyFinderView *FuzzyFinderView) onRowSelected(rowIndex uint) (err error) {
+	fuzzyFinderView.channels.UpdateDisplay()
+	return
+}
+
+// HandleAction checks if the fuzzy finder view supports this action and if it does executes it
+func (fuzzyFinderView *FuzzyFinderView) HandleAction(action Action) (err error) {
+	fuzzyFinderView.lock.Lock()
+	defer fuzzyFinderView.lock.Unlock()
+
+	var handled bool
+	if handler, ok := fuzzyFinderView.handlers[action.ActionType]; ok {
+		log.Debugf("Action handled by FuzzyFinderView")
+		err = handler(fuzzyFinderView, action)
+	} else if handled, err = fuzzyFinderView.AbstractWindowView.HandleAction(action); handled {
+		log.Debugf("Action handled by AbstractWindowView")
+	} else {
+		log.Debugf("Action not handled")
+	}
+
+	return
+}
+
+func selectFuzzyFinderMatch(fuzzyFinderView *FuzzyFinderView, action Action) (err error) {
+	if fuzzyFinderView.rows() == 0 {
+		fuzzyFinderView.channels.DoAction(Action{ActionType: ActionRemoveView})
+		return
+	}
+
+	candidate := fuzzyFinderView.matches[fuzzyFinderView.viewPos().ActiveRowIndex()].candidate
+	log.Debugf("Fuzzy finder candidate selected: %v %v", fuzzyFinderCandidateTypeNames[candidate.candidateType], candidate.text)
+
+	fuzzyFinderView.channels.DoAction(Action{ActionType: ActionRemoveView})
+
+	switch value := candidate.value.(type) {
+	case Ref:
+		refName := quoteConfigWord(value.Shorthand())
+		fuzzyFinderView.evaluateCommand(fmt.Sprintf("%v %v\n%v %v %v", addtabCommand, refName, addviewCommand, cfCommitView, refName))
+	case *Commit:
+		fuzzyFinderView.evaluateCommand(fmt.Sprintf("%v %v\n%v %v %v", addtabCommand, value.oid.ShortID(), addviewCommand, cfDiffView, value.oid))
+	case ActionType:
+		fuzzyFinderView.channels.DoAction(Action{ActionType: value})
+	case string:
+		if candidate.candidateType == fctFile {
+			fuzzyFinderView.evaluateCommand(fmt.Sprintf("%v log --follow -p -- %v", gitInteractiveCommand, quoteConfigWord(value)))
+		} else {
+			fuzzyFinderView.channels.DoAction(Action{
+				ActionType: ActionPrompt,
+				Args: []interface{}{
+					ActionPromptArgs{keys: value + " "},
+				},
+			})
+		}
+	default:
+		err = fmt.Errorf("Unsupported fuzzy finder candidate value: %T", candidate.value)
+	}
+
+	return
+}
+
+func (fuzzyFinderView *FuzzyFinderView) evaluateCommand(command string) {
+	fuzzyFinderView.channels.DoAction(Action{
+		ActionType: ActionEvaluateCommand,
+		Args: []interface{}{
+			ActionEvaluateCommandArgs{
+				command: command,
+				onComplete: func(errs []error) {
+					fuzzyFinderView.channels.ReportErrors(errs)
+				},
+			},
+		},
+	})
+}
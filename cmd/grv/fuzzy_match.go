@@ -0,0 +1,126 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	fmMatchScore         = 16
+	fmStartBonus         = 24
+	fmWordStartBonus     = 16
+	fmConsecutiveBonus   = 12
+	fmExactCaseBonus     = 1
+	fmMaxGapPenalty      = 8
+	fmWordSeparatorRunes = "/-_.: "
+)
+
+// FuzzyMatch describes where a query matched a piece of text and how good the match is
+type FuzzyMatch struct {
+	Score     int
+	Positions []int
+}
+
+// IsMatchedPosition returns true if the rune at the provided index was matched by the query
+func (fuzzyMatch FuzzyMatch) IsMatchedPosition(runeIndex int) bool {
+	for _, position := range fuzzyMatch.Positions {
+		if position == runeIndex {
+			return true
+		} else if position > runeIndex {
+			break
+		}
+	}
+
+	return false
+}
+
+// FuzzyMatchString determines whether all runes of the query appear in order in the provided text.
+// Matching is case insensitive unless the query contains an upper case rune.
+// Matches at the start of words and runs of consecutive runes are scored more highly
+func FuzzyMatchString(query, text string) (fuzzyMatch FuzzyMatch, matched bool) {
+	queryRunes := []rune(query)
+	if len(queryRunes) == 0 {
+		return fuzzyMatch, true
+	}
+
+	textRunes := []rune(text)
+	caseSensitive := strings.IndexFunc(query, unicode.IsUpper) != -1
+
+	normalise := func(char rune) rune {
+		if caseSensitive {
+			return char
+		}
+
+		return unicode.ToLower(char)
+	}
+
+	for startIndex := range textRunes {
+		if normalise(textRunes[startIndex]) != normalise(queryRunes[0]) {
+			continue
+		}
+
+		positions := []int{startIndex}
+		textIndex := startIndex + 1
+
+		for _, queryRune := range queryRunes[1:] {
+			for textIndex < len(textRunes) && normalise(textRunes[textIndex]) != normalise(queryRune) {
+				textIndex++
+			}
+
+			if textIndex == len(textRunes) {
+				// Matching from a later start index cannot succeed either
+				return
+			}
+
+			positions = append(positions, textIndex)
+			textIndex++
+		}
+
+		score := fuzzyMatchScore(queryRunes, textRunes, positions)
+
+		if !matched || score > fuzzyMatch.Score {
+			fuzzyMatch = FuzzyMatch{
+				Score:     score,
+				Positions: positions,
+			}
+			matched = true
+		}
+	}
+
+	return
+}
+
+func fuzzyMatchScore(queryRunes, textRunes []rune, positions []int) (score int) {
+	for queryIndex, position := range positions {
+		score += fmMatchScore
+
+		if textRunes[position] == queryRunes[queryIndex] {
+			score += fmExactCaseBonus
+		}
+
+		if position == 0 {
+			score += fmStartBonus
+		} else if isWordStart(textRunes, position) {
+			score += fmWordStartBonus
+		}
+
+		if queryIndex > 0 {
+			if gap := position - positions[queryIndex-1] - 1; gap == 0 {
+				score += fmConsecutiveBonus
+			} else {
+				score -= MinInt(gap, fmMaxGapPenalty)
+			}
+		}
+	}
+
+	score -= MinInt(positions[0], fmMaxGapPenalty)
+
+	return
+}
+
+func isWordStart(textRunes []rune, position int) bool {
+	previousRune := textRunes[position-1]
+
+	return strings.ContainsRune(fmWordSeparatorRunes, previousRune) ||
+		(unicode.IsLower(previousRune) && unicode.IsUpper(textRunes[position]))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryRunesMustAppearInOrderToMatch(t *testing.T) {
+	matchTests := []struct {
+		query             string
+		text              string
+		expectedMatched   bool
+		expectedPositions []int
+	}{
+		{
+			query:             "",
+			text:              "master",
+			expectedMatched:   true,
+			expectedPositions: nil,
+		},
+		{
+			query:             "mst",
+			text:              "master",
+			expectedMatched:   true,
+			expectedPositions: []int{0, 2, 3},
+		},
+		{
+			query:           "tsm",
+			text:            "master",
+			expectedMatched: false,
+		},
+		{
+			query:             "MAS",
+			text:              "origin/MASTER",
+			expectedMatched:   true,
+			expectedPositions: []int{7, 8, 9},
+		},
+		{
+			query:           "Mas",
+			text:            "master",
+			expectedMatched: false,
+		},
+		{
+			query:             "view",
+			text:              "cmd/grv/ref_view.go",
+			expectedMatched:   true,
+			expectedPositions: []int{12, 13, 14, 15},
+		},
+	}
+
+	for _, matchTest := range matchTests {
+		fuzzyMatch, matched := FuzzyMatchString(matchTest.query, matchTest.text)
+
+		if matched != matchTest.expectedMatched {
+			t.Errorf("Match result does not match expected value for query %q and text %q. Expected: %v, Actual: %v",
+				matchTest.query, matchTest.text, matchTest.expectedMatched, matched)
+		} else if matched && !reflect.DeepEqual(matchTest.expectedPositions, fuzzyMatch.Positions) {
+			t.Errorf("Matched positions do not match expected value for query %q and text %q. Expected: %v, Actual: %v",
+				matchTest.query, matchTest.text, matchTest.expectedPositions, fuzzyMatch.Positions)
+		}
+	}
+}
+
+func TestWordStartAndConsecutiveMatchesAreScoredMoreHighly(t *testing.T) {
+	scoreTests := []struct {
+		query      string
+		betterText string
+		worseText  string
+	}{
+		{
+			query:      "rv",
+			betterText: "ref_view.go",
+			worseText:  "server.go",
+		},
+		{
+			query:      "diff",
+			betterText: "diff_view.go",
+			worseText:  "dir/info/files.go",
+		},
+		{
+			query:      "fb",
+			betterText: "feature/bugfix",
+			worseText:  "fabric",
+		},
+		{
+			query:      "cv",
+			betterText: "CommitView",
+			worseText:  "curve",
+		},
+	}
+
+	for _, scoreTest := range scoreTests {
+		betterMatch, betterMatched := FuzzyMatchString(scoreTest.query, scoreTest.betterText)
+		worseMatch, worseMatched := FuzzyMatchString(scoreTest.query, scoreTest.worseText)
+
+		if !betterMatched || !worseMatched {
+			t.Errorf("Expected query %q to match both %q and %q", scoreTest.query, scoreTest.betterText, scoreTest.worseText)
+		} else if betterMatch.Score <= worseMatch.Score {
+			t.Errorf("Expected %q to score higher than %q for query %q. Scores: %v, %v",
+				scoreTest.betterText, scoreTest.worseText, scoreTest.query, betterMatch.Score, worseMatch.Score)
+		}
+	}
+}
@@ -33,10 +33,15 @@ func (controller *GitCommandRepoController) Initialise(RepoSupplier) {
 
 // CheckoutRef does a git checkout on the provided ref
 func (controller *GitCommandRepoController) CheckoutRef(ref Ref, resultHandler RefOperationResultHandler) {
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		err := controller.runGitCommand("checkout", ref.Shorthand())
 		if err == nil {
 			controller.repoData.LoadRefs(nil)
+			controller.reportEvent(PostCheckoutEvent)
 		}
 
 		resultHandler(ref, err)
@@ -45,10 +50,15 @@ func (controller *GitCommandRepoController) CheckoutRef(ref Ref, resultHandler R
 
 // CheckoutCommit does a git checkout on the provided commit
 func (controller *GitCommandRepoController) CheckoutCommit(commit *Commit, resultHandler RepoResultHandler) {
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		err := controller.runGitCommand("checkout", commit.oid.String())
 		if err == nil {
 			controller.repoData.LoadRefs(nil)
+			controller.reportEvent(PostCheckoutEvent)
 		}
 
 		resultHandler(err)
@@ -68,6 +78,7 @@ func (controller *GitCommandRepoController) CreateBranch(branchName string, oid
 func (controller *GitCommandRepoController) CreateBranchAndCheckout(branchName string, oid *Oid, resultHandler RefOperationResultHandler) {
 	err := controller.runGitCommand("checkout", "-b", branchName, oid.String())
 	if err == nil {
+		controller.reportEvent(PostCheckoutEvent)
 		controller.findRef(resultHandler, branchName, func(ref Ref) bool {
 			branch, isBranch := ref.(*LocalBranch)
 			return isBranch && branchName == branch.Shorthand()
@@ -107,9 +118,15 @@ func (controller *GitCommandRepoController) CreateAnnotatedTag(tagName string, o
 
 // CheckoutPreviousRef uses git checkout - to checkout the previous ref
 func (controller *GitCommandRepoController) CheckoutPreviousRef(resultHandler RefOperationResultHandler) {
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		var err error
 		if err = controller.runGitCommand("checkout", "-"); err == nil {
+			controller.reportEvent(PostCheckoutEvent)
+
 			if err = controller.repoData.LoadHead(); err == nil {
 				head := controller.repoData.Head()
 
@@ -179,6 +196,50 @@ func (controller *GitCommandRepoController) AmendCommit(resultHandler CommitResu
 	controller.runInteractiveGitCommand(controller.onGitCommit(resultHandler), "commit", "--amend")
 }
 
+// CommitWithMessage uses git commit to create a new commit with the provided message without launching an editor
+func (controller *GitCommandRepoController) CommitWithMessage(message string, resultHandler CommitResultHandler) {
+	controller.commitWithMessage(message, resultHandler, "commit")
+}
+
+// AmendCommitWithMessage uses git commit --amend to amend the last commit with the provided message without launching an editor
+func (controller *GitCommandRepoController) AmendCommitWithMessage(message string, resultHandler CommitResultHandler) {
+	controller.commitWithMessage(message, resultHandler, "commit", "--amend")
+}
+
+// commitWithMessage writes the message to the commit message file and passes it to git commit
+func (controller *GitCommandRepoController) commitWithMessage(message string, resultHandler CommitResultHandler, args ...string) {
+	pendingBackgroundWork.Start()
+
+	go func() {
+		defer pendingBackgroundWork.Complete()
+
+		commitMessageFile, err := controller.CommitMessageFile()
+		if err != nil {
+			resultHandler(nil, err)
+			return
+		}
+
+		_, err = commitMessageFile.WriteString(message + "\n")
+		if closeErr := commitMessageFile.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			resultHandler(nil, fmt.Errorf("Failed to write commit message file: %v", err))
+			return
+		}
+
+		args = append(args, "--cleanup=whitespace", "--file", commitMessageFile.Name())
+
+		if err = controller.runGitCommand(args...); err != nil {
+			resultHandler(nil, err)
+			return
+		}
+
+		controller.onGitCommit(resultHandler)(nil, 0)
+	}()
+}
+
 func (controller *GitCommandRepoController) onGitCommit(resultHandler CommitResultHandler) func(error, int) error {
 	return func(commandErr error, exitStatus int) (err error) {
 		if commandErr != nil || exitStatus != 0 {
@@ -193,6 +254,8 @@ func (controller *GitCommandRepoController) onGitCommit(resultHandler CommitResu
 			oid = controller.repoData.Head().Oid()
 		}
 
+		controller.reportEvent(PostCommitEvent)
+
 		resultHandler(oid, resultError)
 		return
 	}
@@ -200,14 +263,22 @@ func (controller *GitCommandRepoController) onGitCommit(resultHandler CommitResu
 
 // Pull performs a git pull for the provided remote
 func (controller *GitCommandRepoController) Pull(remote string, resultHandler RepoResultHandler) {
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		resultHandler(controller.runGitCommand("pull", remote))
 	}()
 }
 
 // Push performs a git push on the provided remote and ref
 func (controller *GitCommandRepoController) Push(remote string, ref Ref, track bool, resultHandler RepoResultHandler) {
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		args := []string{"push"}
 
 		if track {
@@ -216,7 +287,28 @@ func (controller *GitCommandRepoController) Push(remote string, ref Ref, track b
 
 		args = append(args, remote, ref.Shorthand())
 
-		resultHandler(controller.runGitCommand(args...))
+		err := controller.runGitCommand(args...)
+		if err == nil {
+			controller.reportEvent(PostPushEvent)
+		}
+
+		resultHandler(err)
+	}()
+}
+
+// PushTags performs a git push --tags on the provided remote
+func (controller *GitCommandRepoController) PushTags(remote string, resultHandler RepoResultHandler) {
+	pendingBackgroundWork.Start()
+
+	go func() {
+		defer pendingBackgroundWork.Complete()
+
+		err := controller.runGitCommand("push", "--tags", remote)
+		if err == nil {
+			controller.reportEvent(PostPushEvent)
+		}
+
+		resultHandler(err)
 	}()
 }
 
@@ -234,7 +326,11 @@ func (controller *GitCommandRepoController) DeleteLocalRef(ref Ref) (err error)
 
 // DeleteRemoteRef uses git push --delete to delete a remote branch or tag
 func (controller *GitCommandRepoController) DeleteRemoteRef(remote string, ref Ref, resultHandler RepoResultHandler) {
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		var refName string
 
 		switch rawRef := ref.(type) {
@@ -243,7 +339,8 @@ func (controller *GitCommandRepoController) DeleteRemoteRef(remote string, ref R
 		case *LocalBranch:
 			refName = ref.Shorthand()
 		case *Tag:
-			refName = ref.Shorthand()
+			// The full ref name ensures a branch with the same name as the tag is not deleted
+			refName = rawRef.Name()
 		default:
 			resultHandler(fmt.Errorf("Invalid ref type %T", ref))
 			return
@@ -263,6 +360,53 @@ func (controller *GitCommandRepoController) Rebase(ref Ref) (err error) {
 	return controller.runGitCommand("rebase", ref.Shorthand())
 }
 
+// AddNote uses git notes add to attach a note to the provided oid under the first configured notes ref
+func (controller *GitCommandRepoController) AddNote(oid *Oid, resultHandler RepoResultHandler) {
+	controller.runInteractiveGitCommand(controller.onGitNotes(oid, resultHandler), controller.notesArgs("add", oid)...)
+}
+
+// EditNote uses git notes edit to edit the note attached to the provided oid under the first configured notes ref
+func (controller *GitCommandRepoController) EditNote(oid *Oid, resultHandler RepoResultHandler) {
+	controller.runInteractiveGitCommand(controller.onGitNotes(oid, resultHandler), controller.notesArgs("edit", oid)...)
+}
+
+// RemoveNote uses git notes remove to remove the note attached to the provided oid under the first configured notes ref
+func (controller *GitCommandRepoController) RemoveNote(oid *Oid) (err error) {
+	if err = controller.runGitCommand(controller.notesArgs("remove", oid)...); err == nil {
+		controller.reportNotesChanged(oid)
+	}
+
+	return
+}
+
+func (controller *GitCommandRepoController) notesArgs(subCommand string, oid *Oid) (args []string) {
+	args = []string{"notes"}
+
+	if notesRefs := NotesRefs(controller.config); len(notesRefs) > 0 {
+		args = append(args, "--ref", notesRefs[0])
+	}
+
+	return append(args, subCommand, oid.String())
+}
+
+func (controller *GitCommandRepoController) onGitNotes(oid *Oid, resultHandler RepoResultHandler) func(error, int) error {
+	return func(commandErr error, exitStatus int) (err error) {
+		if commandErr != nil || exitStatus != 0 {
+			resultHandler(fmt.Errorf("Command Status: %v, Error: %v", exitStatus, commandErr))
+			return
+		}
+
+		controller.reportNotesChanged(oid)
+
+		resultHandler(nil)
+		return
+	}
+}
+
+func (controller *GitCommandRepoController) reportNotesChanged(oid *Oid) {
+	controller.channels.ReportEvent(Event{EventType: NotesChangedEvent, Args: []interface{}{oid}})
+}
+
 func (controller *GitCommandRepoController) findRef(resultHandler RefOperationResultHandler, refName string, refPredicate func(Ref) bool) {
 	controller.repoData.LoadRefs(func(refs []Ref) error {
 		for _, ref := range refs {
@@ -277,6 +421,10 @@ func (controller *GitCommandRepoController) findRef(resultHandler RefOperationRe
 	})
 }
 
+func (controller *GitCommandRepoController) reportEvent(eventType EventType) {
+	controller.channels.ReportEvent(Event{EventType: eventType})
+}
+
 func (controller *GitCommandRepoController) gitBinary() string {
 	if gitBinary := controller.config.GetString(CfGitBinaryFilePath); gitBinary != "" {
 		return gitBinary
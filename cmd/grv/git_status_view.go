@@ -18,6 +18,8 @@ type gitStatusViewHandler func(*GitStatusView, Action) error
 
 const (
 	gsvLastModifyThresholdMillis = 500
+	gsvMaxCommitMessageHistory   = 20
+	gsvRecentCommitMessageNum    = 10
 )
 
 var commitMessageFileCommentLineRegex = regexp.MustCompile(`^\s*#`)
@@ -108,6 +110,7 @@ type GitStatusView struct {
 	lastViewDimension      ViewDimension
 	lastModify             time.Time
 	variables              GRVVariableSetter
+	commitMessageHistory   []string
 	lock                   sync.Mutex
 }
 
@@ -142,7 +145,12 @@ func NewGitStatusView(repoData RepoData, repoController RepoController, channels
 func (gitStatusView *GitStatusView) Initialise() (err error) {
 	log.Debug("Initialising GitStatusView")
 
-	go gitStatusView.repoData.LoadStatus()
+	pendingBackgroundWork.Start()
+
+	go func() {
+		defer pendingBackgroundWork.Complete()
+		gitStatusView.repoData.LoadStatus()
+	}()
 
 	return
 }
@@ -284,7 +292,11 @@ func (gitStatusView *GitStatusView) RegisterGitStatusFileSelectedListener(gitSta
 func (gitStatusView *GitStatusView) notifyFileEntrySelected(renderedStatus *renderedStatusEntry) {
 	log.Debugf("Notifying git status file selected listeners that file is selected")
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		for _, gitStatusViewListener := range gitStatusView.gitStatusViewListeners {
 			gitStatusViewListener.OnFileSelected(renderedStatus.statusType, renderedStatus.StatusEntry.NewFilePath())
 		}
@@ -296,7 +308,11 @@ func (gitStatusView *GitStatusView) notifyFileEntrySelected(renderedStatus *rend
 func (gitStatusView *GitStatusView) notifyStageGroupSelected(statusType StatusType) {
 	log.Debugf("Notifying git status file selected listeners that a stage group is selected")
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		for _, gitStatusViewListener := range gitStatusView.gitStatusViewListeners {
 			gitStatusViewListener.OnStageGroupSelected(statusType)
 		}
@@ -308,7 +324,11 @@ func (gitStatusView *GitStatusView) notifyStageGroupSelected(statusType StatusTy
 func (gitStatusView *GitStatusView) notifyNoEntrySelected() {
 	log.Debugf("Notifying git status file selected listeners that no entry is selected")
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		for _, gitStatusViewListener := range gitStatusView.gitStatusViewListeners {
 			gitStatusViewListener.OnNoEntrySelected()
 		}
@@ -653,7 +673,7 @@ func (gitStatusView *GitStatusView) generateBranchStatus() (lines []string) {
 	return
 }
 
-func (gitStatusView *GitStatusView) generateCommitMessageFile() (filePath string, err error) {
+func (gitStatusView *GitStatusView) generateCommitMessageFile(message string) (filePath string, err error) {
 	commitMessageFile, err := gitStatusView.repoController.CommitMessageFile()
 	if err != nil {
 		return
@@ -678,7 +698,7 @@ func (gitStatusView *GitStatusView) generateCommitMessageFile() (filePath string
 
 	writer := bufio.NewWriter(commitMessageFile)
 
-	if _, err = writer.WriteString("\n"); err != nil {
+	if _, err = writer.WriteString(strings.TrimRight(message, "\n") + "\n"); err != nil {
 		err = fmt.Errorf("Failed to write commit message file: %v", err)
 		return
 	}
@@ -932,13 +952,21 @@ func commit(gitStatusView *GitStatusView, action Action) (err error) {
 		return fmt.Errorf("Committing is not possible due to unmerged files - Resolve conflicts before commiting")
 	}
 
-	gitStatusView.repoController.Commit(func(oid *Oid, err error) {
+	resultHandler := func(oid *Oid, err error) {
 		if err == nil {
 			gitStatusView.channels.ReportStatus("Created commit %v", oid.ShortID())
 		} else {
 			gitStatusView.channels.ReportError(fmt.Errorf("Commit failed: %v", err))
 		}
-	})
+	}
+
+	if gitStatusView.isCommitMessageEnteredBeforeCommit() {
+		err = gitStatusView.enterCommitMessage("Commit", "", func(message string) {
+			gitStatusView.repoController.CommitWithMessage(message, resultHandler)
+		})
+	} else {
+		gitStatusView.repoController.Commit(resultHandler)
+	}
 
 	return
 }
@@ -948,13 +976,193 @@ func amendCommit(gitStatusView *GitStatusView, action Action) (err error) {
 		return fmt.Errorf("Committing is not possible due to unmerged files - Resolve conflicts before commiting")
 	}
 
-	gitStatusView.repoController.AmendCommit(func(oid *Oid, err error) {
+	resultHandler := func(oid *Oid, err error) {
 		if err == nil {
 			gitStatusView.channels.ReportStatus("Amended commit. New oid: %v", oid.ShortID())
 		} else {
 			gitStatusView.channels.ReportError(fmt.Errorf("Amending commit failed: %v", err))
 		}
+	}
+
+	if gitStatusView.isCommitMessageEnteredBeforeCommit() {
+		var headCommit *Commit
+		if headCommit, err = gitStatusView.repoData.Commit(gitStatusView.repoData.Head().Oid()); err != nil {
+			return fmt.Errorf("Unable to load commit to amend: %v", err)
+		}
+
+		err = gitStatusView.enterCommitMessage("Amend Commit", headCommit.commit.Message(), func(message string) {
+			gitStatusView.repoController.AmendCommitWithMessage(message, resultHandler)
+		})
+	} else {
+		gitStatusView.repoController.AmendCommit(resultHandler)
+	}
+
+	return
+}
+
+// isCommitMessageEnteredBeforeCommit returns true if grv obtains the commit message before running git commit.
+// This is required when using the built-in editor or when commit messages are checked against commit message rules
+func (gitStatusView *GitStatusView) isCommitMessageEnteredBeforeCommit() bool {
+	return gitStatusView.config.GetBool(CfBuiltinCommitEditor) || NewCommitMessageLinter(gitStatusView.config).HasRules()
+}
+
+// enterCommitMessage prompts for a commit message using either the built-in or external editor.
+// The entered message is passed to onSubmit once it has been checked against the commit message rules
+func (gitStatusView *GitStatusView) enterCommitMessage(title, message string, onSubmit OnCommitMessageClosed) (err error) {
+	onMessageEntered := func(message string) {
+		gitStatusView.lintCommitMessage(title, message, onSubmit)
+	}
+
+	if gitStatusView.config.GetBool(CfBuiltinCommitEditor) {
+		gitStatusView.showCommitMessageView(title, message, onMessageEntered)
+	} else {
+		err = gitStatusView.editCommitMessageFile(message, onMessageEntered)
+	}
+
+	return
+}
+
+// lintCommitMessage passes the message to onSubmit if it satisfies the commit message rules. Otherwise
+// the violations are displayed and the user can choose to edit the message again or commit anyway
+func (gitStatusView *GitStatusView) lintCommitMessage(title, message string, onSubmit OnCommitMessageClosed) {
+	violations := NewCommitMessageLinter(gitStatusView.config).Lint(message)
+	if len(violations) == 0 {
+		onSubmit(message)
+		return
+	}
+
+	gitStatusView.channels.DoAction(Action{
+		ActionType: ActionCreateMessageBoxView,
+		Args: []interface{}{ActionCreateMessageBoxViewArgs{
+			config: MessageBoxConfig{
+				Title:   "Commit message rules violated",
+				Message: strings.Join(violations, ". "),
+				Buttons: []MessageBoxButton{ButtonEdit, ButtonCommitAnyway},
+				OnSelect: func(button MessageBoxButton) {
+					switch button {
+					case ButtonEdit:
+						gitStatusView.lock.Lock()
+						defer gitStatusView.lock.Unlock()
+
+						gitStatusView.channels.ReportError(gitStatusView.enterCommitMessage(title, message, onSubmit))
+					case ButtonCommitAnyway:
+						onSubmit(message)
+					}
+				},
+			},
+		}},
 	})
+}
+
+// editCommitMessageFile opens the commit message file in the users editor and passes the resulting message to onComplete
+func (gitStatusView *GitStatusView) editCommitMessageFile(message string, onComplete OnCommitMessageClosed) (err error) {
+	filePath, err := gitStatusView.generateCommitMessageFile(message)
+	if err != nil {
+		return
+	}
+
+	editor, err := gitStatusView.userEditor()
+	if err != nil {
+		return
+	}
+
+	log.Debugf("Editing commit message file %v using editor %v", filePath, editor)
+
+	gitStatusView.channels.DoAction(Action{ActionType: ActionRunCommand, Args: []interface{}{
+		ActionRunCommandArgs{
+			command:     "/bin/sh",
+			args:        []string{"-c", editor + ` "$@"`, editor, filePath},
+			noShell:     true,
+			interactive: true,
+			stdin:       os.Stdin,
+			stdout:      os.Stdout,
+			stderr:      os.Stderr,
+			onComplete: func(commandErr error, exitStatus int) (err error) {
+				if commandErr != nil || exitStatus != 0 {
+					return fmt.Errorf("Editor exited with status %v: %v", exitStatus, commandErr)
+				}
+
+				commitMessage, err := gitStatusView.processCommitMessageFile(filePath)
+				if err != nil {
+					return
+				}
+
+				onComplete(commitMessage)
+				return
+			},
+		},
+	}})
+
+	return
+}
+
+// showCommitMessageView displays the built-in commit message editor. Messages which are
+// submitted or discarded are recorded so that they can be recalled in later commits
+func (gitStatusView *GitStatusView) showCommitMessageView(title, message string, onSubmit OnCommitMessageClosed) {
+	signature, err := gitStatusView.repoData.UserSignature()
+	if err != nil {
+		log.Infof("Unable to determine user signature: %v", err)
+	}
+
+	gitStatusView.channels.DoAction(Action{
+		ActionType: ActionCreateCommitMessageView,
+		Args: []interface{}{
+			ActionCreateCommitMessageViewArgs{
+				config: CommitMessageConfig{
+					Title:          title,
+					Message:        message,
+					RecentMessages: gitStatusView.recentCommitMessages(),
+					Signature:      signature,
+					OnSubmit: func(message string) {
+						gitStatusView.addCommitMessageToHistory(message)
+						onSubmit(message)
+					},
+					OnCancel: gitStatusView.addCommitMessageToHistory,
+				},
+			},
+		},
+	})
+}
+
+func (gitStatusView *GitStatusView) addCommitMessageToHistory(message string) {
+	gitStatusView.lock.Lock()
+	defer gitStatusView.lock.Unlock()
+
+	history := []string{message}
+	for _, historyMessage := range gitStatusView.commitMessageHistory {
+		if historyMessage != message && len(history) < gsvMaxCommitMessageHistory {
+			history = append(history, historyMessage)
+		}
+	}
+
+	gitStatusView.commitMessageHistory = history
+}
+
+// recentCommitMessages returns the messages entered in the commit message view
+// followed by the messages of the most recent commits on HEAD
+func (gitStatusView *GitStatusView) recentCommitMessages() (messages []string) {
+	messages = append(messages, gitStatusView.commitMessageHistory...)
+
+	head := gitStatusView.repoData.Head()
+	commitNum := MinUInt(gitStatusView.repoData.CommitSetState(head).commitNum, gsvRecentCommitMessageNum)
+
+	commitCh, err := gitStatusView.repoData.Commits(head, 0, commitNum)
+	if err != nil {
+		log.Errorf("Unable to load recent commit messages: %v", err)
+		return
+	}
+
+	recorded := map[string]bool{}
+	for _, message := range messages {
+		recorded[message] = true
+	}
+
+	for commit := range commitCh {
+		if message := strings.TrimRight(commit.commit.Message(), "\n"); !recorded[message] {
+			messages = append(messages, message)
+			recorded[message] = true
+		}
+	}
 
 	return
 }
@@ -3,10 +3,13 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
@@ -25,6 +28,7 @@ const (
 	grvMaxDrawFrequency      = time.Millisecond * 50
 	grvMinErrorDisplay       = time.Second * 2
 	grvMaxGitStatusFrequency = time.Millisecond * 500
+	grvConfigReloadDelay     = time.Millisecond * 500
 )
 
 type gRVChannels struct {
@@ -79,6 +83,14 @@ type EventType int
 const (
 	NoEvent EventType = iota
 	ViewRemovedEvent
+	HeadChangedEvent
+	RefsChangedEvent
+	StatusChangedEvent
+	PostCommitEvent
+	PostPushEvent
+	PostCheckoutEvent
+	ConfigFileChangedEvent
+	NotesChangedEvent
 )
 
 // Event contains data that describes the reported event
@@ -94,17 +106,25 @@ type EventListener interface {
 
 // GRV is the top level structure containing all state in the program
 type GRV struct {
-	repoInitialiser *RepositoryInitialiser
-	repoData        *RepositoryData
-	repoController  RepoController
-	view            *View
-	ui              UI
-	channels        gRVChannels
-	config          *Configuration
-	inputBuffer     *InputBuffer
-	input           *InputKeyMapper
-	eventListeners  []EventListener
-	variables       *GRVVariables
+	repoInitialiser   *RepositoryInitialiser
+	repoData          *RepositoryData
+	repoController    RepoController
+	view              *View
+	ui                UI
+	channels          gRVChannels
+	config            *Configuration
+	inputBuffer       *InputBuffer
+	input             *InputKeyMapper
+	macroRecorder     *MacroRecorder
+	pendingMacro      *Action
+	macroReplays      int
+	eventListeners    []EventListener
+	variables         *GRVVariables
+	sessionStore      *SessionStore
+	batch             bool
+	controlSocket     *ControlSocket
+	repoConfigContent []byte
+	configFilesCh     chan []string
 }
 
 // UpdateDisplay sends a request to update the display
@@ -146,6 +166,7 @@ func (channels *channels) ReportErrors(errors []error) {
 // DoAction sends an action to be executed
 func (channels *channels) DoAction(action Action) {
 	if action.ActionType != ActionNone {
+		pendingBackgroundWork.Start()
 		channels.actionCh <- action
 	}
 }
@@ -153,6 +174,7 @@ func (channels *channels) DoAction(action Action) {
 // ReportEvent sends the event to all listeners
 func (channels *channels) ReportEvent(event Event) {
 	if event.EventType != NoEvent {
+		pendingBackgroundWork.Start()
 		channels.eventCh <- event
 	}
 }
@@ -171,15 +193,26 @@ func (channels *channels) ReportStatus(format string, args ...interface{}) {
 
 // ProcessInput sends the provided input to be processed
 func (channels *channels) ProcessInput(input string) {
+	pendingBackgroundWork.Start()
+
 	select {
 	case channels.inputKeyCh <- input:
 	default:
+		pendingBackgroundWork.Complete()
 		log.Errorf("Unable to add input \"%v\" to input channel", input)
 	}
 }
 
+type uiCreator func(channels Channels, config Config) UI
+
 // NewGRV creates a new instace of GRV
 func NewGRV(readOnly bool) *GRV {
+	return newGRV(readOnly, func(channels Channels, config Config) UI {
+		return NewNCursesDisplay(channels, config)
+	})
+}
+
+func newGRV(readOnly bool, createUI uiCreator) *GRV {
 	grvChannels := gRVChannels{
 		exitCh:     make(chan bool),
 		inputKeyCh: make(chan string, grvInputBufferSize),
@@ -205,8 +238,9 @@ func NewGRV(readOnly bool) *GRV {
 		repoController = NewGitCommandRepoController(repoData, channels, config)
 	}
 
-	ui := NewNCursesDisplay(channels, config)
-	view := NewView(repoData, repoController, channels, config, variables)
+	ui := createUI(channels, config)
+	macroRecorder := NewMacroRecorder()
+	view := NewView(repoData, repoController, channels, config, variables, macroRecorder)
 
 	return &GRV{
 		repoInitialiser: NewRepositoryInitialiser(),
@@ -218,21 +252,20 @@ func NewGRV(readOnly bool) *GRV {
 		config:          config,
 		inputBuffer:     NewInputBuffer(keyBindings),
 		input:           NewInputKeyMapper(ui),
+		macroRecorder:   macroRecorder,
 		eventListeners:  []EventListener{view, repoData, config},
 		variables:       variables,
+		configFilesCh:   make(chan []string, 1),
 	}
 }
 
 // Initialise sets up all the components of GRV
-func (grv *GRV) Initialise(repoPath, workTreePath string) (err error) {
+// The previous session is restored if restoreSession is true
+func (grv *GRV) Initialise(repoPath, workTreePath string, restoreSession bool) (err error) {
 	log.Info("Initialising GRV")
 
 	channels := grv.channels.Channels()
 
-	if configErrors := grv.config.Initialise(); configErrors != nil {
-		channels.ReportErrors(configErrors)
-	}
-
 	if err = grv.repoInitialiser.CreateRepositoryInstance(repoPath, workTreePath); err != nil {
 		return
 	}
@@ -243,24 +276,70 @@ func (grv *GRV) Initialise(repoPath, workTreePath string) (err error) {
 
 	grv.repoController.Initialise(grv.repoInitialiser)
 
+	if grv.batch {
+		grv.setTerminalDimensionVariables(grv.ui.ViewDimension())
+	} else if viewDimension, dimensionErr := TerminalDimension(os.Stdout.Fd()); dimensionErr == nil {
+		grv.setTerminalDimensionVariables(viewDimension)
+	} else {
+		log.Errorf("Unable to determine terminal dimensions: %v", dimensionErr)
+	}
+
+	if configErrors := grv.config.Initialise(); configErrors != nil {
+		channels.ReportErrors(configErrors)
+	}
+
+	hookEventReporter := NewHookEventReporter(channels)
+	grv.repoData.RegisterRefStateListener(hookEventReporter)
+	grv.repoData.RegisterStatusListener(hookEventReporter)
+
+	grv.loadRepoConfig()
+
 	if err = grv.ui.Initialise(); err != nil {
 		return
 	}
 
+	if configDir := grv.config.ConfigDir(); configDir != "" && !grv.batch {
+		grv.sessionStore = NewSessionStore(configDir, grv.repoData.Path())
+
+		if restoreSession {
+			grv.loadSessionState()
+		}
+
+		if err := grv.macroRecorder.Load(configDir); err != nil {
+			log.Errorf("Unable to load macros: %v", err)
+			channels.ReportError(err)
+		}
+	}
+
 	if err = grv.view.Initialise(); err != nil {
 		return
 	}
 
-	InitReadLine(channels, grv.config)
+	if !grv.batch {
+		InitReadLine(channels, grv.config, NewConfigCommandCompleter(grv.config, grv.repoData))
+	}
 
 	return
 }
 
+// ListenOnControlSocket allows other processes to control GRV through a unix domain socket at the provided path
+func (grv *GRV) ListenOnControlSocket(socketPath string) (err error) {
+	grv.controlSocket, err = NewControlSocket(socketPath, grv.channels.Channels(), grv.variables)
+	return
+}
+
 // Free closes and frees any resources used by GRV
 func (grv *GRV) Free() {
 	log.Info("Freeing GRV")
 
-	FreeReadLine()
+	if grv.controlSocket != nil {
+		grv.controlSocket.Close()
+	}
+
+	if !grv.batch {
+		FreeReadLine()
+	}
+
 	grv.ui.Free()
 	grv.repoData.Free()
 	grv.repoInitialiser.Free()
@@ -298,9 +377,180 @@ func (grv *GRV) End() {
 		log.Errorf("Error calling CancelGetInput: %v", err)
 	}
 
+	if grv.controlSocket != nil {
+		grv.controlSocket.Close()
+	}
+
+	grv.saveSessionState()
 	grv.view.Dispose()
 }
 
+func (grv *GRV) loadSessionState() {
+	sessionState, err := grv.sessionStore.Load()
+	if err != nil {
+		log.Errorf("Unable to load session state: %v", err)
+		grv.channels.Channels().ReportError(err)
+		return
+	}
+
+	if sessionState != nil {
+		grv.view.SetSessionState(sessionState)
+	}
+}
+
+func (grv *GRV) setTerminalDimensionVariables(viewDimension ViewDimension) {
+	grv.variables.SetVariable(VarTerminalRows, fmt.Sprintf("%v", viewDimension.rows))
+	grv.variables.SetVariable(VarTerminalCols, fmt.Sprintf("%v", viewDimension.cols))
+}
+
+// loadRepoConfig processes the config file in the work tree of the repository.
+// As the file is provided by the repository the user is asked to confirm it should
+// be processed unless it has previously been trusted and has not changed since
+func (grv *GRV) loadRepoConfig() {
+	workdir := grv.repoData.Workdir()
+	if workdir == "" {
+		return
+	}
+
+	channels := grv.channels.Channels()
+	configPath := RepoConfigFilePath(workdir)
+
+	content, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Errorf("Unable to read repository config file %v: %v", configPath, err)
+			channels.ReportError(err)
+		}
+
+		return
+	}
+
+	var trustStore *RepoConfigTrustStore
+	trusted := false
+
+	if configDir := grv.config.ConfigDir(); configDir != "" {
+		trustStore = NewRepoConfigTrustStore(configDir)
+
+		if trusted, err = trustStore.IsTrusted(configPath, content); err != nil {
+			log.Errorf("Unable to read trusted repository config files: %v", err)
+		}
+	}
+
+	if !trusted {
+		if grv.batch {
+			log.Warnf("Not loading untrusted repository config file %v", configPath)
+			return
+		}
+
+		switch PromptRepoConfigTrust(os.Stdin, os.Stdout, configPath) {
+		case RctDeny:
+			log.Infof("Not loading repository config file %v", configPath)
+			return
+		case RctAllowAlways:
+			if trustStore != nil {
+				if err = trustStore.Trust(configPath, content); err != nil {
+					log.Errorf("Unable to trust repository config file %v: %v", configPath, err)
+					channels.ReportError(err)
+				}
+			}
+		}
+	}
+
+	grv.repoConfigContent = content
+
+	if configErrors := grv.config.LoadRepoConfigReader(bytes.NewReader(content), configPath); configErrors != nil {
+		channels.ReportErrors(configErrors)
+	}
+}
+
+// reloadedRepoConfigContent returns the repository config content to load when the config is reloaded.
+// Modified content is only loaded if it has been trusted, otherwise the previously loaded content is used
+func (grv *GRV) reloadedRepoConfigContent() []byte {
+	if grv.repoConfigContent == nil {
+		return nil
+	}
+
+	configPath := RepoConfigFilePath(grv.repoData.Workdir())
+
+	content, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Infof("Repository config file %v no longer exists", configPath)
+			return nil
+		}
+
+		log.Errorf("Unable to read repository config file %v: %v", configPath, err)
+		return grv.repoConfigContent
+	}
+
+	if bytes.Equal(content, grv.repoConfigContent) {
+		return content
+	}
+
+	if configDir := grv.config.ConfigDir(); configDir != "" {
+		if trusted, err := NewRepoConfigTrustStore(configDir).IsTrusted(configPath, content); err != nil {
+			log.Errorf("Unable to read trusted repository config files: %v", err)
+		} else if trusted {
+			return content
+		}
+	}
+
+	log.Warnf("Repository config file %v has changed and is not trusted", configPath)
+	grv.channels.Channels().ReportError(fmt.Errorf("Repository config file %v has changed and is not trusted. Restart grv to review the changes", configPath))
+
+	return grv.repoConfigContent
+}
+
+// reloadConfig reloads all config files. The running config is retained if any errors occur
+func (grv *GRV) reloadConfig() {
+	channels := grv.channels.Channels()
+	repoConfigContent := grv.reloadedRepoConfigContent()
+
+	errs := grv.config.Reload(func(config *Configuration) (errs []error) {
+		errs = config.LoadConfigFile()
+
+		if repoConfigContent != nil {
+			configPath := RepoConfigFilePath(grv.repoData.Workdir())
+			errs = append(errs, config.LoadRepoConfigReader(bytes.NewReader(repoConfigContent), configPath)...)
+		}
+
+		return
+	})
+
+	if len(errs) > 0 {
+		channels.ReportErrors(errs)
+		channels.ReportStatus("Config not reloaded as errors were found")
+		return
+	}
+
+	grv.repoConfigContent = repoConfigContent
+	grv.inputBuffer.SetKeyBindings(grv.config.KeyBindings())
+	grv.watchConfigFiles()
+
+	channels.ReportStatus("Reloaded config")
+	channels.UpdateDisplay()
+}
+
+// watchConfigFiles updates the set of config files monitored for changes
+func (grv *GRV) watchConfigFiles() {
+	select {
+	case <-grv.configFilesCh:
+	default:
+	}
+
+	grv.configFilesCh <- grv.config.LoadedFiles()
+}
+
+func (grv *GRV) saveSessionState() {
+	if grv.sessionStore == nil {
+		return
+	}
+
+	if err := grv.sessionStore.Save(grv.view.SessionState()); err != nil {
+		log.Errorf("Unable to save session state to %v: %v", grv.sessionStore.FilePath(), err)
+	}
+}
+
 // Run sets up the input, display, action and singal handler loops
 // This function blocks until Exit is called
 func (grv *GRV) Run() {
@@ -315,9 +565,15 @@ func (grv *GRV) Run() {
 	go grv.runHandlerLoop(&waitGroup, channels.exitCh, channels.inputKeyCh, channels.actionCh, channels.errorCh, channels.eventCh)
 	waitGroup.Add(1)
 	go grv.runSignalHandlerLoop(&waitGroup, channels.exitCh)
+	grv.watchConfigFiles()
 	waitGroup.Add(1)
 	go grv.runFileSystemMonitorLoop(&waitGroup, channels.exitCh)
 
+	if grv.controlSocket != nil {
+		waitGroup.Add(1)
+		go grv.controlSocket.Run(&waitGroup)
+	}
+
 	channels.displayCh <- true
 
 	log.Info("Waiting for loops to finish")
@@ -340,15 +596,19 @@ func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputK
 				if err != nil {
 					errorCh <- err
 				} else {
+					pendingBackgroundWork.Start()
 					grv.channels.actionCh <- mouseEventAction
 				}
 			}
 		} else if key != "" {
 			log.Debugf("Received keypress from UI %v", key)
 
+			pendingBackgroundWork.Start()
+
 			select {
 			case inputKeyCh <- key:
 			default:
+				pendingBackgroundWork.Complete()
 				log.Errorf("Unable to add keypress %v to input channel", key)
 			}
 		}
@@ -437,36 +697,90 @@ func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, di
 	}
 }
 
+// processInput maps buffered input to the next action. When the active view accepts
+// text input then keys not bound in that view are converted into text to insert
+func (grv *GRV) processInput() (action Action, keystring string) {
+	viewHierarchy := grv.view.ActiveViewIDHierarchy()
+	activeViewID := viewHierarchy[len(viewHierarchy)-1]
+
+	if !IsTextInputView(activeViewID) {
+		return grv.inputBuffer.Process(viewHierarchy)
+	}
+
+	if action, keystring = grv.inputBuffer.ProcessTextInput(activeViewID); action.ActionType == ActionNone && keystring != "" {
+		action = Action{
+			ActionType: ActionInsertText,
+			Args:       []interface{}{ActionInsertTextArgs{text: keystring}},
+		}
+	}
+
+	return
+}
+
 func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, inputKeyCh <-chan string, actionCh chan Action, errorCh chan<- error, eventCh <-chan Event) {
 	defer waitGroup.Done()
 	defer log.Info("Handler loop stopping")
 	log.Info("Starting handler loop")
 
+	keyHintTimer := time.NewTimer(time.Hour)
+	keyHintTimer.Stop()
+
 	for {
 		select {
 		case key := <-inputKeyCh:
+			grv.view.HideKeyHints()
+
+			// Keys still buffered from earlier input form part of any key sequence which stops recording
+			recordedKeyNum := grv.inputBuffer.BufferedKeyNum() + len(TokeniseKeys(key))
+			grv.macroRecorder.Record(key)
 			grv.inputBuffer.Append(key)
+			grv.macroReplays = 0
+
+			if grv.pendingMacro != nil {
+				action := *grv.pendingMacro
+				grv.pendingMacro = nil
+
+				if err := grv.processMacroAction(action, recordedKeyNum); err != nil {
+					errorCh <- err
+				}
+			}
 
 			for {
-				viewHierarchy := grv.view.ActiveViewIDHierarchy()
-				action, keystring := grv.inputBuffer.Process(viewHierarchy)
+				action, keystring := grv.processInput()
 
 				if action.ActionType != ActionNone {
-					if IsPromptAction(action.ActionType) {
+					if IsMacroAction(action.ActionType) {
+						if err := grv.processMacroAction(action, recordedKeyNum); err != nil {
+							errorCh <- err
+						}
+					} else if IsPromptAction(action.ActionType) {
 						keys, enterFound := grv.inputBuffer.DiscardTo("<Enter>")
 						if enterFound {
 							keys = strings.TrimSuffix(keys, "<Enter>")
 						}
 
-						action.Args = append(action.Args, ActionPromptArgs{
+						promptArgs := ActionPromptArgs{
 							keys:       keys,
 							terminated: enterFound,
-						})
+						}
+
+						// Input entered at the prompt is read directly by readline and is not received as keys
+						if !enterFound && grv.macroRecorder.IsRecording() {
+							promptKeyNum := len(TokeniseKeys(keystring))
+							initialKeyNum := len(TokeniseKeys(keys))
+
+							promptArgs.inputRecorder = func(input string, cancelled bool) {
+								grv.macroRecorder.RecordPrompt(promptKeyNum, initialKeyNum, input, cancelled)
+							}
+						}
+
+						action.Args = append(action.Args, promptArgs)
 
 						if err := grv.view.HandleAction(action); err != nil {
 							errorCh <- err
 						}
 					} else {
+						pendingBackgroundWork.Start()
 						actionCh <- action
 					}
 				} else if keystring != "" {
@@ -475,6 +789,11 @@ func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, in
 					break
 				}
 			}
+
+			grv.resetKeyHintTimer(keyHintTimer)
+			pendingBackgroundWork.Complete()
+		case <-keyHintTimer.C:
+			grv.showKeyHints()
 		case action := <-actionCh:
 			switch action.ActionType {
 			case ActionExit:
@@ -489,18 +808,35 @@ func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, in
 				if err := grv.sleep(action); err != nil {
 					errorCh <- err
 				}
+			case ActionEvaluateCommand:
+				if err := grv.evaluateCommand(action); err != nil {
+					errorCh <- err
+				}
+			case ActionMapMacro:
+				if err := grv.mapMacro(action); err != nil {
+					errorCh <- err
+				}
 			default:
 				if err := grv.view.HandleAction(action); err != nil {
 					errorCh <- err
 				}
 			}
+
+			pendingBackgroundWork.Complete()
 		case event := <-eventCh:
 			log.Infof("Received event: %v", event)
+
+			if event.EventType == ConfigFileChangedEvent {
+				grv.reloadConfig()
+			}
+
 			for _, eventListener := range grv.eventListeners {
 				if err := eventListener.HandleEvent(event); err != nil {
 					errorCh <- err
 				}
 			}
+
+			pendingBackgroundWork.Complete()
 		case _, ok := <-exitCh:
 			if !ok {
 				return
@@ -509,6 +845,117 @@ func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, in
 	}
 }
 
+func (grv *GRV) resetKeyHintTimer(keyHintTimer *time.Timer) {
+	if !keyHintTimer.Stop() {
+		select {
+		case <-keyHintTimer.C:
+		default:
+		}
+	}
+
+	keyHintTimeout := grv.config.GetInt(CfKeyHintTimeout)
+	if keyHintTimeout <= 0 {
+		return
+	}
+
+	if _, isPrefix := grv.inputBuffer.PendingPrefix(grv.view.ActiveViewIDHierarchy()); isPrefix {
+		keyHintTimer.Reset(time.Duration(keyHintTimeout) * time.Millisecond)
+	}
+}
+
+func (grv *GRV) showKeyHints() {
+	viewHierarchy := grv.view.ActiveViewIDHierarchy()
+
+	if prefix, isPrefix := grv.inputBuffer.PendingPrefix(viewHierarchy); isPrefix {
+		completions := grv.config.KeyBindings().Completions(viewHierarchy, prefix)
+		log.Debugf("Displaying %v key hint(s) for prefix %v", len(completions), prefix)
+		grv.view.ShowKeyHints(prefix, completions)
+	}
+}
+
+// processMacroAction starts or stops recording a macro or replays a macro.
+// The register the macro is recorded in or replayed from is the key following the action.
+// If no key has been entered yet the action is processed when the next key is received
+func (grv *GRV) processMacroAction(action Action, recordedKeyNum int) (err error) {
+	channels := grv.channels.Channels()
+
+	if action.ActionType == ActionRecordMacro && grv.macroRecorder.IsRecording() {
+		var register string
+		if register, err = grv.macroRecorder.StopRecording(recordedKeyNum); err != nil {
+			return
+		}
+
+		channels.ReportStatus("Recorded macro @%v", register)
+		return
+	}
+
+	register, registerEntered := grv.inputBuffer.PopKey()
+	if !registerEntered {
+		grv.pendingMacro = &action
+		return
+	}
+
+	switch action.ActionType {
+	case ActionRecordMacro:
+		if err = grv.macroRecorder.StartRecording(register); err != nil {
+			return
+		}
+
+		channels.ReportStatus("Recording macro @%v", register)
+	case ActionPlayMacro:
+		var keys string
+		if keys, err = grv.macroRecorder.Replay(register); err != nil {
+			return
+		}
+
+		count, _ := GetActionCount(action)
+		grv.macroReplays += int(count)
+
+		if grv.macroReplays > mcMaxReplays {
+			grv.inputBuffer.DiscardTo("")
+			return fmt.Errorf("Macro replay limit of %v reached", mcMaxReplays)
+		}
+
+		log.Debugf("Replaying macro @%v %v time(s): %v", register, count, keys)
+		grv.inputBuffer.Prepend(strings.Repeat(keys, int(count)))
+	}
+
+	return
+}
+
+// mapMacro maps a key sequence to the keys recorded in a macro and adds the map command to the grvrc file
+func (grv *GRV) mapMacro(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected argument of type ActionMapMacroArgs")
+	}
+
+	arg, ok := action.Args[0].(ActionMapMacroArgs)
+	if !ok {
+		return fmt.Errorf("Expected argument of type ActionMapMacroArgs but found type %T", action.Args[0])
+	}
+
+	keys, err := grv.macroRecorder.Macro(arg.register)
+	if err != nil {
+		return
+	} else if keys == "" {
+		return fmt.Errorf("Macro @%v is empty", arg.register)
+	}
+
+	mapLine := fmt.Sprintf("%v %v %v %v", mapCommand, arg.view, quoteConfigWord(arg.from), quoteConfigWord(keys))
+
+	if errs := grv.config.Evaluate(mapLine); len(errs) > 0 {
+		return errs[0]
+	}
+
+	if err = grv.config.AppendToConfigFile(mapLine); err != nil {
+		return
+	}
+
+	grv.channels.Channels().ReportStatus("Added \"%v\" to grvrc", mapLine)
+
+	return
+}
+
 func (grv *GRV) runCommand(action Action) (err error) {
 	if len(action.Args) == 0 {
 		return fmt.Errorf("Expected argument of type ActionRunCommandArgs")
@@ -606,6 +1053,26 @@ func (grv *GRV) sleep(action Action) (err error) {
 	return
 }
 
+func (grv *GRV) evaluateCommand(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected argument of type ActionEvaluateCommandArgs")
+	}
+
+	arg, ok := action.Args[0].(ActionEvaluateCommandArgs)
+	if !ok {
+		return fmt.Errorf("Expected argument of type ActionEvaluateCommandArgs but found type %T", action.Args[0])
+	}
+
+	log.Debugf("Evaluating command: %v", arg.command)
+	errs := grv.config.Evaluate(arg.command)
+
+	if arg.onComplete != nil {
+		arg.onComplete(errs)
+	}
+
+	return
+}
+
 func (grv *GRV) runSignalHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool) {
 	defer waitGroup.Done()
 	defer log.Info("Signal handler loop stopping")
@@ -636,6 +1103,8 @@ func (grv *GRV) runSignalHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bo
 					log.Errorf("Unable to resize display: %v", err)
 				}
 
+				grv.setTerminalDimensionVariables(grv.ui.ViewDimension())
+
 				grv.channels.displayCh <- true
 			}
 		case _, ok := <-exitCh:
@@ -646,6 +1115,39 @@ func (grv *GRV) runSignalHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bo
 	}
 }
 
+// watchConfigFiles watches the directories containing the provided config files for events.
+// Directories in the repository are already watched and directories which have been watched previously are not watched again.
+// The returned set contains both the provided and canonical paths of each config file
+func watchConfigFiles(filePaths []string, repoFilePath string, configEventCh chan fs.EventInfo, watchedConfigDirs map[string]bool) (configFiles map[string]bool) {
+	configFiles = map[string]bool{}
+	repoDir := filepath.Clean(repoFilePath) + string(filepath.Separator)
+
+	for _, filePath := range filePaths {
+		configFiles[filePath] = true
+
+		if canonicalFilePath, err := CanonicalPath(filePath); err == nil {
+			configFiles[canonicalFilePath] = true
+			filePath = canonicalFilePath
+		}
+
+		configDir := filepath.Dir(filePath)
+
+		if watchedConfigDirs[configDir] || strings.HasPrefix(configDir+string(filepath.Separator), repoDir) {
+			continue
+		}
+
+		if err := fs.Watch(configDir, configEventCh, fs.All); err != nil {
+			log.Errorf("Unable to watch config directory %v for filesystem events: %v", configDir, err)
+			continue
+		}
+
+		log.Infof("Watching filesystem events for config directory: %v", configDir)
+		watchedConfigDirs[configDir] = true
+	}
+
+	return
+}
+
 func (grv *GRV) runFileSystemMonitorLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool) {
 	defer waitGroup.Done()
 	defer log.Info("FileSystem Monitor loop stopping")
@@ -666,10 +1168,28 @@ func (grv *GRV) runFileSystemMonitorLoop(waitGroup *sync.WaitGroup, exitCh <-cha
 
 	log.Infof("Watching filesystem events for path: %v", watchDir)
 
+	configEventCh := make(chan fs.EventInfo, 1)
+	defer fs.Stop(configEventCh)
+
+	configFiles := map[string]bool{}
+	watchedConfigDirs := map[string]bool{}
+
 	timer := time.NewTimer(time.Hour)
 	timer.Stop()
 	timerActive := false
 
+	configTimer := time.NewTimer(time.Hour)
+	configTimer.Stop()
+	configTimerActive := false
+
+	onConfigFileEvent := func(event fs.EventInfo) {
+		if configFiles[event.Path()] && !configTimerActive {
+			log.Debugf("Config file event: %v", event)
+			configTimer.Reset(grvConfigReloadDelay)
+			configTimerActive = true
+		}
+	}
+
 	ignorePaths := map[string]bool{}
 
 	logFile := LogFile()
@@ -699,7 +1219,16 @@ func (grv *GRV) runFileSystemMonitorLoop(waitGroup *sync.WaitGroup, exitCh <-cha
 				if !gitDirModified && strings.HasPrefix(event.Path(), repoGitDir) {
 					gitDirModified = true
 				}
+
+				onConfigFileEvent(event)
 			}
+		case event := <-configEventCh:
+			onConfigFileEvent(event)
+		case filePaths := <-grv.configFilesCh:
+			configFiles = watchConfigFiles(filePaths, repoFilePath, configEventCh, watchedConfigDirs)
+		case <-configTimer.C:
+			configTimerActive = false
+			channels.ReportEvent(Event{EventType: ConfigFileChangedEvent})
 		case <-timer.C:
 			timerActive = false
 
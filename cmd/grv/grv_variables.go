@@ -20,6 +20,8 @@ const (
 	VarLineCount
 	VarRepoPath
 	VarRepoWorkDir
+	VarTerminalRows
+	VarTerminalCols
 
 	VarCount
 )
@@ -90,6 +92,16 @@ var variableDescriptors = []variableDescriptor{
 		name:        "repo-workdir",
 		description: "Work directory path",
 	},
+	{
+		variable:    VarTerminalRows,
+		name:        "terminal-rows",
+		description: "Number of rows in the terminal",
+	},
+	{
+		variable:    VarTerminalCols,
+		name:        "terminal-cols",
+		description: "Number of columns in the terminal",
+	},
 }
 
 var activeViewOnlyVariables = map[GRVVariable]bool{}
@@ -495,6 +495,7 @@ func GenerateHelpView(config Config) (helpSections []*HelpSection, helpViewIndex
 	helpSections = append(helpSections, config.GenerateHelpSections()...)
 	helpSections = append(helpSections, GenerateShellCommandHelpSections(config)...)
 	helpSections = append(helpSections, GenerateFilterQueryLanguageHelpSections(config)...)
+	helpSections = append(helpSections, GenerateControlSocketHelpSections(config)...)
 
 	helpViewIndex = NewHelpViewIndex(helpSections)
 	indexHelpSection := helpViewIndex.generateHelpSection()
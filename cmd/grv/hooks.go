@@ -0,0 +1,150 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+type hookEventDescriptor struct {
+	name        string
+	eventType   EventType
+	description string
+}
+
+var hookEventDescriptors = []hookEventDescriptor{
+	{
+		name:        "head-changed",
+		eventType:   HeadChangedEvent,
+		description: "HEAD has changed to point to a different ref or commit",
+	},
+	{
+		name:        "refs-changed",
+		eventType:   RefsChangedEvent,
+		description: "Refs have been added, removed or updated",
+	},
+	{
+		name:        "status-changed",
+		eventType:   StatusChangedEvent,
+		description: "The git status of the work tree has changed",
+	},
+	{
+		name:        "post-commit",
+		eventType:   PostCommitEvent,
+		description: "A commit has been created or amended",
+	},
+	{
+		name:        "post-push",
+		eventType:   PostPushEvent,
+		description: "A ref has been pushed to a remote",
+	},
+	{
+		name:        "post-checkout",
+		eventType:   PostCheckoutEvent,
+		description: "A ref or commit has been checked out",
+	},
+}
+
+var hookEventNameMap = map[string]EventType{}
+var hookEventTypeMap = map[EventType]string{}
+
+func init() {
+	for _, hookEventDescriptor := range hookEventDescriptors {
+		hookEventNameMap[hookEventDescriptor.name] = hookEventDescriptor.eventType
+		hookEventTypeMap[hookEventDescriptor.eventType] = hookEventDescriptor.name
+	}
+}
+
+// LookupHookEvent returns the event type with the provided hook event name
+func LookupHookEvent(name string) (eventType EventType, exists bool) {
+	eventType, exists = hookEventNameMap[name]
+	return
+}
+
+// HookEventName returns the hook event name of the provided event type
+func HookEventName(eventType EventType) string {
+	return hookEventTypeMap[eventType]
+}
+
+// IsHookEvent returns true if hooks can be registered for the provided event type
+func IsHookEvent(eventType EventType) bool {
+	_, isHookEvent := hookEventTypeMap[eventType]
+	return isHookEvent
+}
+
+// HookEventReporter reports repository state changes as events so hooks can be run
+type HookEventReporter struct {
+	channels     Channels
+	refsLoaded   bool
+	statusLoaded bool
+	lock         sync.Mutex
+}
+
+// NewHookEventReporter creates a new instance
+func NewHookEventReporter(channels Channels) *HookEventReporter {
+	return &HookEventReporter{
+		channels: channels,
+	}
+}
+
+// OnRefsChanged reports a refs-changed event.
+// The initial load of refs is not reported
+func (hookEventReporter *HookEventReporter) OnRefsChanged(addedRefs, removedRefs []Ref, updatedRefs []*UpdatedRef) {
+	hookEventReporter.lock.Lock()
+	initialLoad := !hookEventReporter.refsLoaded && len(removedRefs) == 0 && len(updatedRefs) == 0
+	hookEventReporter.refsLoaded = true
+	hookEventReporter.lock.Unlock()
+
+	if !initialLoad {
+		hookEventReporter.reportEvent(RefsChangedEvent)
+	}
+}
+
+// OnHeadChanged reports a head-changed event
+func (hookEventReporter *HookEventReporter) OnHeadChanged(oldHead, newHead Ref) {
+	hookEventReporter.reportEvent(HeadChangedEvent)
+}
+
+// OnTrackingBranchesUpdated does nothing
+func (hookEventReporter *HookEventReporter) OnTrackingBranchesUpdated(trackingBranches []*LocalBranch) {
+}
+
+// OnStatusChanged reports a status-changed event.
+// The initial load of the status is not reported
+func (hookEventReporter *HookEventReporter) OnStatusChanged(status *Status) {
+	hookEventReporter.lock.Lock()
+	initialLoad := !hookEventReporter.statusLoaded
+	hookEventReporter.statusLoaded = true
+	hookEventReporter.lock.Unlock()
+
+	if !initialLoad {
+		hookEventReporter.reportEvent(StatusChangedEvent)
+	}
+}
+
+func (hookEventReporter *HookEventReporter) reportEvent(eventType EventType) {
+	log.Debugf("Reporting hook event %v", HookEventName(eventType))
+	hookEventReporter.channels.ReportEvent(Event{EventType: eventType})
+}
+
+// GenerateHookEventsHelpSection generates documentation for the events hooks can be registered for
+func GenerateHookEventsHelpSection(config Config) *HelpSection {
+	headers := []TableHeader{
+		{text: "Event", themeComponentID: CmpHelpViewSectionTableHeader},
+		{text: "Description", themeComponentID: CmpHelpViewSectionTableHeader},
+	}
+
+	tableFormatter := NewTableFormatterWithHeaders(headers, config)
+	tableFormatter.SetGridLines(true)
+
+	tableFormatter.Resize(uint(len(hookEventDescriptors)))
+
+	for rowIndex, hookEventDescriptor := range hookEventDescriptors {
+		tableFormatter.SetCellWithStyle(uint(rowIndex), 0, CmpHelpViewSectionTableRow, "%v", hookEventDescriptor.name)
+		tableFormatter.SetCellWithStyle(uint(rowIndex), 1, CmpHelpViewSectionTableRow, "%v", hookEventDescriptor.description)
+	}
+
+	return &HelpSection{
+		tableFormatter: tableFormatter,
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"strconv"
 	"strings"
 )
 
+const ibMaxCountDigits = 6
+
 // InputBuffer buffers input and maps it to configured actions or key sequences
 type InputBuffer struct {
 	buffer      []string
@@ -18,12 +21,36 @@ func NewInputBuffer(keyBindings KeyBindings) *InputBuffer {
 	}
 }
 
+// SetKeyBindings replaces the key bindings input is mapped with
+func (inputBuffer *InputBuffer) SetKeyBindings(keyBindings KeyBindings) {
+	inputBuffer.keyBindings = keyBindings
+}
+
 // Append adds new input to the end of the buffer
 func (inputBuffer *InputBuffer) Append(input string) {
 	keys := TokeniseKeys(input)
 	inputBuffer.buffer = append(inputBuffer.buffer, keys...)
 }
 
+// Prepend adds input to the start of the buffer so that it is processed before any input already buffered
+func (inputBuffer *InputBuffer) Prepend(input string) {
+	inputBuffer.prepend(TokeniseKeys(input))
+}
+
+// PopKey removes and returns the next key in the buffer
+func (inputBuffer *InputBuffer) PopKey() (key string, exists bool) {
+	if !inputBuffer.hasInput() {
+		return
+	}
+
+	return inputBuffer.pop(), true
+}
+
+// BufferedKeyNum returns the number of keys which have not yet been processed
+func (inputBuffer *InputBuffer) BufferedKeyNum() int {
+	return len(inputBuffer.buffer)
+}
+
 func (inputBuffer *InputBuffer) prepend(keys []string) {
 	inputBuffer.buffer = append(keys, inputBuffer.buffer...)
 }
@@ -38,14 +65,50 @@ func (inputBuffer *InputBuffer) hasInput() bool {
 	return len(inputBuffer.buffer) > 0
 }
 
+// popCount removes any count from the start of the buffer. Digits are only
+// treated as part of a count if they are not bound to an action or key sequence
+func (inputBuffer *InputBuffer) popCount(viewHierarchy ViewHierarchy) (count uint, countKeys []string) {
+	for inputBuffer.hasInput() {
+		key := inputBuffer.buffer[0]
+
+		if len(key) != 1 || key < "0" || key > "9" || (key == "0" && len(countKeys) == 0) {
+			break
+		} else if binding, isPrefix := inputBuffer.keyBindings.Binding(viewHierarchy, key); isPrefix ||
+			binding.bindingType == BtKeystring || binding.actionType != ActionNone {
+			break
+		}
+
+		countKeys = append(countKeys, inputBuffer.pop())
+	}
+
+	countString := strings.Join(countKeys, "")
+	if len(countString) > ibMaxCountDigits {
+		countString = countString[:ibMaxCountDigits]
+	}
+
+	if parsedCount, err := strconv.ParseUint(countString, 10, 32); err == nil {
+		count = uint(parsedCount)
+	}
+
+	return
+}
+
 // Process goes through the input in the buffer and attempts to map it to actions or key sequences
 // If no mapping is possible the key sequences on the buffer are returned.
-// If a prefix is matched then the buffer returns NOP so that more input can be appended to it
+// If a prefix is matched then the buffer returns NOP so that more input can be appended to it.
+// A count preceding the key sequence of an action which accepts a count is provided as an action argument.
+// A count preceding a key sequence which does not map to an action is returned as part of the key sequence
 func (inputBuffer *InputBuffer) Process(viewHierarchy ViewHierarchy) (action Action, keystring string) {
 	if !inputBuffer.hasInput() {
 		return
 	}
 
+	count, countKeys := inputBuffer.popCount(viewHierarchy)
+	if !inputBuffer.hasInput() {
+		inputBuffer.prepend(countKeys)
+		return
+	}
+
 	keyBuffer := make([]string, 0)
 	keyBindings := inputBuffer.keyBindings
 	isPrefix := false
@@ -58,7 +121,7 @@ OuterLoop:
 		switch {
 		case prefix:
 			if len(inputBuffer.buffer) == 0 {
-				inputBuffer.prepend(keyBuffer)
+				inputBuffer.prepend(append(countKeys, keyBuffer...))
 				return
 			}
 
@@ -66,6 +129,10 @@ OuterLoop:
 		case binding.bindingType == BtAction:
 			if binding.actionType != ActionNone {
 				action = Action{ActionType: binding.actionType}
+
+				if count > 0 && ActionAcceptsCount(action.ActionType) {
+					action.Args = []interface{}{ActionCountArgs{count: count}}
+				}
 			} else if isPrefix {
 				inputBuffer.prepend(keyBuffer[1:])
 				keyBuffer = keyBuffer[0:1]
@@ -79,11 +146,63 @@ OuterLoop:
 		}
 	}
 
+	if action.ActionType == ActionNone {
+		keyBuffer = append(countKeys, keyBuffer...)
+	}
+
 	keystring = strings.Join(keyBuffer, "")
 
 	return
 }
 
+// ProcessTextInput maps input to actions bound in the provided view only so that views accepting text input
+// receive all other keys. When the next key is not bound it is returned as the keystring to be inserted as text.
+// No count is parsed as digits form part of the text entered
+func (inputBuffer *InputBuffer) ProcessTextInput(viewID ViewID) (action Action, keystring string) {
+	keyBuffer := make([]string, 0)
+
+	for inputBuffer.hasInput() {
+		keyBuffer = append(keyBuffer, inputBuffer.pop())
+		binding, isPrefix := inputBuffer.keyBindings.ViewBinding(viewID, strings.Join(keyBuffer, ""))
+
+		switch {
+		case isPrefix:
+			if !inputBuffer.hasInput() {
+				inputBuffer.prepend(keyBuffer)
+				return
+			}
+		case binding.bindingType == BtKeystring:
+			inputBuffer.prepend(TokeniseKeys(binding.keystring))
+			keyBuffer = keyBuffer[0:0]
+		case binding.actionType != ActionNone:
+			action = Action{ActionType: binding.actionType}
+			keystring = strings.Join(keyBuffer, "")
+			return
+		default:
+			inputBuffer.prepend(keyBuffer[1:])
+			keystring = keyBuffer[0]
+			return
+		}
+	}
+
+	return
+}
+
+// PendingPrefix returns the buffered key sequence, excluding any count, when it is a prefix of at least one binding
+func (inputBuffer *InputBuffer) PendingPrefix(viewHierarchy ViewHierarchy) (prefix string, isPrefix bool) {
+	_, countKeys := inputBuffer.popCount(viewHierarchy)
+	defer inputBuffer.prepend(countKeys)
+
+	if !inputBuffer.hasInput() {
+		return
+	}
+
+	prefix = strings.Join(inputBuffer.buffer, "")
+	_, isPrefix = inputBuffer.keyBindings.Binding(viewHierarchy, prefix)
+
+	return
+}
+
 // DiscardTo discards and returns all pending input up to and including the provided targetKey
 // If the targetKey is not present then all pending input is discarded
 func (inputBuffer *InputBuffer) DiscardTo(targetKey string) (discarded string, targetKeyFound bool) {
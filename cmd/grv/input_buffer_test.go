@@ -16,6 +16,11 @@ func (keyBindings *MockKeyBindings) Binding(viewHierarchy ViewHierarchy, keystri
 	return args.Get(0).(Binding), args.Bool(1)
 }
 
+func (keyBindings *MockKeyBindings) ViewBinding(viewID ViewID, keystring string) (binding Binding, isPrefix bool) {
+	args := keyBindings.Called(viewID, keystring)
+	return args.Get(0).(Binding), args.Bool(1)
+}
+
 func (keyBindings *MockKeyBindings) SetActionBinding(viewID ViewID, keystring string, actionType ActionType) {
 	keyBindings.Called(viewID, keystring, actionType)
 }
@@ -34,6 +39,15 @@ func (keyBindings *MockKeyBindings) KeyStrings(actionType ActionType, viewID Vie
 	return args.Get(0).([]BoundKeyString)
 }
 
+func (keyBindings *MockKeyBindings) Completions(viewHierarchy ViewHierarchy, prefix string) []KeyBindingCompletion {
+	args := keyBindings.Called(viewHierarchy, prefix)
+	return args.Get(0).([]KeyBindingCompletion)
+}
+
+func (keyBindings *MockKeyBindings) SetKeymaps(keymaps []*Keymap) {
+	keyBindings.Called(keymaps)
+}
+
 func (keyBindings *MockKeyBindings) GenerateHelpSections(config Config) []*HelpSection {
 	args := keyBindings.Called(config)
 	return args.Get(0).([]*HelpSection)
@@ -131,6 +145,183 @@ func TestPotentialPrefixMatchIsReturnedAsSeparateKeysWhenFullInputDoesNotMatchBi
 	checkProcessResult(Action{ActionType: ActionNone}, "b", action, keyString, t)
 }
 
+func TestCountIsProvidedToActionsWhichAcceptACount(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "1").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "2").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "0").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "j").Return(newActionBinding(ActionNextLine), false)
+	keyBindings.On("Binding", viewHierarchy, "g").Return(newActionBinding(ActionNone), true)
+	keyBindings.On("Binding", viewHierarchy, "gg").Return(newActionBinding(ActionFirstLine), false)
+	keyBindings.On("Binding", viewHierarchy, "q").Return(newActionBinding(ActionExit), false)
+
+	inputBuffer.Append("1")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("0j")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine, Args: []interface{}{ActionCountArgs{count: 10}}}, "j", action, keyString, t)
+
+	inputBuffer.Append("12g")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("g")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionFirstLine, Args: []interface{}{ActionCountArgs{count: 12}}}, "gg", action, keyString, t)
+
+	inputBuffer.Append("2q")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionExit}, "q", action, keyString, t)
+}
+
+func TestCountIsReturnedWithUnboundKeyAndNotProvidedToLaterKeys(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "3").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "x").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "j").Return(newActionBinding(ActionNextLine), false)
+
+	inputBuffer.Append("3xj")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "3x", action, keyString, t)
+
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine}, "j", action, keyString, t)
+}
+
+func TestCountIsKeptUntilMultiKeySequenceCompletesOrIsRejected(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "3").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "a").Return(newActionBinding(ActionNone), true)
+	keyBindings.On("Binding", viewHierarchy, "ab").Return(newActionBinding(ActionNone), true)
+	keyBindings.On("Binding", viewHierarchy, "abc").Return(newActionBinding(ActionNextLine), false)
+	keyBindings.On("Binding", viewHierarchy, "abx").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "b").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "x").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "j").Return(newActionBinding(ActionNextLine), false)
+
+	inputBuffer.Append("3a")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("b")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("c")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine, Args: []interface{}{ActionCountArgs{count: 3}}}, "abc", action, keyString, t)
+
+	inputBuffer.Append("3abxj")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "3a", action, keyString, t)
+
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "b", action, keyString, t)
+
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "x", action, keyString, t)
+
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine}, "j", action, keyString, t)
+}
+
+func TestBoundDigitsAreNotTreatedAsACount(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "1").Return(newActionBinding(ActionNextTab), false)
+
+	inputBuffer.Append("1")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextTab}, "1", action, keyString, t)
+}
+
+func TestPendingPrefixExcludesCountAndLeavesInputBuffered(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "1").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "2").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "g").Return(newActionBinding(ActionNone), true)
+	keyBindings.On("Binding", viewHierarchy, "gg").Return(newActionBinding(ActionFirstLine), false)
+
+	inputBuffer.Append("12")
+	if _, isPrefix := inputBuffer.PendingPrefix(viewHierarchy); isPrefix {
+		t.Errorf("Expected a count alone not to be a pending prefix")
+	}
+
+	inputBuffer.Append("g")
+	prefix, isPrefix := inputBuffer.PendingPrefix(viewHierarchy)
+	if !isPrefix || prefix != "g" {
+		t.Errorf("Pending prefix does not match expected value. Expected: g, Actual: %v (isPrefix: %v)", prefix, isPrefix)
+	}
+
+	inputBuffer.Append("g")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionFirstLine, Args: []interface{}{ActionCountArgs{count: 12}}}, "gg", action, keyString, t)
+}
+
+func TestTextInputIsReturnedOneKeyAtATimeWhenUnbound(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	keyBindings.On("ViewBinding", ViewCommitMessage, "1").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("ViewBinding", ViewCommitMessage, "x").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("ViewBinding", ViewCommitMessage, "<Enter>").Return(newActionBinding(ActionNewLine), false)
+
+	inputBuffer.Append("1x<Enter>")
+
+	action, keyString := inputBuffer.ProcessTextInput(ViewCommitMessage)
+	checkProcessResult(Action{ActionType: ActionNone}, "1", action, keyString, t)
+
+	action, keyString = inputBuffer.ProcessTextInput(ViewCommitMessage)
+	checkProcessResult(Action{ActionType: ActionNone}, "x", action, keyString, t)
+
+	action, keyString = inputBuffer.ProcessTextInput(ViewCommitMessage)
+	checkProcessResult(Action{ActionType: ActionNewLine}, "<Enter>", action, keyString, t)
+
+	action, keyString = inputBuffer.ProcessTextInput(ViewCommitMessage)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+}
+
+func TestTextInputPrefixIsReturnedAsTextWhenFullInputDoesNotMatchBinding(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	keyBindings.On("ViewBinding", ViewCommitMessage, "<C-x>").Return(newActionBinding(ActionNone), true)
+	keyBindings.On("ViewBinding", ViewCommitMessage, "<C-x>a").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("ViewBinding", ViewCommitMessage, "a").Return(newActionBinding(ActionNone), false)
+
+	inputBuffer.Append("<C-x>")
+	action, keyString := inputBuffer.ProcessTextInput(ViewCommitMessage)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("a")
+	action, keyString = inputBuffer.ProcessTextInput(ViewCommitMessage)
+	checkProcessResult(Action{ActionType: ActionNone}, "<C-x>", action, keyString, t)
+
+	action, keyString = inputBuffer.ProcessTextInput(ViewCommitMessage)
+	checkProcessResult(Action{ActionType: ActionNone}, "a", action, keyString, t)
+}
+
 func TestDiscardToOnlyDiscardsInputUntilProvidedKey(t *testing.T) {
 	keyBindings := &MockKeyBindings{}
 	inputBuffer := NewInputBuffer(keyBindings)
@@ -31,6 +31,7 @@ const (
 	ActionSuspend
 	ActionRunCommand
 	ActionSleep
+	ActionEvaluateCommand
 	ActionPrompt
 	ActionSearchPrompt
 	ActionReverseSearchPrompt
@@ -39,6 +40,7 @@ const (
 	ActionBranchNamePrompt
 	ActionTagNamePrompt
 	ActionCustomPrompt
+	ActionFuzzyFinderPrompt
 	ActionSearch
 	ActionReverseSearch
 	ActionSearchFindNext
@@ -66,6 +68,7 @@ const (
 	ActionRemoveView
 	ActionAddFilter
 	ActionRemoveFilter
+	ActionExport
 	ActionCenterView
 	ActionScrollCursorTop
 	ActionScrollCursorBottom
@@ -103,6 +106,36 @@ const (
 	ActionShowHelpView
 	ActionNextButton
 	ActionPrevButton
+	ActionRecordMacro
+	ActionPlayMacro
+	ActionShowMacroView
+	ActionMapMacro
+	ActionCreateCommitMessageView
+	ActionInsertText
+	ActionNewLine
+	ActionDeleteCharBackward
+	ActionDeleteCharForward
+	ActionCursorLeft
+	ActionCursorRight
+	ActionCursorUp
+	ActionCursorDown
+	ActionCursorLineStart
+	ActionCursorLineEnd
+	ActionSubmitCommitMessage
+	ActionAddSignedOffBy
+	ActionAddCoAuthoredBy
+	ActionPrevCommitMessage
+	ActionNextCommitMessage
+	ActionAddNote
+	ActionEditNote
+	ActionRemoveNote
+	ActionPushTags
+	ActionDeleteRemoteTag
+	ActionVerifyTag
+	ActionShowCompareView
+	ActionCompareRef
+	ActionCreateCommitInfoView
+	ActionShowCommitInfo
 )
 
 // ActionCategory defines the type of an action
@@ -123,6 +156,8 @@ type ActionDescriptor struct {
 	actionKey      string
 	actionCategory ActionCategory
 	promptAction   bool
+	macroAction    bool
+	acceptsCount   bool
 	description    string
 	keyBindings    map[ViewID][]string
 }
@@ -153,6 +188,10 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 		actionCategory: ActionCategoryGeneral,
 		description:    "Sleep for a specified time",
 	},
+	ActionEvaluateCommand: {
+		actionCategory: ActionCategoryGeneral,
+		description:    "Evaluate a configuration command",
+	},
 	ActionPrompt: {
 		actionKey:      "<grv-prompt>",
 		actionCategory: ActionCategoryGeneral,
@@ -210,6 +249,15 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 		promptAction:   true,
 		description:    "Custom prompt for user input",
 	},
+	ActionFuzzyFinderPrompt: {
+		actionKey:      "<grv-fuzzy-finder>",
+		actionCategory: ActionCategoryGeneral,
+		promptAction:   true,
+		description:    "Fuzzy find refs, commits, files, commands and actions",
+		keyBindings: map[ViewID][]string{
+			ViewAll: {"<C-p>"},
+		},
+	},
 	ActionSearch: {
 		actionCategory: ActionCategorySearch,
 		description:    "Perform search forwards",
@@ -221,6 +269,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionSearchFindNext: {
 		actionKey:      "<grv-search-find-next>",
 		actionCategory: ActionCategorySearch,
+		acceptsCount:   true,
 		description:    "Move to next search match",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"n"},
@@ -229,6 +278,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionSearchFindPrev: {
 		actionKey:      "<grv-search-find-prev>",
 		actionCategory: ActionCategorySearch,
+		acceptsCount:   true,
 		description:    "Move to previous search match",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"N"},
@@ -246,6 +296,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionNextLine: {
 		actionKey:      "<grv-next-line>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Move down one line",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"<Down>", "j"},
@@ -254,6 +305,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionPrevLine: {
 		actionKey:      "<grv-prev-line>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Move up one line",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"<Up>", "k"},
@@ -262,6 +314,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionNextPage: {
 		actionKey:      "<grv-next-page>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Move one page down",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"<PageDown>", "<C-f>"},
@@ -270,6 +323,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionPrevPage: {
 		actionKey:      "<grv-prev-page>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Move one page up",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"<PageUp>", "<C-b>"},
@@ -278,6 +332,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionNextHalfPage: {
 		actionKey:      "<grv-next-half-page>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Move half page down",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"<C-d>"},
@@ -286,6 +341,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionPrevHalfPage: {
 		actionKey:      "<grv-prev-half-page>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Move half page up",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"<C-u>"},
@@ -294,6 +350,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionScrollRight: {
 		actionKey:      "<grv-scroll-right>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Scroll right",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"<Right>", "l"},
@@ -302,6 +359,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionScrollLeft: {
 		actionKey:      "<grv-scroll-left>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Scroll left",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"<Left>", "h"},
@@ -310,6 +368,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionFirstLine: {
 		actionKey:      "<grv-first-line>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Move to first line",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"gg"},
@@ -318,6 +377,7 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 	ActionLastLine: {
 		actionKey:      "<grv-last-line>",
 		actionCategory: ActionCategoryMovement,
+		acceptsCount:   true,
 		description:    "Move to last line",
 		keyBindings: map[ViewID][]string{
 			ViewAll: {"G"},
@@ -388,7 +448,8 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 		actionCategory: ActionCategoryViewNavigation,
 		description:    "Close view (or close tab if empty)",
 		keyBindings: map[ViewID][]string{
-			ViewAll: {"q"},
+			ViewAll:           {"q"},
+			ViewCommitMessage: {"<Escape>"},
 		},
 	},
 	ActionAddFilter: {
@@ -404,6 +465,10 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 			ViewRef:    {"<C-r>"},
 		},
 	},
+	ActionExport: {
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Export rows to file",
+	},
 	ActionCenterView: {
 		actionKey:      "<grv-center-view>",
 		actionCategory: ActionCategoryMovement,
@@ -662,6 +727,226 @@ var actionDescriptors = map[ActionType]ActionDescriptor{
 			ViewMessageBox: {"<Left>", "h", "<S-Tab>"},
 		},
 	},
+	ActionRecordMacro: {
+		actionKey:      "<grv-record-macro>",
+		actionCategory: ActionCategoryGeneral,
+		macroAction:    true,
+		description:    "Start or stop recording a macro",
+		keyBindings: map[ViewID][]string{
+			ViewAll: {"Q"},
+		},
+	},
+	ActionPlayMacro: {
+		actionKey:      "<grv-play-macro>",
+		actionCategory: ActionCategoryGeneral,
+		macroAction:    true,
+		acceptsCount:   true,
+		description:    "Replay a recorded macro",
+		keyBindings: map[ViewID][]string{
+			ViewAll: {"@"},
+		},
+	},
+	ActionShowMacroView: {
+		actionKey:      "<grv-show-macros>",
+		actionCategory: ActionCategoryGeneral,
+		description:    "Show the macro view",
+	},
+	ActionMapMacro: {
+		actionCategory: ActionCategoryGeneral,
+		description:    "Map a key sequence to a recorded macro",
+	},
+	ActionCreateCommitMessageView: {
+		actionCategory: ActionCategoryGeneral,
+		description:    "Create a commit message view",
+	},
+	ActionInsertText: {
+		actionCategory: ActionCategoryGeneral,
+		description:    "Insert text into a text input view",
+	},
+	ActionNewLine: {
+		actionKey:      "<grv-new-line>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Insert a new line",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<Enter>"},
+		},
+	},
+	ActionDeleteCharBackward: {
+		actionKey:      "<grv-delete-char-backward>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Delete the character before the cursor",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<Backspace>", "<C-h>"},
+		},
+	},
+	ActionDeleteCharForward: {
+		actionKey:      "<grv-delete-char-forward>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Delete the character under the cursor",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<Dc>", "<C-d>"},
+		},
+	},
+	ActionCursorLeft: {
+		actionKey:      "<grv-cursor-left>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Move the cursor left",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<Left>"},
+		},
+	},
+	ActionCursorRight: {
+		actionKey:      "<grv-cursor-right>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Move the cursor right",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<Right>"},
+		},
+	},
+	ActionCursorUp: {
+		actionKey:      "<grv-cursor-up>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Move the cursor up",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<Up>"},
+		},
+	},
+	ActionCursorDown: {
+		actionKey:      "<grv-cursor-down>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Move the cursor down",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<Down>"},
+		},
+	},
+	ActionCursorLineStart: {
+		actionKey:      "<grv-cursor-line-start>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Move the cursor to the start of the line",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<Home>", "<C-a>"},
+		},
+	},
+	ActionCursorLineEnd: {
+		actionKey:      "<grv-cursor-line-end>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Move the cursor to the end of the line",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<End>", "<C-e>"},
+		},
+	},
+	ActionSubmitCommitMessage: {
+		actionKey:      "<grv-submit-commit-message>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Commit with the entered message",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<C-s>"},
+		},
+	},
+	ActionAddSignedOffBy: {
+		actionKey:      "<grv-add-signed-off-by>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Add a Signed-off-by trailer",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<M-s>"},
+		},
+	},
+	ActionAddCoAuthoredBy: {
+		actionKey:      "<grv-add-co-authored-by>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Add a Co-authored-by trailer",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<M-c>"},
+		},
+	},
+	ActionPrevCommitMessage: {
+		actionKey:      "<grv-prev-commit-message>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Recall the previous commit message",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<M-p>"},
+		},
+	},
+	ActionNextCommitMessage: {
+		actionKey:      "<grv-next-commit-message>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Recall the next commit message",
+		keyBindings: map[ViewID][]string{
+			ViewCommitMessage: {"<M-n>"},
+		},
+	},
+	ActionAddNote: {
+		actionKey:      "<grv-add-note>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Add a note to the commit",
+		keyBindings: map[ViewID][]string{
+			ViewCommit: {"a"},
+		},
+	},
+	ActionEditNote: {
+		actionKey:      "<grv-edit-note>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Edit the note on the commit",
+		keyBindings: map[ViewID][]string{
+			ViewCommit: {"e"},
+		},
+	},
+	ActionRemoveNote: {
+		actionKey:      "<grv-remove-note>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Remove the note from the commit",
+		keyBindings: map[ViewID][]string{
+			ViewCommit: {"D"},
+		},
+	},
+	ActionPushTags: {
+		actionKey:      "<grv-push-tags>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Push all tags to remote",
+		keyBindings: map[ViewID][]string{
+			ViewRef: {"P"},
+		},
+	},
+	ActionDeleteRemoteTag: {
+		actionKey:      "<grv-delete-remote-tag>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Delete tag on remote",
+		keyBindings: map[ViewID][]string{
+			ViewRef: {"X"},
+		},
+	},
+	ActionVerifyTag: {
+		actionKey:      "<grv-verify-tag>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Verify tag signature",
+		keyBindings: map[ViewID][]string{
+			ViewRef: {"v"},
+		},
+	},
+	ActionShowCompareView: {
+		actionCategory: ActionCategoryGeneral,
+		description:    "Show the commits unique to each of two refs",
+	},
+	ActionCompareRef: {
+		actionKey:      "<grv-compare-ref>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Compare ref with HEAD",
+		keyBindings: map[ViewID][]string{
+			ViewRef: {"C"},
+		},
+	},
+	ActionCreateCommitInfoView: {
+		actionCategory: ActionCategoryGeneral,
+		description:    "Create a commit info view",
+	},
+	ActionShowCommitInfo: {
+		actionKey:      "<grv-show-commit-info>",
+		actionCategory: ActionCategoryViewSpecific,
+		description:    "Show branches and tags containing the commit",
+		keyBindings: map[ViewID][]string{
+			ViewCommit: {"i"},
+		},
+	},
 }
 
 var whitespaceBindingRegex = regexp.MustCompile(`^(.*\s+.*)+$`)
@@ -700,10 +985,16 @@ type ActionSplitViewArgs struct {
 	orientation ContainerOrientation
 }
 
+// ActionCountArgs contains the count entered before the key sequence bound to an action
+type ActionCountArgs struct {
+	count uint
+}
+
 // ActionPromptArgs contains arguments to an action that displays a prompt
 type ActionPromptArgs struct {
-	keys       string
-	terminated bool
+	keys          string
+	terminated    bool
+	inputRecorder func(input string, cancelled bool)
 }
 
 // ActionQuestionPromptArgs contains arguments to configure a question prompt
@@ -714,6 +1005,11 @@ type ActionQuestionPromptArgs struct {
 	onAnswer      func(string)
 }
 
+// ActionFuzzyFinderPromptArgs contains the listener notified as the fuzzy finder query is entered
+type ActionFuzzyFinderPromptArgs struct {
+	promptListener PromptListener
+}
+
 // ActionCreateContextMenuArgs contains arguments to create and configure a context menu
 type ActionCreateContextMenuArgs struct {
 	config        ContextMenuConfig
@@ -732,6 +1028,29 @@ type ActionCreateMessageBoxViewArgs struct {
 	config MessageBoxConfig
 }
 
+// ActionCreateCommitMessageViewArgs contains arguments to create and configure a commit message view
+type ActionCreateCommitMessageViewArgs struct {
+	config CommitMessageConfig
+}
+
+// ActionCreateCommitInfoViewArgs contains arguments to create and configure a commit info view
+type ActionCreateCommitInfoViewArgs struct {
+	commit        *Commit
+	viewDimension ViewDimension
+}
+
+// ActionInsertTextArgs contains the text to insert into a text input view
+type ActionInsertTextArgs struct {
+	text string
+}
+
+// ActionMapMacroArgs contains the key sequence to map to the macro recorded in a register
+type ActionMapMacroArgs struct {
+	view     string
+	from     string
+	register string
+}
+
 // ActionRunCommandArgs contains arguments to run a command and process
 // the status and output
 type ActionRunCommandArgs struct {
@@ -748,6 +1067,13 @@ type ActionRunCommandArgs struct {
 	onComplete     func(err error, exitStatus int) error
 }
 
+// ActionEvaluateCommandArgs contains a configuration command to evaluate
+// and a callback which receives any errors produced
+type ActionEvaluateCommandArgs struct {
+	command    string
+	onComplete func(errs []error)
+}
+
 // ActionCustomPromptArgs contains arguments to display a custom prompt
 // and handle the user input
 type ActionCustomPromptArgs struct {
@@ -793,43 +1119,124 @@ func newKeystringBinding(keystring string) Binding {
 // KeyBindings exposes key bindings that have been configured and allows new bindings to be set
 type KeyBindings interface {
 	Binding(viewHierarchy ViewHierarchy, keystring string) (binding Binding, isPrefix bool)
+	ViewBinding(viewID ViewID, keystring string) (binding Binding, isPrefix bool)
 	SetActionBinding(viewID ViewID, keystring string, actionType ActionType)
 	SetKeystringBinding(viewID ViewID, keystring, mappedKeystring string)
 	RemoveBinding(viewID ViewID, keystring string) (removed bool)
 	KeyStrings(actionType ActionType, viewID ViewID) (keystrings []BoundKeyString)
+	Completions(viewHierarchy ViewHierarchy, prefix string) (completions []KeyBindingCompletion)
+	SetKeymaps(keymaps []*Keymap)
 	GenerateHelpSections(Config) []*HelpSection
 }
 
+// KeyBindingCompletion is a bound key sequence which begins with a pending prefix
+type KeyBindingCompletion struct {
+	keystring string
+	binding   Binding
+}
+
 // BoundKeyString is a keystring bound to an action
 type BoundKeyString struct {
 	keystring          string
 	userDefinedBinding bool
 }
 
+type userKeyBinding struct {
+	viewID    ViewID
+	keystring string
+	binding   Binding
+	removed   bool
+}
+
 // KeyBindingManager manages key bindings in grv
 type KeyBindingManager struct {
 	bindings           map[ViewID]*pt.Trie
 	helpFormat         map[ActionType]map[ViewID][]BoundKeyString
 	userDefinedBinding bool
+	userKeyBindings    []userKeyBinding
 }
 
 // NewKeyBindingManager creates a new instance
 func NewKeyBindingManager() KeyBindings {
-	keyBindingManager := &KeyBindingManager{
-		bindings:   make(map[ViewID]*pt.Trie),
-		helpFormat: make(map[ActionType]map[ViewID][]BoundKeyString),
-	}
+	keyBindingManager := &KeyBindingManager{}
+	keyBindingManager.SetKeymaps(nil)
 
+	return keyBindingManager
+}
+
+// SetKeymaps replaces all bindings with the default bindings modified by each of the provided keymaps in turn.
+// Bindings set since the key binding manager was created are then applied again on top of the keymaps
+func (keyBindingManager *KeyBindingManager) SetKeymaps(keymaps []*Keymap) {
+	keyBindingManager.bindings = make(map[ViewID]*pt.Trie)
+	keyBindingManager.helpFormat = make(map[ActionType]map[ViewID][]BoundKeyString)
+
+	keyBindingManager.userDefinedBinding = false
 	keyBindingManager.setDefaultKeyBindings()
+
+	for _, keymap := range keymaps {
+		keyBindingManager.applyKeymap(keymap)
+	}
+
 	keyBindingManager.userDefinedBinding = true
 
-	return keyBindingManager
+	for _, userKeyBinding := range keyBindingManager.userKeyBindings {
+		switch {
+		case userKeyBinding.removed:
+			keyBindingManager.removeBinding(userKeyBinding.viewID, userKeyBinding.keystring)
+		case userKeyBinding.binding.bindingType == BtAction:
+			keyBindingManager.setActionBinding(userKeyBinding.viewID, userKeyBinding.keystring, userKeyBinding.binding.actionType)
+		default:
+			keyBindingManager.setKeystringBinding(userKeyBinding.viewID, userKeyBinding.keystring, userKeyBinding.binding.keystring)
+		}
+	}
+}
+
+func (keyBindingManager *KeyBindingManager) applyKeymap(keymap *Keymap) {
+	// All keys bound to an action are removed before any are rebound
+	// so that a key can be moved from one action to another
+	for actionType, viewKeystrings := range keymap.actionBindings {
+		for viewID := range viewKeystrings {
+			for _, boundKeystring := range keyBindingManager.KeyStrings(actionType, viewID) {
+				keyBindingManager.removeBinding(viewID, boundKeystring.keystring)
+			}
+		}
+	}
+
+	for actionType, viewKeystrings := range keymap.actionBindings {
+		for viewID, keystrings := range viewKeystrings {
+			for _, keystring := range keystrings {
+				keyBindingManager.removeBinding(viewID, keystring)
+				keyBindingManager.setActionBinding(viewID, keystring, actionType)
+			}
+		}
+	}
+
+	keyBindingManager.userDefinedBinding = !keymap.builtIn
+
+	for _, keyBinding := range keymap.keyBindings {
+		if keyBinding.mappedKeystring == "" {
+			keyBindingManager.removeBinding(keyBinding.viewID, keyBinding.keystring)
+		} else {
+			keyBindingManager.setKeystringBinding(keyBinding.viewID, keyBinding.keystring, keyBinding.mappedKeystring)
+		}
+	}
+
+	keyBindingManager.userDefinedBinding = false
 }
 
 // Binding returns the Binding bound to the provided key sequence for the view hierarchy provided
 // If no binding exists or the provided key sequence is a prefix to a binding then an action binding with action ActionNone is returned and a boolean indicating whether there is a prefix match
 func (keyBindingManager *KeyBindingManager) Binding(viewHierarchy ViewHierarchy, keystring string) (Binding, bool) {
-	viewHierarchy = append(viewHierarchy, ViewAll)
+	return keyBindingManager.binding(append(viewHierarchy, ViewAll), keystring)
+}
+
+// ViewBinding returns the Binding bound to the provided key sequence for the provided view only.
+// Unlike Binding, bindings defined for all views are not considered
+func (keyBindingManager *KeyBindingManager) ViewBinding(viewID ViewID, keystring string) (Binding, bool) {
+	return keyBindingManager.binding(ViewHierarchy{viewID}, keystring)
+}
+
+func (keyBindingManager *KeyBindingManager) binding(viewHierarchy ViewHierarchy, keystring string) (Binding, bool) {
 	isPrefix := false
 
 	for _, viewID := range viewHierarchy {
@@ -847,6 +1254,16 @@ func (keyBindingManager *KeyBindingManager) Binding(viewHierarchy ViewHierarchy,
 
 // SetActionBinding allows an action to be bound to the provided key sequence and view
 func (keyBindingManager *KeyBindingManager) SetActionBinding(viewID ViewID, keystring string, actionType ActionType) {
+	keyBindingManager.userKeyBindings = append(keyBindingManager.userKeyBindings, userKeyBinding{
+		viewID:    viewID,
+		keystring: keystring,
+		binding:   newActionBinding(actionType),
+	})
+
+	keyBindingManager.setActionBinding(viewID, keystring, actionType)
+}
+
+func (keyBindingManager *KeyBindingManager) setActionBinding(viewID ViewID, keystring string, actionType ActionType) {
 	viewBindings := keyBindingManager.getOrCreateViewBindings(viewID)
 	viewBindings.Set(pt.Prefix(keystring), newActionBinding(actionType))
 	keyBindingManager.updateHelpFormat(actionType, viewID, keystring)
@@ -854,7 +1271,17 @@ func (keyBindingManager *KeyBindingManager) SetActionBinding(viewID ViewID, keys
 
 // SetKeystringBinding allows a key sequence to be bound to the provided key sequence and view
 func (keyBindingManager *KeyBindingManager) SetKeystringBinding(viewID ViewID, keystring, mappedKeystring string) {
-	keyBindingManager.RemoveBinding(viewID, keystring)
+	keyBindingManager.userKeyBindings = append(keyBindingManager.userKeyBindings, userKeyBinding{
+		viewID:    viewID,
+		keystring: keystring,
+		binding:   newKeystringBinding(mappedKeystring),
+	})
+
+	keyBindingManager.setKeystringBinding(viewID, keystring, mappedKeystring)
+}
+
+func (keyBindingManager *KeyBindingManager) setKeystringBinding(viewID ViewID, keystring, mappedKeystring string) {
+	keyBindingManager.removeBinding(viewID, keystring)
 
 	viewBindings := keyBindingManager.getOrCreateViewBindings(viewID)
 	viewBindings.Set(pt.Prefix(keystring), newKeystringBinding(mappedKeystring))
@@ -899,6 +1326,16 @@ func (keyBindingManager *KeyBindingManager) updateHelpFormat(actionType ActionTy
 
 // RemoveBinding removes the binding for the provided keystring if it exists
 func (keyBindingManager *KeyBindingManager) RemoveBinding(viewID ViewID, keystring string) (removed bool) {
+	keyBindingManager.userKeyBindings = append(keyBindingManager.userKeyBindings, userKeyBinding{
+		viewID:    viewID,
+		keystring: keystring,
+		removed:   true,
+	})
+
+	return keyBindingManager.removeBinding(viewID, keystring)
+}
+
+func (keyBindingManager *KeyBindingManager) removeBinding(viewID ViewID, keystring string) (removed bool) {
 	binding, _ := keyBindingManager.Binding([]ViewID{viewID}, keystring)
 
 	if viewBindings, ok := keyBindingManager.bindings[viewID]; ok {
@@ -955,15 +1392,55 @@ func (keyBindingManager *KeyBindingManager) KeyStrings(actionType ActionType, vi
 	return
 }
 
+// Completions returns the bindings which complete the provided prefix for the view hierarchy provided
+// A binding in a view earlier in the hierarchy hides a binding for the same key sequence in a later view
+func (keyBindingManager *KeyBindingManager) Completions(viewHierarchy ViewHierarchy, prefix string) (completions []KeyBindingCompletion) {
+	viewHierarchy = append(viewHierarchy, ViewAll)
+	seenKeystrings := map[string]bool{}
+
+	for _, viewID := range viewHierarchy {
+		viewBindings, ok := keyBindingManager.bindings[viewID]
+		if !ok {
+			continue
+		}
+
+		viewBindings.VisitSubtree(pt.Prefix(prefix), func(key pt.Prefix, item pt.Item) error {
+			keystring := string(key)
+
+			if keystring == prefix || strings.HasPrefix(keystring, "<grv-") || seenKeystrings[keystring] {
+				return nil
+			}
+
+			seenKeystrings[keystring] = true
+			binding := item.(Binding)
+
+			if binding.bindingType == BtKeystring || binding.actionType != ActionNone {
+				completions = append(completions, KeyBindingCompletion{
+					keystring: keystring,
+					binding:   binding,
+				})
+			}
+
+			return nil
+		})
+	}
+
+	slice.Sort(completions, func(i, j int) bool {
+		return completions[i].keystring < completions[j].keystring
+	})
+
+	return
+}
+
 func (keyBindingManager *KeyBindingManager) setDefaultKeyBindings() {
 	for actionKey, actionType := range actionKeys {
-		keyBindingManager.SetActionBinding(ViewAll, actionKey, actionType)
+		keyBindingManager.setActionBinding(ViewAll, actionKey, actionType)
 	}
 
 	for actionType, actionDescriptor := range actionDescriptors {
 		for viewID, keys := range actionDescriptor.keyBindings {
 			for _, key := range keys {
-				keyBindingManager.SetActionBinding(viewID, key, actionType)
+				keyBindingManager.setActionBinding(viewID, key, actionType)
 			}
 		}
 	}
@@ -975,7 +1452,25 @@ func (keyBindingManager *KeyBindingManager) GenerateHelpSections(config Config)
 		{
 			title: HelpSectionText{text: "Key Bindings"},
 			description: []HelpSectionText{
-				{text: "The following tables contain default and user configured key bindings"},
+				{text: "The following tables contain default and user configured key bindings for the active keymap"},
+				{text: "The active keymap is selected using the keymap config variable, e.g. set keymap emacs"},
+				{},
+				{text: "Movement and search actions can be preceded by a count. For example, 5j moves down five lines and"},
+				{text: "3n moves to the third next search match. A count before G or gg moves to that line number, e.g. 10G"},
+				{},
+				{text: "When a key sequence is the start of one or more key bindings, such as g or z, a popup listing the"},
+				{text: "keys which complete it is displayed after key-hint-timeout milliseconds. Set it to 0 to disable the popup"},
+				{},
+				{text: "Keys can be recorded into a macro register by pressing Q followed by the register and then Q again to stop."},
+				{text: "The macro is replayed by pressing @ followed by the register. The macros command lists recorded macros"},
+				{},
+				{text: "The fuzzy finder lists refs, loaded commits, files at HEAD, commands and actions matching a query."},
+				{text: "Use <C-n> and <C-p> to move between matches and <Enter> to open a ref, commit or file, run an action"},
+				{text: "or start entering a command"},
+				{},
+				{text: "When builtin-commit-editor is set, commit messages are entered in a popup instead of an external editor."},
+				{text: "The summary length is shown as it is typed and body lines are wrapped at 72 characters."},
+				{text: "Use <C-s> to commit, <Escape> to cancel and <M-p> and <M-n> to recall earlier commit messages"},
 			},
 		},
 	}
@@ -1174,6 +1669,37 @@ func IsPromptAction(actionType ActionType) bool {
 	return false
 }
 
+// IsMacroAction returns true if the action records or replays a macro.
+// The register a macro action applies to is the key following its key sequence
+func IsMacroAction(actionType ActionType) bool {
+	if actionDescriptor, exists := actionDescriptors[actionType]; exists {
+		return actionDescriptor.macroAction
+	}
+
+	return false
+}
+
+// ActionAcceptsCount returns true if the action can be preceded by a count
+func ActionAcceptsCount(actionType ActionType) bool {
+	if actionDescriptor, exists := actionDescriptors[actionType]; exists {
+		return actionDescriptor.acceptsCount
+	}
+
+	return false
+}
+
+// GetActionCount returns the count provided with the action.
+// A count of 1 is returned if no count was provided
+func GetActionCount(action Action) (count uint, countProvided bool) {
+	for _, arg := range action.Args {
+		if countArgs, ok := arg.(ActionCountArgs); ok && countArgs.count > 0 {
+			return countArgs.count, true
+		}
+	}
+
+	return 1, false
+}
+
 // MouseEventAction maps a mouse event to an action
 func MouseEventAction(mouseEvent MouseEvent) (action Action, err error) {
 	switch mouseEvent.mouseEventType {
@@ -94,6 +94,19 @@ func TestViewAllBindingIsAvailableInAllViews(t *testing.T) {
 	checkBinding(binding, isPrefix, expectedBinding, false, t)
 }
 
+func TestViewBindingIgnoresViewAllBindings(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	keyBindings.SetActionBinding(ViewAll, "aaa", ActionFirstLine)
+	keyBindings.SetActionBinding(ViewCommitMessage, "bbb", ActionLastLine)
+
+	binding, isPrefix := keyBindings.ViewBinding(ViewCommitMessage, "aaa")
+	checkBinding(binding, isPrefix, newActionBinding(ActionNone), false, t)
+
+	binding, isPrefix = keyBindings.ViewBinding(ViewCommitMessage, "bbb")
+	checkBinding(binding, isPrefix, newActionBinding(ActionLastLine), false, t)
+}
+
 func TestKeyStringsReturnsExpectedBoundKeys(t *testing.T) {
 	keyBindings := NewKeyBindingManager()
 
@@ -159,6 +172,57 @@ func TestRemoveBindingDoesNotAffectSubTreeBindings(t *testing.T) {
 	checkBinding(binding, isPrefix, expectedBinding, false, t)
 }
 
+func TestCompletionsReturnsBindingsBeginningWithPrefixForViewHierarchy(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	keyBindings.SetActionBinding(ViewRef, "xa", ActionFirstLine)
+	keyBindings.SetActionBinding(ViewRef, "xb", ActionLastLine)
+	keyBindings.SetActionBinding(ViewAll, "xb", ActionNextLine)
+	keyBindings.SetKeystringBinding(ViewAll, "xc", "<grv-prev-line>")
+	keyBindings.SetActionBinding(ViewDiff, "xd", ActionNextPage)
+	keyBindings.SetActionBinding(ViewRef, "yx", ActionPrevPage)
+
+	completions := keyBindings.Completions(ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewRef}), "x")
+
+	expectedCompletions := []KeyBindingCompletion{
+		{keystring: "xa", binding: newActionBinding(ActionFirstLine)},
+		{keystring: "xb", binding: newActionBinding(ActionLastLine)},
+		{keystring: "xc", binding: newKeystringBinding("<grv-prev-line>")},
+	}
+
+	if !reflect.DeepEqual(expectedCompletions, completions) {
+		t.Errorf("Completions do not match expected value. Expected: %v, Actual: %v", expectedCompletions, completions)
+	}
+}
+
+func TestSetKeymapsAppliesKeymapsAndPreservesUserBindings(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewRef})
+
+	keyBindings.SetActionBinding(ViewAll, "Q", ActionLastLine)
+	keyBindings.SetKeymaps([]*Keymap{NewViKeymap(), NewEmacsKeymap()})
+
+	bindingTests := map[string]Binding{
+		"<C-n>": newActionBinding(ActionNextLine),
+		"<C-f>": newActionBinding(ActionScrollRight),
+		"j":     newActionBinding(ActionNone),
+		"Q":     newActionBinding(ActionLastLine),
+	}
+
+	for keystring, expectedBinding := range bindingTests {
+		binding, isPrefix := keyBindings.Binding(viewHierarchy, keystring)
+		checkBinding(binding, isPrefix, expectedBinding, false, t)
+	}
+
+	keyBindings.SetKeymaps([]*Keymap{NewViKeymap()})
+
+	binding, isPrefix := keyBindings.Binding(viewHierarchy, "j")
+	checkBinding(binding, isPrefix, newActionBinding(ActionNextLine), false, t)
+
+	binding, isPrefix = keyBindings.Binding(viewHierarchy, "Q")
+	checkBinding(binding, isPrefix, newActionBinding(ActionLastLine), false, t)
+}
+
 func TestIsPromptActionCorrectlyIdentifiesPromptActions(t *testing.T) {
 	tests := map[ActionType]bool{
 		ActionPrompt:              true,
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	khColumnSeparatorWidth = 4
+	khKeyDescriptionGap    = 2
+)
+
+type keyHint struct {
+	keys        string
+	description string
+}
+
+// KeyHintView displays the key sequences which complete a pending key binding prefix
+type KeyHintView struct {
+	prefix string
+	hints  []keyHint
+}
+
+// NewKeyHintView creates a new instance of the key hint view
+func NewKeyHintView() *KeyHintView {
+	return &KeyHintView{}
+}
+
+// Initialise does nothing
+func (keyHintView *KeyHintView) Initialise() (err error) {
+	return
+}
+
+// SetCompletions sets the pending prefix and the key bindings which complete it
+func (keyHintView *KeyHintView) SetCompletions(prefix string, completions []KeyBindingCompletion) {
+	keyHintView.prefix = prefix
+	keyHintView.hints = nil
+
+	for _, completion := range completions {
+		keyHintView.hints = append(keyHintView.hints, keyHint{
+			keys:        strings.TrimPrefix(completion.keystring, prefix),
+			description: keyBindingDescription(completion.binding),
+		})
+	}
+}
+
+// Clear removes all hints from the key hint view
+func (keyHintView *KeyHintView) Clear() {
+	keyHintView.prefix = ""
+	keyHintView.hints = nil
+}
+
+// HasHints returns true if there are hints to display
+func (keyHintView *KeyHintView) HasHints() bool {
+	return len(keyHintView.hints) > 0
+}
+
+// DisplayRowsRequired calculates the number of rows on the display required to display all hints in the available columns
+func (keyHintView *KeyHintView) DisplayRowsRequired(availableCols uint) uint {
+	if !keyHintView.HasHints() {
+		return 0
+	}
+
+	_, hintRows := keyHintView.layout(availableCols)
+
+	return hintRows + 2
+}
+
+func (keyHintView *KeyHintView) maxWidths() (keyWidth, descriptionWidth uint) {
+	for _, hint := range keyHintView.hints {
+		keyWidth = MaxUInt(keyWidth, uint(StringWidth(hint.keys)))
+		descriptionWidth = MaxUInt(descriptionWidth, uint(StringWidth(hint.description)))
+	}
+
+	return
+}
+
+func (keyHintView *KeyHintView) layout(availableCols uint) (hintColumns, hintRows uint) {
+	keyWidth, descriptionWidth := keyHintView.maxWidths()
+	hintWidth := keyWidth + khKeyDescriptionGap + descriptionWidth + khColumnSeparatorWidth
+	writableCols := availableCols - MinUInt(availableCols, 2)
+
+	hintColumns = MaxUInt(1, writableCols/hintWidth)
+	hintNum := uint(len(keyHintView.hints))
+	hintRows = (hintNum + hintColumns - 1) / hintColumns
+
+	return
+}
+
+// Render generates and writes the key hint view to the provided window
+func (keyHintView *KeyHintView) Render(win RenderWindow) (err error) {
+	hintColumns, hintRows := keyHintView.layout(win.Cols())
+	keyWidth, descriptionWidth := keyHintView.maxWidths()
+	hintNum := uint(len(keyHintView.hints))
+
+	win.ApplyStyle(CmpKeyHintViewDescription)
+
+	var lineBuilder *LineBuilder
+	for rowIndex := uint(0); rowIndex < hintRows && rowIndex+2 < win.Rows(); rowIndex++ {
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, 1); err != nil {
+			return
+		}
+
+		for columnIndex := uint(0); columnIndex < hintColumns; columnIndex++ {
+			hintIndex := columnIndex*hintRows + rowIndex
+			if hintIndex >= hintNum {
+				break
+			}
+
+			hint := keyHintView.hints[hintIndex]
+
+			lineBuilder.
+				AppendWithStyle(CmpKeyHintViewKey, " %v%v", hint.keys, keyHintPadding(keyWidth, hint.keys)).
+				AppendWithStyle(CmpKeyHintViewDescription, "%v%v", strings.Repeat(" ", khKeyDescriptionGap), hint.description)
+
+			if columnIndex+1 < hintColumns {
+				lineBuilder.Append("%v%v", keyHintPadding(descriptionWidth, hint.description), strings.Repeat(" ", khColumnSeparatorWidth-1))
+			}
+		}
+	}
+
+	win.DrawBorderWithStyle(CmpKeyHintViewDescription)
+
+	if err = win.SetTitle(CmpKeyHintViewTitle, "%v", keyHintView.prefix); err != nil {
+		return
+	}
+
+	bindingText := "Binding"
+	if hintNum > 1 {
+		bindingText += "s"
+	}
+
+	err = win.SetFooter(CmpKeyHintViewTitle, "%v %v", hintNum, bindingText)
+
+	return
+}
+
+func keyHintPadding(width uint, text string) string {
+	if textWidth := uint(StringWidth(text)); textWidth < width {
+		return strings.Repeat(" ", int(width-textWidth))
+	}
+
+	return ""
+}
+
+func keyBindingDescription(binding Binding) string {
+	actionType := binding.actionType
+
+	if binding.bindingType == BtKeystring {
+		mappedActionType, isActionKey := actionKeys[binding.keystring]
+		if !isActionKey {
+			return fmt.Sprintf("Maps to %v", binding.keystring)
+		}
+
+		actionType = mappedActionType
+	}
+
+	if actionDescriptor, exists := actionDescriptors[actionType]; exists {
+		return actionDescriptor.description
+	}
+
+	return ""
+}
+
+// HandleEvent does nothing
+func (keyHintView *KeyHintView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// HandleAction does nothing
+func (keyHintView *KeyHintView) HandleAction(Action) (err error) {
+	return
+}
+
+// OnStateChange does nothing
+func (keyHintView *KeyHintView) OnStateChange(ViewState) {
+
+}
+
+// ViewID returns the view ID of the key hint view
+func (keyHintView *KeyHintView) ViewID() ViewID {
+	return ViewKeyHint
+}
+
+// RenderHelpBar does nothing
+func (keyHintView *KeyHintView) RenderHelpBar(*LineBuilder) (err error) {
+	return
+}
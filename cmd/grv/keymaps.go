@@ -0,0 +1,170 @@
+package main
+
+const (
+	kmViKeymapName    = "vi"
+	kmEmacsKeymapName = "emacs"
+)
+
+type keymapKeyBinding struct {
+	viewID          ViewID
+	keystring       string
+	mappedKeystring string
+}
+
+// Keymap is a named set of changes to the key bindings of its base keymap.
+// Every keymap is ultimately based on the default vi-like key bindings
+type Keymap struct {
+	name           string
+	base           string
+	builtIn        bool
+	actionBindings map[ActionType]map[ViewID][]string
+	keyBindings    []keymapKeyBinding
+}
+
+// NewKeymap creates a new user defined keymap based on the vi keymap
+func NewKeymap(name string) *Keymap {
+	return &Keymap{
+		name: name,
+		base: kmViKeymapName,
+	}
+}
+
+// SetKeystringBinding binds a key sequence to another key sequence in the keymap
+func (keymap *Keymap) SetKeystringBinding(viewID ViewID, keystring, mappedKeystring string) {
+	keymap.keyBindings = append(keymap.keyBindings, keymapKeyBinding{
+		viewID:          viewID,
+		keystring:       keystring,
+		mappedKeystring: mappedKeystring,
+	})
+}
+
+// RemoveBinding removes the binding for a key sequence in the keymap
+func (keymap *Keymap) RemoveBinding(viewID ViewID, keystring string) {
+	keymap.SetKeystringBinding(viewID, keystring, "")
+}
+
+// NewViKeymap creates the vi keymap. It contains the default key bindings of grv
+func NewViKeymap() *Keymap {
+	return &Keymap{
+		name:    kmViKeymapName,
+		builtIn: true,
+	}
+}
+
+// NewEmacsKeymap creates the emacs keymap
+// It replaces the vi movement, scrolling and window key bindings with emacs style equivalents
+func NewEmacsKeymap() *Keymap {
+	return &Keymap{
+		name:    kmEmacsKeymapName,
+		base:    kmViKeymapName,
+		builtIn: true,
+		actionBindings: map[ActionType]map[ViewID][]string{
+			ActionPrompt: {
+				ViewAll: {PromptText, "<M-x>"},
+			},
+			ActionSearchPrompt: {
+				ViewAll: {SearchPromptText, "<C-s>"},
+			},
+			ActionReverseSearchPrompt: {
+				ViewAll: {ReverseSearchPromptText, "<C-r>"},
+			},
+			ActionRemoveFilter: {
+				ViewCommit: {"<C-x>r"},
+				ViewRef:    {"<C-x>r"},
+			},
+			ActionNextLine: {
+				ViewAll: {"<Down>", "<C-n>"},
+			},
+			ActionPrevLine: {
+				ViewAll: {"<Up>", "<C-p>"},
+			},
+			ActionNextPage: {
+				ViewAll: {"<PageDown>", "<C-v>"},
+			},
+			ActionPrevPage: {
+				ViewAll: {"<PageUp>", "<M-v>"},
+			},
+			ActionNextHalfPage: {
+				ViewAll: {},
+			},
+			ActionPrevHalfPage: {
+				ViewAll: {},
+			},
+			ActionScrollRight: {
+				ViewAll: {"<Right>", "<C-f>"},
+			},
+			ActionScrollLeft: {
+				ViewAll: {"<Left>", "<C-b>"},
+			},
+			ActionFirstLine: {
+				ViewAll: {"<Home>"},
+			},
+			ActionLastLine: {
+				ViewAll: {"<End>"},
+			},
+			ActionCenterView: {
+				ViewAll: {"<C-l>"},
+			},
+			ActionScrollCursorTop: {
+				ViewAll: {},
+			},
+			ActionScrollCursorBottom: {
+				ViewAll: {},
+			},
+			ActionCursorTopView: {
+				ViewAll: {},
+			},
+			ActionCursorMiddleView: {
+				ViewAll: {"<M-r>"},
+			},
+			ActionCursorBottomView: {
+				ViewAll: {},
+			},
+			ActionNextView: {
+				ViewAll: {"<C-x>o", "<Tab>"},
+			},
+			ActionPrevView: {
+				ViewAll: {"<C-x>O", "<S-Tab>"},
+			},
+			ActionFullScreenView: {
+				ViewAll: {"<C-x>1"},
+			},
+			ActionToggleViewLayout: {
+				ViewAll: {"<C-x>3"},
+			},
+			ActionNextTab: {
+				ViewAll: {"<C-x>to"},
+			},
+			ActionPrevTab: {
+				ViewAll: {"<C-x>tO"},
+			},
+			ActionRemoveView: {
+				ViewAll: {"q", "<C-x>0"},
+			},
+			ActionNextButton: {
+				ViewMessageBox: {"<Right>", "<C-f>", "<Tab>"},
+			},
+			ActionPrevButton: {
+				ViewMessageBox: {"<Left>", "<C-b>", "<S-Tab>"},
+			},
+			ActionFuzzyFinderPrompt: {
+				ViewAll: {"<C-x>b"},
+			},
+			ActionCursorLeft: {
+				ViewCommitMessage: {"<Left>", "<C-b>"},
+			},
+			ActionCursorRight: {
+				ViewCommitMessage: {"<Right>", "<C-f>"},
+			},
+			ActionCursorUp: {
+				ViewCommitMessage: {"<Up>", "<C-p>"},
+			},
+			ActionCursorDown: {
+				ViewCommitMessage: {"<Down>", "<C-n>"},
+			},
+			ActionSubmitCommitMessage: {
+				ViewCommitMessage: {"<C-c><C-c>"},
+			},
+		},
+	}
+}
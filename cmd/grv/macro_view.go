@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// MacroView displays the macros recorded in each register
+type MacroView struct {
+	*AbstractWindowView
+	macros            MacroProvider
+	activeViewPos     ViewPos
+	lastViewDimension ViewDimension
+	lock              sync.Mutex
+}
+
+// NewMacroView creates a new instance
+func NewMacroView(macros MacroProvider, channels Channels, config Config, variables GRVVariableSetter) *MacroView {
+	macroView := &MacroView{
+		macros:        macros,
+		activeViewPos: NewViewPosition(),
+	}
+
+	macroView.AbstractWindowView = NewAbstractWindowView(macroView, channels, config, variables, &macroView.lock, "macro")
+
+	return macroView
+}
+
+// Render generates and writes the macro view to the provided window
+func (macroView *MacroView) Render(win RenderWindow) (err error) {
+	macroView.lock.Lock()
+	defer macroView.lock.Unlock()
+
+	macroView.lastViewDimension = win.ViewDimensions()
+
+	macros := macroView.macros.Macros()
+	viewRows := uint(len(macros))
+	rows := win.Rows() - 2
+
+	viewPos := macroView.viewPos()
+	viewPos.DetermineViewStartRow(rows, viewRows)
+	viewRowIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	var lineBuilder *LineBuilder
+	for rowIndex := uint(0); rowIndex < rows && viewRowIndex < viewRows; rowIndex++ {
+		macro := macros[viewRowIndex]
+
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		lineBuilder.Append(" ").
+			AppendWithStyle(CmpMacroViewRegister, "@%v", macro.Register).
+			Append("  ").
+			AppendWithStyle(CmpMacroViewKeys, "%v", macro.Keys)
+
+		viewRowIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, macroView.viewState); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpMacroViewTitle, "Macros"); err != nil {
+		return
+	}
+
+	if viewRows == 0 {
+		err = win.SetFooter(CmpMacroViewFooter, "No macros recorded")
+	} else {
+		err = win.SetFooter(CmpMacroViewFooter, "Macro %v of %v", viewPos.ActiveRowIndex()+1, viewRows)
+	}
+
+	if err != nil {
+		return
+	}
+
+	if searchActive, searchPattern, lastSearchFoundMatch := macroView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// ViewID returns the ViewID for the macro view
+func (macroView *MacroView) ViewID() ViewID {
+	return ViewMacro
+}
+
+func (macroView *MacroView) line(lineIndex uint) (line string) {
+	macros := macroView.macros.Macros()
+
+	if lineIndex < uint(len(macros)) {
+		macro := macros[lineIndex]
+		line = fmt.Sprintf("@%v  %v", macro.Register, macro.Keys)
+	}
+
+	return
+}
+
+func (macroView *MacroView) viewPos() ViewPos {
+	return macroView.activeViewPos
+}
+
+func (macroView *MacroView) rows() uint {
+	return uint(len(macroView.macros.Macros()))
+}
+
+func (macroView *MacroView) viewDimension() ViewDimension {
+	return macroView.lastViewDimension
+}
+
+func (macroView *MacroView) onRowSelected(rowIndex uint) (err error) {
+	macroView.channels.UpdateDisplay()
+	return
+}
+
+// HandleAction checks if the macro view supports this action and if it does executes it
+func (macroView *MacroView) HandleAction(action Action) (err error) {
+	macroView.lock.Lock()
+	defer macroView.lock.Unlock()
+
+	var handled bool
+	if handled, err = macroView.AbstractWindowView.HandleAction(action); handled {
+		log.Debugf("Action handled by AbstractWindowView")
+	} else {
+		log.Debugf("Action not handled")
+	}
+
+	return
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+	slice "github.com/bradfitz/slice"
+)
+
+const (
+	mcMacroFileName     = "macros.json"
+	mcVersion           = 1
+	mcLastMacroRegister = "@"
+	mcMaxReplays        = 1000
+)
+
+var macroRegisterRegex = regexp.MustCompile(`^[a-zA-Z0-9]$`)
+
+// Macro is a key sequence recorded into a named register
+type Macro struct {
+	Register string `json:"register"`
+	Keys     string `json:"keys"`
+}
+
+type macroFile struct {
+	Version int     `json:"version"`
+	Macros  []Macro `json:"macros"`
+}
+
+// MacroProvider provides access to recorded macros
+type MacroProvider interface {
+	Macros() []Macro
+}
+
+// MacroRecorder records key sequences into named registers.
+// Recorded macros are persisted in the grv config directory
+type MacroRecorder struct {
+	macros            map[string]string
+	recordingRegister string
+	recordedKeys      []string
+	lastRegister      string
+	filePath          string
+	lock              sync.Mutex
+}
+
+// NewMacroRecorder creates a new instance with no recorded macros
+func NewMacroRecorder() *MacroRecorder {
+	return &MacroRecorder{
+		macros: make(map[string]string),
+	}
+}
+
+// Load reads the macros stored in the provided config directory.
+// Macros recorded after loading are saved to the same directory
+func (macroRecorder *MacroRecorder) Load(configDir string) (err error) {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	macroRecorder.filePath = filepath.Join(configDir, mcMacroFileName)
+
+	data, err := ioutil.ReadFile(macroRecorder.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Infof("No macros found at %v", macroRecorder.filePath)
+			err = nil
+		}
+
+		return
+	}
+
+	storedMacros := &macroFile{}
+	if err = json.Unmarshal(data, storedMacros); err != nil {
+		return fmt.Errorf("Invalid macro file %v: %v", macroRecorder.filePath, err)
+	}
+
+	if storedMacros.Version != mcVersion {
+		log.Infof("Ignoring macros with unsupported version %v", storedMacros.Version)
+		return
+	}
+
+	for _, macro := range storedMacros.Macros {
+		if macroRegisterRegex.MatchString(macro.Register) {
+			macroRecorder.macros[macro.Register] = macro.Keys
+		}
+	}
+
+	log.Infof("Loaded %v macro(s) from %v", len(macroRecorder.macros), macroRecorder.filePath)
+
+	return
+}
+
+func (macroRecorder *MacroRecorder) save() (err error) {
+	if macroRecorder.filePath == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(&macroFile{
+		Version: mcVersion,
+		Macros:  macroRecorder.macroList(),
+	}, "", "\t")
+	if err != nil {
+		return
+	}
+
+	tempFilePath := macroRecorder.filePath + ".tmp"
+	if err = ioutil.WriteFile(tempFilePath, data, 0644); err != nil {
+		return
+	}
+
+	if err = os.Rename(tempFilePath, macroRecorder.filePath); err != nil {
+		return
+	}
+
+	log.Infof("Saved macros to %v", macroRecorder.filePath)
+
+	return
+}
+
+// StartRecording begins recording input into the provided register
+func (macroRecorder *MacroRecorder) StartRecording(register string) (err error) {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	if !macroRegisterRegex.MatchString(register) {
+		return fmt.Errorf("Invalid macro register: %v", register)
+	}
+
+	macroRecorder.recordingRegister = register
+	macroRecorder.recordedKeys = nil
+
+	log.Infof("Recording macro into register %v", register)
+
+	return
+}
+
+// IsRecording returns true if input is currently being recorded
+func (macroRecorder *MacroRecorder) IsRecording() bool {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	return macroRecorder.recordingRegister != ""
+}
+
+// Record adds the provided input to the macro being recorded
+func (macroRecorder *MacroRecorder) Record(input string) {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	if macroRecorder.recordingRegister != "" {
+		macroRecorder.recordedKeys = append(macroRecorder.recordedKeys, TokeniseKeys(input)...)
+	}
+}
+
+// RecordPrompt replaces the last promptKeyNum + initialKeyNum recorded keys, which opened a prompt
+// and provided its initial input, with the prompt keys followed by the input submitted at the prompt.
+// A cancelled prompt is removed from the macro along with the keys which opened it
+func (macroRecorder *MacroRecorder) RecordPrompt(promptKeyNum, initialKeyNum int, input string, cancelled bool) {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	if macroRecorder.recordingRegister == "" {
+		return
+	}
+
+	keys := macroRecorder.recordedKeys
+	initialKeyIndex := MaxInt(len(keys)-initialKeyNum, 0)
+
+	if cancelled {
+		keys = keys[:MaxInt(initialKeyIndex-promptKeyNum, 0)]
+	} else {
+		keys = append(keys[:initialKeyIndex], TokeniseKeys(input)...)
+		keys = append(keys, "<Enter>")
+	}
+
+	macroRecorder.recordedKeys = keys
+}
+
+// StopRecording stores the recorded input in the register recording was started with.
+// The last discardedKeyNum recorded keys are not included in the macro
+func (macroRecorder *MacroRecorder) StopRecording(discardedKeyNum int) (register string, err error) {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	if macroRecorder.recordingRegister == "" {
+		err = fmt.Errorf("No macro is being recorded")
+		return
+	}
+
+	keys := macroRecorder.recordedKeys
+	keys = keys[:len(keys)-MinInt(discardedKeyNum, len(keys))]
+
+	register = macroRecorder.recordingRegister
+	macroRecorder.macros[register] = strings.Join(keys, "")
+	macroRecorder.recordingRegister = ""
+	macroRecorder.recordedKeys = nil
+
+	log.Infof("Recorded macro into register %v: %v", register, macroRecorder.macros[register])
+
+	err = macroRecorder.save()
+
+	return
+}
+
+// Macro returns the keys recorded in the provided register
+func (macroRecorder *MacroRecorder) Macro(register string) (keys string, err error) {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	return macroRecorder.macro(register)
+}
+
+// Replay returns the keys recorded in the provided register and records the register as the last replayed.
+// The register @ refers to the last replayed macro
+func (macroRecorder *MacroRecorder) Replay(register string) (keys string, err error) {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	if register == mcLastMacroRegister {
+		if macroRecorder.lastRegister == "" {
+			err = fmt.Errorf("No previously replayed macro")
+			return
+		}
+
+		register = macroRecorder.lastRegister
+	}
+
+	if keys, err = macroRecorder.macro(register); err == nil {
+		macroRecorder.lastRegister = register
+	}
+
+	return
+}
+
+func (macroRecorder *MacroRecorder) macro(register string) (keys string, err error) {
+	keys, exists := macroRecorder.macros[register]
+	if !exists {
+		err = fmt.Errorf("No macro recorded in register %v", register)
+	}
+
+	return
+}
+
+// Macros returns all recorded macros ordered by register
+func (macroRecorder *MacroRecorder) Macros() []Macro {
+	macroRecorder.lock.Lock()
+	defer macroRecorder.lock.Unlock()
+
+	return macroRecorder.macroList()
+}
+
+func (macroRecorder *MacroRecorder) macroList() (macros []Macro) {
+	for register, keys := range macroRecorder.macros {
+		macros = append(macros, Macro{
+			Register: register,
+			Keys:     keys,
+		})
+	}
+
+	slice.Sort(macros, func(i, j int) bool {
+		return macros[i].Register < macros[j].Register
+	})
+
+	return
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestRecordedKeysAreStoredInRegisterExcludingDiscardedKeys(t *testing.T) {
+	macroRecorder := NewMacroRecorder()
+
+	if err := macroRecorder.StartRecording("a"); err != nil {
+		t.Fatalf("Unable to start recording: %v", err)
+	}
+
+	macroRecorder.Record("j")
+	macroRecorder.Record("<C-w>o")
+	macroRecorder.Record("Q")
+
+	register, err := macroRecorder.StopRecording(1)
+	if err != nil {
+		t.Fatalf("Unable to stop recording: %v", err)
+	}
+
+	if register != "a" {
+		t.Errorf("Register does not match expected value. Expected: a, Actual: %v", register)
+	}
+
+	if macroRecorder.IsRecording() {
+		t.Errorf("Expected recording to have stopped")
+	}
+
+	keys, err := macroRecorder.Macro("a")
+	if err != nil {
+		t.Fatalf("Unable to retrieve macro: %v", err)
+	}
+
+	if expectedKeys := "j<C-w>o"; keys != expectedKeys {
+		t.Errorf("Macro keys do not match expected value. Expected: %v, Actual: %v", expectedKeys, keys)
+	}
+}
+
+func TestInputIsOnlyRecordedWhileRecording(t *testing.T) {
+	macroRecorder := NewMacroRecorder()
+
+	macroRecorder.Record("k")
+	macroRecorder.StartRecording("b")
+	macroRecorder.Record("G")
+	macroRecorder.StopRecording(0)
+	macroRecorder.Record("j")
+
+	if keys, _ := macroRecorder.Macro("b"); keys != "G" {
+		t.Errorf("Macro keys do not match expected value. Expected: G, Actual: %v", keys)
+	}
+}
+
+func TestRecordingIntoAnInvalidRegisterReturnsAnError(t *testing.T) {
+	macroRecorder := NewMacroRecorder()
+
+	for _, register := range []string{"<Enter>", "!", ""} {
+		if err := macroRecorder.StartRecording(register); err == nil {
+			t.Errorf("Expected error when recording into register %q", register)
+		}
+	}
+
+	if macroRecorder.IsRecording() {
+		t.Errorf("Expected no recording to have started")
+	}
+}
+
+func TestLastRegisterReplaysPreviouslyReplayedMacro(t *testing.T) {
+	macroRecorder := NewMacroRecorder()
+
+	if _, err := macroRecorder.Replay(mcLastMacroRegister); err == nil {
+		t.Errorf("Expected error when no macro has been replayed")
+	}
+
+	macroRecorder.StartRecording("a")
+	macroRecorder.Record("jj")
+	macroRecorder.StopRecording(0)
+
+	macroRecorder.StartRecording("b")
+	macroRecorder.Record("kk")
+	macroRecorder.StopRecording(0)
+
+	if _, err := macroRecorder.Replay("b"); err != nil {
+		t.Fatalf("Unable to replay macro: %v", err)
+	}
+
+	macroRecorder.Macro("a")
+
+	if keys, _ := macroRecorder.Replay(mcLastMacroRegister); keys != "kk" {
+		t.Errorf("Macro keys do not match expected value. Expected: kk, Actual: %v", keys)
+	}
+}
+
+func TestMacrosAreRestoredAfterBeingSaved(t *testing.T) {
+	configDir, err := ioutil.TempDir("", "grv-macros")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(configDir)
+
+	macroRecorder := NewMacroRecorder()
+	if err = macroRecorder.Load(configDir); err != nil {
+		t.Fatalf("Unable to load macros: %v", err)
+	}
+
+	macroRecorder.StartRecording("z")
+	macroRecorder.Record("<grv-next-tab>gg")
+	macroRecorder.StopRecording(0)
+
+	macroRecorder.StartRecording("a")
+	macroRecorder.Record("/master<Enter>")
+	if _, err = macroRecorder.StopRecording(0); err != nil {
+		t.Fatalf("Unable to save macros: %v", err)
+	}
+
+	restoredMacroRecorder := NewMacroRecorder()
+	if err = restoredMacroRecorder.Load(configDir); err != nil {
+		t.Fatalf("Unable to load macros: %v", err)
+	}
+
+	expectedMacros := []Macro{
+		{Register: "a", Keys: "/master<Enter>"},
+		{Register: "z", Keys: "<grv-next-tab>gg"},
+	}
+
+	if macros := restoredMacroRecorder.Macros(); !reflect.DeepEqual(expectedMacros, macros) {
+		t.Errorf("Restored macros do not match expected value. Expected: %v, Actual: %v", expectedMacros, macros)
+	}
+}
+
+func TestMacroContainingPromptReplaysSubmittedPromptInput(t *testing.T) {
+	macroRecorder := NewMacroRecorder()
+
+	macroRecorder.StartRecording("a")
+	macroRecorder.Record("j")
+	macroRecorder.Record(":se")
+	macroRecorder.RecordPrompt(1, 2, "set theme solarized", false)
+	macroRecorder.Record("/ab")
+	macroRecorder.RecordPrompt(1, 2, "abc", true)
+	macroRecorder.Record("k")
+	macroRecorder.StopRecording(0)
+
+	keys, err := macroRecorder.Replay("a")
+	if err != nil {
+		t.Fatalf("Unable to replay macro: %v", err)
+	}
+
+	if expectedKeys := "j:set theme solarized<Enter>k"; keys != expectedKeys {
+		t.Fatalf("Macro keys do not match expected value. Expected: %v, Actual: %v", expectedKeys, keys)
+	}
+
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "j").Return(newActionBinding(ActionNextLine), false)
+	keyBindings.On("Binding", viewHierarchy, ":").Return(newActionBinding(ActionPrompt), false)
+	keyBindings.On("Binding", viewHierarchy, "k").Return(newActionBinding(ActionPrevLine), false)
+
+	inputBuffer.Append(keys)
+
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine}, "j", action, keyString, t)
+
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionPrompt}, ":", action, keyString, t)
+
+	promptKeys, enterFound := inputBuffer.DiscardTo("<Enter>")
+	if expectedPromptKeys := "set theme solarized<Enter>"; !enterFound || promptKeys != expectedPromptKeys {
+		t.Errorf("Prompt input does not match expected value. Expected: %v, Actual: %v", expectedPromptKeys, promptKeys)
+	}
+
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionPrevLine}, "k", action, keyString, t)
+}
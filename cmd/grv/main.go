@@ -34,6 +34,12 @@ type grvArgs struct {
 	logFilePath      string
 	version          bool
 	readOnly         bool
+	noSessionRestore bool
+	batch            bool
+	commands         string
+	batchRows        uint
+	batchCols        uint
+	controlSocket    string
 }
 
 func main() {
@@ -56,10 +62,22 @@ func main() {
 	InitialiseLogging(args.logLevel, args.logFilePath)
 	log.Info(getVersion())
 
+	if args.batch {
+		os.Exit(runBatch(args))
+	}
+
 	log.Debugf("Creating GRV instance")
 	grv := NewGRV(args.readOnly)
 
-	if err := grv.Initialise(args.repoFilePath, args.workTreeFilePath); err != nil {
+	if args.controlSocket != "" {
+		if err := grv.ListenOnControlSocket(args.controlSocket); err != nil {
+			fmt.Fprintf(os.Stderr, "FATAL: %v\n", err)
+			grv.Free()
+			log.Fatal(err)
+		}
+	}
+
+	if err := grv.Initialise(args.repoFilePath, args.workTreeFilePath, !args.noSessionRestore); err != nil {
 		fmt.Fprintf(os.Stderr, "FATAL: Unable to initialise grv: %v\n", err)
 		grv.Free()
 		log.Fatal(err)
@@ -72,6 +90,34 @@ func main() {
 	log.Info("Exiting normally")
 }
 
+func runBatch(args *grvArgs) (exitCode int) {
+	log.Debugf("Creating batch GRV instance")
+	grv := NewBatchGRV(args.readOnly, os.Stdout, ViewDimension{
+		rows: args.batchRows,
+		cols: args.batchCols,
+	})
+	defer grv.Free()
+
+	if err := grv.Initialise(args.repoFilePath, args.workTreeFilePath, false); err != nil {
+		fmt.Fprintf(os.Stderr, "FATAL: Unable to initialise grv: %v\n", err)
+		log.Error(err)
+		return 1
+	}
+
+	errs := grv.RunBatch(SplitBatchCommands(args.commands))
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+	}
+
+	if len(errs) > 0 {
+		exitCode = 1
+	}
+
+	log.Info("Batch mode finished")
+
+	return
+}
+
 func parseArgs() *grvArgs {
 	repoFilePathPtr := flag.String("repoFilePath", mnRepoFilePathDefault, "Repository file path")
 	workTreeFilePathPtr := flag.String("workTreeFilePath", mnWorkTreeFilePathDefault, "Work tree file path")
@@ -79,6 +125,12 @@ func parseArgs() *grvArgs {
 	logFilePathPtr := flag.String("logFile", mnLogFilePathDefault, "Log file path")
 	versionPtr := flag.Bool("version", false, "Print version")
 	readOnlyPtr := flag.Bool("readOnly", false, "Run grv in read only mode")
+	noSessionRestorePtr := flag.Bool("noSessionRestore", false, "Don't restore tabs and views from the previous session")
+	batchPtr := flag.Bool("batch", false, "Run commands without a terminal and print the rendered views to stdout")
+	commandsPtr := flag.String("c", "", "Commands to run in batch mode, separated by semicolons or newlines")
+	batchRowsPtr := flag.Uint("batchRows", BuiDefaultRows, "Number of rows rendered in batch mode")
+	batchColsPtr := flag.Uint("batchCols", BuiDefaultCols, "Number of columns rendered in batch mode")
+	controlSocketPtr := flag.String("controlSocket", "", "Listen for commands on a unix domain socket at the provided path")
 
 	flag.Parse()
 
@@ -89,6 +141,12 @@ func parseArgs() *grvArgs {
 		logFilePath:      *logFilePathPtr,
 		version:          *versionPtr,
 		readOnly:         *readOnlyPtr,
+		noSessionRestore: *noSessionRestorePtr,
+		batch:            *batchPtr,
+		commands:         *commandsPtr,
+		batchRows:        *batchRowsPtr,
+		batchCols:        *batchColsPtr,
+		controlSocket:    *controlSocketPtr,
 	}
 }
 
@@ -27,10 +27,12 @@ type MessageBoxButton string
 
 // The set of buttons available
 const (
-	ButtonCancel MessageBoxButton = "Cancel"
-	ButtonOK     MessageBoxButton = "OK"
-	ButtonYes    MessageBoxButton = "Yes"
-	ButtonNo     MessageBoxButton = "No"
+	ButtonCancel       MessageBoxButton = "Cancel"
+	ButtonOK           MessageBoxButton = "OK"
+	ButtonYes          MessageBoxButton = "Yes"
+	ButtonNo           MessageBoxButton = "No"
+	ButtonEdit         MessageBoxButton = "Edit"
+	ButtonCommitAnyway MessageBoxButton = "Commit Anyway"
 )
 
 // MessageBoxConfig is the configuration for the MessageBoxView
@@ -282,7 +284,7 @@ func (messageBoxView *MessageBoxView) selectButton(button MessageBoxButton) {
 	messageBoxView.channels.DoAction(Action{ActionType: ActionRemoveView})
 
 	if messageBoxView.messageBoxConfig.OnSelect != nil {
-		go messageBoxView.messageBoxConfig.OnSelect(button)
+		pendingBackgroundWork.Go(func() { messageBoxView.messageBoxConfig.OnSelect(button) })
 	}
 }
 
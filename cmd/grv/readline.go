@@ -16,6 +16,15 @@ package main
 // extern int grvReadlineStartUpHook(void);
 // extern int grvReadlineEventHook(void);
 // extern int grvReadlineEscapeHandler(int, int);
+// extern char *grvReadlineCompletionGenerator(char *, int);
+// extern void grvReadlineDisplayCompletionMatches(char **, int, int);
+// extern int grvReadlineNextEntryHandler(int, int);
+// extern int grvReadlinePrevEntryHandler(int, int);
+//
+// static char **grv_complete(const char *text, int start, int end) {
+//	rl_attempted_completion_over = 1;
+//	return rl_completion_matches(text, (rl_compentry_func_t *)grvReadlineCompletionGenerator);
+// }
 //
 // static void grv_init_readline(void) {
 // 	rl_redisplay_function = grvReadlineUpdateDisplay;
@@ -26,7 +35,13 @@ package main
 //#if RL_READLINE_VERSION >= 0x0603
 //	rl_change_environment = 0;
 //#endif
-//	rl_bind_key('\t', NULL);
+//	rl_attempted_completion_function = grv_complete;
+//	rl_completion_display_matches_hook = (rl_compdisp_func_t *)grvReadlineDisplayCompletionMatches;
+//	rl_completer_word_break_characters = (char *)" \t\n";
+//	rl_variable_bind("bell-style", "none");
+//	rl_bind_key('\t', rl_complete);
+//	rl_bind_key(CTRL('n'), grvReadlineNextEntryHandler);
+//	rl_bind_key(CTRL('p'), grvReadlinePrevEntryHandler);
 //	rl_bind_key(0x1B, grvReadlineEscapeHandler);
 // 	rl_set_keyboard_input_timeout(250000);
 //
@@ -72,11 +87,19 @@ var historyFilePrompts = map[string]string{
 	TagNamePromptText:       rlTagNameHistoryFile,
 }
 
+// PromptListener is notified as the prompt input is edited and when the
+// user requests to move to the next or previous entry of a list associated with the prompt
+type PromptListener interface {
+	OnPromptInputChanged(input string)
+	OnPromptEntryMoved(offset int)
+}
+
 // PromptArgs contains arguments to configure the display of a prompt
 type PromptArgs struct {
 	Prompt            string
 	InitialBufferText string
 	NumCharsToRead    int
+	Listener          PromptListener
 }
 
 var readLine ReadLine
@@ -89,16 +112,23 @@ type ReadLine struct {
 	promptInput       string
 	promptPoint       int
 	active            bool
+	cancelled         bool
 	lastPromptText    string
 	initialBufferText string
+	completer         CommandCompleter
+	completions       []string
+	completionMatches []string
+	promptListener    PromptListener
 	lock              sync.Mutex
 }
 
-// InitReadLine initialises the readline library
-func InitReadLine(channels Channels, config Config) {
+// InitReadLine initialises the readline library.
+// Input entered at the command prompt is completed using the provided completer
+func InitReadLine(channels Channels, config Config, completer CommandCompleter) {
 	readLine = ReadLine{
-		channels: channels,
-		config:   config,
+		channels:  channels,
+		config:    config,
+		completer: completer,
 	}
 
 	C.grv_init_readline()
@@ -173,12 +203,25 @@ func Prompt(promptArgs *PromptArgs) string {
 		defer readLineSetNumCharsToRead(0)
 	}
 
+	if promptArgs.Listener != nil {
+		readLineSetPromptListener(promptArgs.Listener)
+		defer readLineSetPromptListener(nil)
+	}
+
+	if promptArgs.Prompt == PromptText {
+		readLineRefreshCompleter()
+	}
+
 	readLineSetupPromptHistory(promptArgs.Prompt)
 	readLineSetActive(true)
 	cPrompt := C.CString(promptArgs.Prompt)
 	cInput := C.readline(cPrompt)
 	readLineSetActive(false)
 
+	if cInput == nil {
+		readLineSetCancelled()
+	}
+
 	C.free(unsafe.Pointer(cPrompt))
 	readLineAddPromptHistory(promptArgs.Prompt, cInput)
 	input := C.GoString(cInput)
@@ -195,6 +238,15 @@ func PromptState() (string, string, int) {
 	return readLine.promptText, readLine.promptInput, readLine.promptPoint
 }
 
+// PromptCompletionMatches returns the possible completions of the current prompt input
+// when the input cannot be completed unambiguously
+func PromptCompletionMatches() []string {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	return readLine.completionMatches
+}
+
 // ReadLineActive returns true if the readline prompt is currently displayed
 func ReadLineActive() bool {
 	readLine.lock.Lock()
@@ -203,6 +255,15 @@ func ReadLineActive() bool {
 	return readLine.active
 }
 
+// PromptCancelled returns true if the last prompt was cancelled
+// rather than its input being submitted
+func PromptCancelled() bool {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	return readLine.cancelled
+}
+
 // CancelReadline cancels the current readline invocation
 func CancelReadline() {
 	readLine.lock.Lock()
@@ -211,6 +272,7 @@ func CancelReadline() {
 	if readLine.active {
 		C.rl_delete_text(0, C.rl_end)
 		C.rl_done = 1
+		readLine.cancelled = true
 	}
 }
 
@@ -218,7 +280,19 @@ func readLineSetActive(active bool) {
 	readLine.lock.Lock()
 	defer readLine.lock.Unlock()
 
+	if active {
+		readLine.cancelled = false
+	}
+
 	readLine.active = active
+	readLine.completionMatches = nil
+}
+
+func readLineSetCancelled() {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	readLine.cancelled = true
 }
 
 func readLineSetInitialBufferText(initialBufferText string) {
@@ -228,6 +302,33 @@ func readLineSetInitialBufferText(initialBufferText string) {
 	readLine.initialBufferText = initialBufferText
 }
 
+// readLineRefreshCompleter captures the state completions are generated from
+// on the calling go routine before the prompt is displayed
+func readLineRefreshCompleter() {
+	readLine.lock.Lock()
+	completer := readLine.completer
+	readLine.lock.Unlock()
+
+	if completer != nil {
+		completer.Refresh()
+	}
+}
+
+func readLineSetPromptListener(promptListener PromptListener) {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	readLine.promptListener = promptListener
+	readLine.promptInput = ""
+}
+
+func readLinePromptListener() PromptListener {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	return readLine.promptListener
+}
+
 func readLineSetNumCharsToRead(numCharsToRead int) {
 	readLine.lock.Lock()
 	defer readLine.lock.Unlock()
@@ -283,19 +384,30 @@ func readLineAddPromptHistory(prompt string, cInput *C.char) {
 //export grvReadlineUpdateDisplay
 func grvReadlineUpdateDisplay() {
 	readLine.lock.Lock()
-	defer readLine.lock.Unlock()
 
 	displayPrompt := C.GoString(C.rl_display_prompt)
 	lineBuffer := C.GoString(C.rl_line_buffer)
 	point := int(C.rl_point)
 
+	inputChanged := lineBuffer != readLine.promptInput
+	if inputChanged {
+		readLine.completionMatches = nil
+	}
+
 	readLine.promptText = displayPrompt
 	readLine.promptInput = lineBuffer
 	readLine.promptPoint = point
+	promptListener := readLine.promptListener
 
 	log.Debugf("ReadLine update display - prompt: %v%v, point: %v",
 		readLine.promptText, readLine.promptInput, readLine.promptPoint)
 
+	readLine.lock.Unlock()
+
+	if inputChanged && promptListener != nil {
+		promptListener.OnPromptInputChanged(lineBuffer)
+	}
+
 	readLine.channels.UpdateDisplay()
 }
 
@@ -323,3 +435,64 @@ func grvReadlineEscapeHandler(C.int, C.int) C.int {
 	CancelReadline()
 	return 0
 }
+
+//export grvReadlineCompletionGenerator
+func grvReadlineCompletionGenerator(text *C.char, state C.int) *C.char {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	if state == 0 {
+		readLine.completions = nil
+
+		if readLine.completer != nil && C.GoString(C.rl_prompt) == PromptText {
+			input := C.GoStringN(C.rl_line_buffer, C.rl_point)
+			readLine.completions = readLine.completer.Complete(input)
+			log.Debugf("Generated %v completion(s) for input: %v", len(readLine.completions), input)
+		}
+	}
+
+	if len(readLine.completions) == 0 {
+		return nil
+	}
+
+	completion := readLine.completions[0]
+	readLine.completions = readLine.completions[1:]
+
+	return C.CString(completion)
+}
+
+//export grvReadlineDisplayCompletionMatches
+func grvReadlineDisplayCompletionMatches(matches **C.char, numMatches C.int, maxLength C.int) {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	// The first entry is the text the input is replaced with and is followed by the matches
+	cMatches := (*[1 << 20]*C.char)(unsafe.Pointer(matches))[1 : numMatches+1 : numMatches+1]
+	readLine.completionMatches = nil
+
+	for _, cMatch := range cMatches {
+		readLine.completionMatches = append(readLine.completionMatches, C.GoString(cMatch))
+	}
+
+	readLine.channels.UpdateDisplay()
+}
+
+//export grvReadlineNextEntryHandler
+func grvReadlineNextEntryHandler(count C.int, key C.int) C.int {
+	if promptListener := readLinePromptListener(); promptListener != nil {
+		promptListener.OnPromptEntryMoved(int(count))
+		return 0
+	}
+
+	return C.rl_get_next_history(count, key)
+}
+
+//export grvReadlinePrevEntryHandler
+func grvReadlinePrevEntryHandler(count C.int, key C.int) C.int {
+	if promptListener := readLinePromptListener(); promptListener != nil {
+		promptListener.OnPromptEntryMoved(-int(count))
+		return 0
+	}
+
+	return C.rl_get_previous_history(count, key)
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"strings"
+	"time"
 
 	slice "github.com/bradfitz/slice"
+	git "gopkg.in/libgit2/git2go.v27"
 )
 
 // CreateRefFilter creates a ref filter from the provided query
@@ -71,6 +73,34 @@ var refFields = map[string]refField{
 			return strings.TrimLeft(renderedRef.value, " ")
 		},
 	},
+	"tagger": {
+		fieldType: FtString,
+		value: func(renderedRef *RenderedRef) interface{} {
+			if tagger := renderedRefTagger(renderedRef); tagger != nil {
+				return tagger.Name
+			}
+
+			return ""
+		},
+	},
+	"taggerdate": {
+		fieldType: FtDate,
+		value: func(renderedRef *RenderedRef) interface{} {
+			if tagger := renderedRefTagger(renderedRef); tagger != nil {
+				return tagger.When
+			}
+
+			return time.Time{}
+		},
+	},
+}
+
+func renderedRefTagger(renderedRef *RenderedRef) *git.Signature {
+	if tag, isTag := renderedRef.ref.(*Tag); isTag && tag.IsAnnotated() {
+		return tag.annotation.tagger
+	}
+
+	return nil
 }
 
 // GenerateRefFieldHelpSection generates help documentation for the ref fields available
@@ -3,6 +3,9 @@ package main
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	git "gopkg.in/libgit2/git2go.v27"
 )
 
 func TestRefFieldExistence(t *testing.T) {
@@ -61,6 +64,14 @@ func TestRefFieldTypes(t *testing.T) {
 			fieldName:         "name",
 			expectedFieldType: FtString,
 		},
+		{
+			fieldName:         "tagger",
+			expectedFieldType: FtString,
+		},
+		{
+			fieldName:         "taggerdate",
+			expectedFieldType: FtDate,
+		},
 	}
 
 	fieldDescriptor := &refFieldDescriptor{}
@@ -109,6 +120,60 @@ func TestRefFieldValuesAreExtracted(t *testing.T) {
 	}
 }
 
+func TestTagFieldValuesAreExtracted(t *testing.T) {
+	taggerDate := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	var tagFieldValueTests = []struct {
+		renderedRef        *RenderedRef
+		expectedTagger     interface{}
+		expectedTaggerDate interface{}
+	}{
+		{
+			renderedRef: &RenderedRef{
+				renderedRefType: RvTag,
+				value:           "v1.0",
+				ref: &Tag{
+					name: "refs/tags/v1.0",
+					annotation: &TagAnnotation{
+						tagger: &git.Signature{
+							Name:  "John Smith",
+							Email: "john@example.com",
+							When:  taggerDate,
+						},
+					},
+				},
+			},
+			expectedTagger:     "John Smith",
+			expectedTaggerDate: taggerDate,
+		},
+		{
+			renderedRef: &RenderedRef{
+				renderedRefType: RvTag,
+				value:           "v0.9",
+				ref: &Tag{
+					name: "refs/tags/v0.9",
+				},
+			},
+			expectedTagger:     "",
+			expectedTaggerDate: time.Time{},
+		},
+	}
+
+	fieldDescriptor := &refFieldDescriptor{}
+
+	for _, tagFieldValueTest := range tagFieldValueTests {
+		renderedRef := tagFieldValueTest.renderedRef
+
+		if actualValue := fieldDescriptor.FieldValue(renderedRef, "tagger"); !reflect.DeepEqual(tagFieldValueTest.expectedTagger, actualValue) {
+			t.Errorf("Tagger does not match expected value for tag %v. Expected: %v, Actual: %v", renderedRef.value, tagFieldValueTest.expectedTagger, actualValue)
+		}
+
+		if actualValue := fieldDescriptor.FieldValue(renderedRef, "taggerdate"); !reflect.DeepEqual(tagFieldValueTest.expectedTaggerDate, actualValue) {
+			t.Errorf("Tagger date does not match expected value for tag %v. Expected: %v, Actual: %v", renderedRef.value, tagFieldValueTest.expectedTaggerDate, actualValue)
+		}
+	}
+}
+
 func TestCertainRenderedRefTypesAlwaysMatchFilter(t *testing.T) {
 	var renderedRefValueTests = []struct {
 		renderedRefType      RenderedRefType
@@ -2,12 +2,17 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
 	log "github.com/Sirupsen/logrus"
 )
 
+const (
+	rvTagDateFormat = "2006-01-02 15:04"
+)
+
 type refViewHandler func(*RefView, Action) error
 
 // RenderedRefType is the type (branch, tag, etc...) of a rendered ref
@@ -212,6 +217,7 @@ func NewRefView(repoData RepoData, repoController RepoController, channels Chann
 			ActionSelect:                  selectRef,
 			ActionAddFilter:               addRefFilter,
 			ActionRemoveFilter:            removeRefFilter,
+			ActionExport:                  exportRefs,
 			ActionMouseSelect:             mouseSelectRef,
 			ActionCheckoutRef:             checkoutRef,
 			ActionCheckoutPreviousRef:     checkoutPreviousRef,
@@ -220,10 +226,14 @@ func NewRefView(repoData RepoData, repoController RepoController, channels Chann
 			ActionCreateTag:               createTagFromRef,
 			ActionCreateAnnotatedTag:      createAnnotatedTagFromRef,
 			ActionPushRef:                 pushRef,
+			ActionPushTags:                pushTags,
+			ActionDeleteRemoteTag:         deleteRemoteTag,
+			ActionVerifyTag:               verifyTag,
 			ActionDeleteRef:               deleteRef,
 			ActionShowAvailableActions:    showActionsForRef,
 			ActionMergeRef:                mergeRef,
 			ActionRebase:                  rebase,
+			ActionCompareRef:              compareRef,
 		},
 	}
 
@@ -303,7 +313,11 @@ func (refView *RefView) RegisterRefListener(refListener RefListener) {
 func (refView *RefView) notifyRefListeners(ref Ref) (err error) {
 	refListeners := append([]RefListener(nil), refView.refListeners...)
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		log.Debugf("Notifying RefListeners of selected ref %v", ref.Name())
 
 		for _, refListener := range refListeners {
@@ -386,7 +400,12 @@ func (refView *RefView) Render(win RenderWindow) (err error) {
 			return
 		}
 
-		lineBuilder.AppendWithStyle(themeComponentID, "%v", renderedRef.value)
+		value := renderedRef.value
+		if refView.config.GetBool(CfMonochrome) {
+			value = monochromeRefValue(renderedRef)
+		}
+
+		lineBuilder.AppendWithStyle(themeComponentID, "%v", value)
 
 		if localBranch, isLocalBranch := renderedRef.ref.(*LocalBranch); isLocalBranch && localBranch.IsTrackingBranch() {
 			lineBuilder.
@@ -395,6 +414,10 @@ func (refView *RefView) Render(win RenderWindow) (err error) {
 				AppendWithStyle(themeComponentID, "%v ", localBranch.ahead).
 				AppendACSChar(AcsDarrow, themeComponentID).
 				AppendWithStyle(themeComponentID, "%v)", localBranch.behind)
+		} else if tag, isTag := renderedRef.ref.(*Tag); isTag && tag.IsAnnotated() {
+			if verification, verified := refView.repoData.SignatureVerification(tag.Oid()); verified && verification.Status() != SignatureNone {
+				lineBuilder.AppendWithStyle(themeComponentID, " [%v]", verification.Status())
+			}
 		}
 
 		refIndex++
@@ -479,6 +502,11 @@ func (refView *RefView) renderFooter(win RenderWindow, selectedRenderedRef *Rend
 		case RvTag:
 			tags, _ := refView.repoData.Tags()
 			footer = fmt.Sprintf("Tag %v of %v", selectedRenderedRef.refNum, len(tags))
+
+			if tag, isTag := selectedRenderedRef.ref.(*Tag); isTag && tag.IsAnnotated() && tag.annotation.tagger != nil {
+				tagger := tag.annotation.tagger
+				footer = fmt.Sprintf("%v - Tagged by %v on %v", footer, tagger.Name, tagger.When.Format(rvTagDateFormat))
+			}
 		}
 	}
 
@@ -536,6 +564,28 @@ func (refView *RefView) generateRenderedRefs() {
 	refView.setVariables()
 }
 
+// monochromeRefValue encloses ref names in the same markers the commit view uses,
+// allowing ref types to be distinguished without color
+func monochromeRefValue(renderedRef *RenderedRef) string {
+	var format string
+
+	switch renderedRef.renderedRefType {
+	case RvLocalBranch, RvHead:
+		format = "{%v}"
+	case RvRemoteBranch:
+		format = "[%v]"
+	case RvTag:
+		format = "<%v>"
+	default:
+		return renderedRef.value
+	}
+
+	name := strings.TrimLeft(renderedRef.value, " *")
+	prefix := renderedRef.value[:len(renderedRef.value)-len(name)]
+
+	return prefix + fmt.Sprintf(format, name)
+}
+
 func generateBranches(refView *RefView, refList *refList, renderedRefs renderedRefSet) {
 	localBranches, remoteBranches, loading := refView.repoData.Branches()
 
@@ -857,6 +907,70 @@ func removeRefFilter(refView *RefView, action Action) (err error) {
 	return
 }
 
+func exportRefs(refView *RefView, action Action) (err error) {
+	filePath, format, err := exportActionArgs(action)
+	if err != nil {
+		return
+	}
+
+	fieldNames := []string{}
+	for fieldName := range refFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+
+	sort.Strings(fieldNames)
+	fields := append(fieldNames, "fullname", "type", "oid", "ahead", "behind")
+
+	// Values are captured while the view lock is held as ahead-behind counts are updated asynchronously
+	var records [][]interface{}
+
+	for _, renderedRef := range refView.renderedRefs.RenderedRefs() {
+		if renderedRef.ref == nil {
+			continue
+		}
+
+		record := []interface{}{}
+		for _, fieldName := range fieldNames {
+			record = append(record, refFields[fieldName].value(renderedRef))
+		}
+
+		var refType string
+		var ahead, behind interface{}
+
+		switch ref := renderedRef.ref.(type) {
+		case *HEAD:
+			refType = "head"
+		case *LocalBranch:
+			refType = "localbranch"
+
+			if ref.IsTrackingBranch() {
+				ahead, behind = ref.ahead, ref.behind
+			}
+		case *RemoteBranch:
+			refType = "remotebranch"
+		case *Tag:
+			refType = "tag"
+		}
+
+		record = append(record, renderedRef.ref.Name(), refType, renderedRef.ref.Oid().String(), ahead, behind)
+		records = append(records, record)
+	}
+
+	runExport(refView.channels, "refs", filePath, format, fields, func(recordWriter RecordWriter) (recordNum uint, err error) {
+		for _, record := range records {
+			if err = recordWriter.WriteRecord(record); err != nil {
+				return
+			}
+
+			recordNum++
+		}
+
+		return
+	})
+
+	return
+}
+
 func mouseSelectRef(refView *RefView, action Action) (err error) {
 	mouseEvent, err := GetMouseEventFromAction(action)
 	if err != nil {
@@ -1094,27 +1208,9 @@ func pushRef(refView *RefView, action Action) (err error) {
 		return
 	}
 
-	remotes := refView.repoData.Remotes()
-	var remote string
-
-	if len(remotes) == 0 {
-		return fmt.Errorf("Cannot push ref: No remotes configured")
-	} else if len(remotes) > 1 {
-		if len(action.Args) == 0 {
-			refView.showRemotesMenu(func(selectedValue interface{}) {
-				refView.channels.DoAction(Action{
-					ActionType: action.ActionType,
-					Args:       []interface{}{selectedValue},
-				})
-			})
-			return
-		} else if remoteName, ok := action.Args[0].(string); ok {
-			remote = remoteName
-		} else {
-			return fmt.Errorf("Expected to find remote argument")
-		}
-	} else {
-		remote = remotes[0]
+	remote, err := refView.remoteForAction(action, "Cannot push ref")
+	if remote == "" || err != nil {
+		return
 	}
 
 	refView.runReportingTask("Running git push", func(quit chan bool) {
@@ -1133,6 +1229,54 @@ func pushRef(refView *RefView, action Action) (err error) {
 	return
 }
 
+func pushTags(refView *RefView, action Action) (err error) {
+	remote, err := refView.remoteForAction(action, "Cannot push tags")
+	if remote == "" || err != nil {
+		return
+	}
+
+	refView.runReportingTask("Running git push --tags", func(quit chan bool) {
+		refView.repoController.PushTags(remote, func(err error) {
+			if err != nil {
+				refView.channels.ReportError(err)
+				refView.channels.ReportStatus("git push --tags failed")
+			} else {
+				refView.channels.ReportStatus("git push --tags for remote %v complete", remote)
+			}
+
+			close(quit)
+		})
+	})
+
+	return
+}
+
+// remoteForAction returns the remote the action should be performed on.
+// If there are multiple remotes and none was provided as an argument then the user
+// is asked to select one and the action is performed again with the selected remote
+func (refView *RefView) remoteForAction(action Action, errorPrefix string) (remote string, err error) {
+	remotes := refView.repoData.Remotes()
+
+	if len(remotes) == 0 {
+		err = fmt.Errorf("%v: No remotes configured", errorPrefix)
+	} else if len(remotes) == 1 {
+		remote = remotes[0]
+	} else if len(action.Args) == 0 {
+		refView.showRemotesMenu(func(selectedValue interface{}) {
+			refView.channels.DoAction(Action{
+				ActionType: action.ActionType,
+				Args:       []interface{}{selectedValue},
+			})
+		})
+	} else if remoteName, ok := action.Args[0].(string); ok {
+		remote = remoteName
+	} else {
+		err = fmt.Errorf("Expected to find remote argument")
+	}
+
+	return
+}
+
 func deleteRef(refView *RefView, action Action) (err error) {
 	renderedRef := refView.selectedRef()
 	if renderedRef == nil || renderedRef.ref == nil {
@@ -1205,6 +1349,33 @@ func deleteRef(refView *RefView, action Action) (err error) {
 	return
 }
 
+func deleteRemoteTag(refView *RefView, action Action) (err error) {
+	renderedRef := refView.selectedRef()
+	if renderedRef == nil {
+		return
+	}
+
+	tag, isTag := renderedRef.ref.(*Tag)
+	if !isTag {
+		return fmt.Errorf("Selected ref is not a tag")
+	}
+
+	remote, err := refView.remoteForAction(action, "Cannot delete remote tag")
+	if remote == "" || err != nil {
+		return
+	}
+
+	question := fmt.Sprintf("Are you sure you want to delete %v on remote %v?", tag.Shorthand(), remote)
+
+	refView.channels.DoAction(YesNoQuestion(question, func(deleteResponse QuestionResponse) {
+		if deleteResponse == ResponseYes {
+			refView.deleteRemoteRef(remote, tag)
+		}
+	}))
+
+	return
+}
+
 func (refView *RefView) deleteRemoteRef(remote string, ref Ref) {
 	refView.runReportingTask(fmt.Sprintf("Deleting ref %v on remote %v", ref.Shorthand(), remote), func(quit chan bool) {
 		refView.repoController.DeleteRemoteRef(remote, ref, func(err error) {
@@ -1251,6 +1422,44 @@ func (refView *RefView) showRemotesMenu(consumer Consumer) {
 	})
 }
 
+func verifyTag(refView *RefView, action Action) (err error) {
+	renderedRef := refView.selectedRef()
+	if renderedRef == nil {
+		return
+	}
+
+	tag, isTag := renderedRef.ref.(*Tag)
+	if !isTag {
+		return fmt.Errorf("Selected ref is not a tag")
+	} else if !tag.IsAnnotated() {
+		return fmt.Errorf("Tag %v is not an annotated tag and cannot be signed", tag.Shorthand())
+	}
+
+	refView.channels.ReportStatus("Verifying tag %v", tag.Shorthand())
+
+	pendingBackgroundWork.Start()
+
+	go func() {
+		defer pendingBackgroundWork.Complete()
+
+		verification := refView.repoData.VerifySignature(tag.Oid())
+
+		switch verification.Status() {
+		case SignatureNone:
+			refView.channels.ReportError(fmt.Errorf("Tag %v is not signed", tag.Shorthand()))
+		case SignatureGood:
+			refView.channels.ReportStatus("Tag %v has a good signature: %v", tag.Shorthand(), signatureDescription(verification))
+		case SignatureBad:
+			refView.channels.ReportError(fmt.Errorf("Tag %v has a bad signature: %v", tag.Shorthand(), signatureDescription(verification)))
+		default:
+			refView.channels.ReportError(fmt.Errorf("Unable to verify signature of tag %v using %v",
+				tag.Shorthand(), refView.config.GetString(CfSignatureVerifyCommand)))
+		}
+	}()
+
+	return
+}
+
 func mergeRef(refView *RefView, action Action) (err error) {
 	renderedRef := refView.selectedRef()
 	if renderedRef == nil || renderedRef.ref == nil {
@@ -1300,6 +1509,24 @@ func rebase(refView *RefView, action Action) (err error) {
 	return
 }
 
+func compareRef(refView *RefView, action Action) (err error) {
+	renderedRef := refView.selectedRef()
+	if renderedRef == nil || renderedRef.ref == nil {
+		return
+	}
+
+	if refView.repoData.Head().Equal(renderedRef.ref) {
+		return fmt.Errorf("Cannot compare HEAD with itself")
+	}
+
+	refView.channels.DoAction(Action{
+		ActionType: ActionShowCompareView,
+		Args:       []interface{}{"HEAD", renderedRef.ref.Name()},
+	})
+
+	return
+}
+
 func showActionsForRef(refView *RefView, action Action) (err error) {
 	if refView.rows() == 0 {
 		return
@@ -1360,6 +1587,23 @@ func showActionsForRef(refView *RefView, action Action) (err error) {
 		})
 	}
 
+	if isTag {
+		contextMenuEntries = append(contextMenuEntries,
+			ContextMenuEntry{
+				DisplayName: "Push all tags to remote",
+				Value:       Action{ActionType: ActionPushTags},
+			},
+			ContextMenuEntry{
+				DisplayName: fmt.Sprintf("Delete %v on remote", refName),
+				Value:       Action{ActionType: ActionDeleteRemoteTag},
+			},
+			ContextMenuEntry{
+				DisplayName: fmt.Sprintf("Verify %v", refName),
+				Value:       Action{ActionType: ActionVerifyTag},
+			},
+		)
+	}
+
 	head := refView.repoData.Head()
 	headName := head.Shorthand()
 	if StringWidth(headName) > 12 {
@@ -1381,6 +1625,10 @@ func showActionsForRef(refView *RefView, action Action) (err error) {
 				DisplayName: fmt.Sprintf("Rebase %v onto %v", headName, refName),
 				Value:       Action{ActionType: ActionRebase},
 			})
+			contextMenuEntries = append(contextMenuEntries, ContextMenuEntry{
+				DisplayName: fmt.Sprintf("Compare %v with %v", headName, refName),
+				Value:       Action{ActionType: ActionCompareRef},
+			})
 		}
 	}
 
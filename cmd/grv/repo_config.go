@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	rcRepoConfigFile  = ".grvrc"
+	rcTrustedFileName = "trusted_repo_configs"
+)
+
+// RepoConfigTrust is the response to the question of whether a repository config file should be loaded
+type RepoConfigTrust int
+
+// The set of responses to a repository config trust prompt
+const (
+	RctDeny RepoConfigTrust = iota
+	RctAllowOnce
+	RctAllowAlways
+)
+
+// RepoConfigFilePath returns the path of the repository config file for the provided work tree
+func RepoConfigFilePath(workdir string) string {
+	return filepath.Join(workdir, rcRepoConfigFile)
+}
+
+// RepoConfigTrustStore records the repository config files the user has chosen to trust.
+// A file is only trusted while its content is unchanged from when it was trusted
+type RepoConfigTrustStore struct {
+	filePath string
+}
+
+// NewRepoConfigTrustStore creates a new instance which stores trusted files in the grv config directory
+func NewRepoConfigTrustStore(configDir string) *RepoConfigTrustStore {
+	return &RepoConfigTrustStore{
+		filePath: filepath.Join(configDir, rcTrustedFileName),
+	}
+}
+
+// IsTrusted returns true if the config file at the provided path has been trusted with the provided content
+func (trustStore *RepoConfigTrustStore) IsTrusted(configPath string, content []byte) (trusted bool, err error) {
+	entries, err := trustStore.load()
+	if err != nil {
+		return
+	}
+
+	trusted = entries[configPath] == repoConfigHash(content)
+
+	return
+}
+
+// Trust records the config file at the provided path as trusted with the provided content.
+// Any previous entry for the file is replaced
+func (trustStore *RepoConfigTrustStore) Trust(configPath string, content []byte) (err error) {
+	entries, err := trustStore.load()
+	if err != nil {
+		return
+	}
+
+	entries[configPath] = repoConfigHash(content)
+
+	var buffer bytes.Buffer
+	for path, hash := range entries {
+		buffer.WriteString(fmt.Sprintf("%v %v\n", hash, path))
+	}
+
+	tempFilePath := trustStore.filePath + ".tmp"
+	if err = ioutil.WriteFile(tempFilePath, buffer.Bytes(), 0600); err != nil {
+		return
+	}
+
+	if err = os.Rename(tempFilePath, trustStore.filePath); err != nil {
+		return
+	}
+
+	log.Infof("Trusted repository config file %v", configPath)
+
+	return
+}
+
+func (trustStore *RepoConfigTrustStore) load() (entries map[string]string, err error) {
+	entries = map[string]string{}
+
+	file, err := os.Open(trustStore.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+
+		entries[fields[1]] = fields[0]
+	}
+
+	err = scanner.Err()
+
+	return
+}
+
+func repoConfigHash(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// PromptRepoConfigTrust asks the user whether the repository config file at the provided path should be loaded.
+// The config file is not loaded if no valid response can be read
+func PromptRepoConfigTrust(reader io.Reader, writer io.Writer, configPath string) RepoConfigTrust {
+	fmt.Fprintf(writer, "The repository config file %v has not been trusted.\n", configPath)
+	fmt.Fprintf(writer, "Load it? [y]es, [n]o, [a]lways (until it changes): ")
+
+	response, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && response == "" {
+		fmt.Fprintln(writer)
+		return RctDeny
+	}
+
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "y", "yes":
+		return RctAllowOnce
+	case "a", "always":
+		return RctAllowAlways
+	}
+
+	return RctDeny
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRepoConfigIsOnlyTrustedWhileContentIsUnchanged(t *testing.T) {
+	configDir, err := ioutil.TempDir("", "grv-repo-config")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(configDir)
+
+	trustStore := NewRepoConfigTrustStore(configDir)
+	configPath := RepoConfigFilePath("/repo")
+	content := []byte("set commit-limit 1000\n")
+
+	if trusted, err := trustStore.IsTrusted(configPath, content); err != nil || trusted {
+		t.Errorf("Expected config to be untrusted before being trusted. Trusted: %v, Error: %v", trusted, err)
+	}
+
+	if err = trustStore.Trust(configPath, content); err != nil {
+		t.Fatalf("Unable to trust config: %v", err)
+	}
+
+	if err = trustStore.Trust(RepoConfigFilePath("/other-repo"), []byte("set mouse true\n")); err != nil {
+		t.Fatalf("Unable to trust config: %v", err)
+	}
+
+	if trusted, err := trustStore.IsTrusted(configPath, content); err != nil || !trusted {
+		t.Errorf("Expected config to be trusted. Trusted: %v, Error: %v", trusted, err)
+	}
+
+	if trusted, err := trustStore.IsTrusted(configPath, []byte("!rm -rf ~\n")); err != nil || trusted {
+		t.Errorf("Expected modified config to be untrusted. Trusted: %v, Error: %v", trusted, err)
+	}
+
+	if trusted, err := trustStore.IsTrusted(RepoConfigFilePath("/another-repo"), content); err != nil || trusted {
+		t.Errorf("Expected config in different repository to be untrusted. Trusted: %v, Error: %v", trusted, err)
+	}
+}
+
+func TestRepoConfigTrustPromptResponses(t *testing.T) {
+	var promptTests = []struct {
+		input         string
+		expectedTrust RepoConfigTrust
+	}{
+		{
+			input:         "y\n",
+			expectedTrust: RctAllowOnce,
+		},
+		{
+			input:         "A\n",
+			expectedTrust: RctAllowAlways,
+		},
+		{
+			input:         "n\n",
+			expectedTrust: RctDeny,
+		},
+		{
+			input:         "\n",
+			expectedTrust: RctDeny,
+		},
+		{
+			input:         "",
+			expectedTrust: RctDeny,
+		},
+	}
+
+	for _, promptTest := range promptTests {
+		var output bytes.Buffer
+		trust := PromptRepoConfigTrust(strings.NewReader(promptTest.input), &output, "/repo/.grvrc")
+
+		if trust != promptTest.expectedTrust {
+			t.Errorf("Response to input %q does not match expected value. Expected: %v, Actual: %v", promptTest.input, promptTest.expectedTrust, trust)
+		}
+
+		if !strings.Contains(output.String(), "/repo/.grvrc") {
+			t.Errorf("Expected prompt to contain config file path. Actual: %q", output.String())
+		}
+	}
+}
@@ -37,12 +37,18 @@ type RepoController interface {
 	CommitMessageFile() (*os.File, error)
 	Commit(CommitResultHandler)
 	AmendCommit(CommitResultHandler)
+	CommitWithMessage(message string, resultHandler CommitResultHandler)
+	AmendCommitWithMessage(message string, resultHandler CommitResultHandler)
 	Pull(remote string, resultHandler RepoResultHandler)
 	Push(remote string, ref Ref, track bool, resultHandler RepoResultHandler)
+	PushTags(remote string, resultHandler RepoResultHandler)
 	DeleteLocalRef(ref Ref) error
 	DeleteRemoteRef(remote string, ref Ref, resultHandler RepoResultHandler)
 	MergeRef(Ref) error
 	Rebase(Ref) error
+	AddNote(oid *Oid, resultHandler RepoResultHandler)
+	EditNote(oid *Oid, resultHandler RepoResultHandler)
+	RemoveNote(oid *Oid) error
 }
 
 // ReadOnlyRepositoryController does not permit any
@@ -60,12 +66,12 @@ func (repoController *ReadOnlyRepositoryController) Initialise(RepoSupplier) {}
 
 // CheckoutRef returns a read only error
 func (repoController *ReadOnlyRepositoryController) CheckoutRef(ref Ref, resultHandler RefOperationResultHandler) {
-	go resultHandler(nil, errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(nil, errReadOnly) })
 }
 
 // CheckoutCommit returns a read only error
 func (repoController *ReadOnlyRepositoryController) CheckoutCommit(commit *Commit, resultHandler RepoResultHandler) {
-	go resultHandler(errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(errReadOnly) })
 }
 
 // CreateBranch returns a read only error
@@ -75,7 +81,7 @@ func (repoController *ReadOnlyRepositoryController) CreateBranch(string, *Oid) e
 
 // CreateBranchAndCheckout returns a read only error
 func (repoController *ReadOnlyRepositoryController) CreateBranchAndCheckout(branchName string, oid *Oid, resultHandler RefOperationResultHandler) {
-	go resultHandler(nil, errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(nil, errReadOnly) })
 }
 
 // CreateTag returns a read only error
@@ -85,12 +91,12 @@ func (repoController *ReadOnlyRepositoryController) CreateTag(string, *Oid) erro
 
 // CreateAnnotatedTag returns a read only error
 func (repoController *ReadOnlyRepositoryController) CreateAnnotatedTag(tagName string, oid *Oid, resultHandler RefOperationResultHandler) {
-	go resultHandler(nil, errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(nil, errReadOnly) })
 }
 
 // CheckoutPreviousRef returns a read only error
 func (repoController *ReadOnlyRepositoryController) CheckoutPreviousRef(resultHandler RefOperationResultHandler) {
-	go resultHandler(nil, errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(nil, errReadOnly) })
 }
 
 // StageFiles returns a read only error
@@ -115,22 +121,37 @@ func (repoController *ReadOnlyRepositoryController) CommitMessageFile() (file *o
 
 // Commit returns a read only error
 func (repoController *ReadOnlyRepositoryController) Commit(resultHandler CommitResultHandler) {
-	go resultHandler(nil, errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(nil, errReadOnly) })
 }
 
 // AmendCommit returns a read only error
 func (repoController *ReadOnlyRepositoryController) AmendCommit(resultHandler CommitResultHandler) {
-	go resultHandler(nil, errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(nil, errReadOnly) })
+}
+
+// CommitWithMessage returns a read only error
+func (repoController *ReadOnlyRepositoryController) CommitWithMessage(message string, resultHandler CommitResultHandler) {
+	pendingBackgroundWork.Go(func() { resultHandler(nil, errReadOnly) })
+}
+
+// AmendCommitWithMessage returns a read only error
+func (repoController *ReadOnlyRepositoryController) AmendCommitWithMessage(message string, resultHandler CommitResultHandler) {
+	pendingBackgroundWork.Go(func() { resultHandler(nil, errReadOnly) })
 }
 
 // Pull returns a read only error
 func (repoController *ReadOnlyRepositoryController) Pull(remote string, resultHandler RepoResultHandler) {
-	go resultHandler(errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(errReadOnly) })
 }
 
 // Push returns a read only error
 func (repoController *ReadOnlyRepositoryController) Push(remote string, ref Ref, track bool, resultHandler RepoResultHandler) {
-	go resultHandler(errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(errReadOnly) })
+}
+
+// PushTags returns a read only error
+func (repoController *ReadOnlyRepositoryController) PushTags(remote string, resultHandler RepoResultHandler) {
+	pendingBackgroundWork.Go(func() { resultHandler(errReadOnly) })
 }
 
 // DeleteLocalRef returns a read only error
@@ -140,7 +161,7 @@ func (repoController *ReadOnlyRepositoryController) DeleteLocalRef(ref Ref) erro
 
 // DeleteRemoteRef returns a read only error
 func (repoController *ReadOnlyRepositoryController) DeleteRemoteRef(remote string, ref Ref, resultHandler RepoResultHandler) {
-	go resultHandler(errReadOnly)
+	pendingBackgroundWork.Go(func() { resultHandler(errReadOnly) })
 }
 
 // MergeRef returns a read only error
@@ -152,3 +173,18 @@ func (repoController *ReadOnlyRepositoryController) MergeRef(Ref) error {
 func (repoController *ReadOnlyRepositoryController) Rebase(Ref) error {
 	return errReadOnly
 }
+
+// AddNote returns a read only error
+func (repoController *ReadOnlyRepositoryController) AddNote(oid *Oid, resultHandler RepoResultHandler) {
+	pendingBackgroundWork.Go(func() { resultHandler(errReadOnly) })
+}
+
+// EditNote returns a read only error
+func (repoController *ReadOnlyRepositoryController) EditNote(oid *Oid, resultHandler RepoResultHandler) {
+	pendingBackgroundWork.Go(func() { resultHandler(errReadOnly) })
+}
+
+// RemoveNote returns a read only error
+func (repoController *ReadOnlyRepositoryController) RemoveNote(oid *Oid) error {
+	return errReadOnly
+}
@@ -56,6 +56,7 @@ type RepoData interface {
 	RepositoryRootPath() string
 	Workdir() string
 	UserEditor() (string, error)
+	UserSignature() (string, error)
 	GenerateGitCommandEnvironment() (env []string, rootDir string)
 	Reload(ReloadResult)
 	LoadHead() error
@@ -73,11 +74,20 @@ type RepoData interface {
 	Commit(oid *Oid) (*Commit, error)
 	CommitByOid(oidStr string) (*Commit, error)
 	CommitParents(oid *Oid) ([]*Commit, error)
+	CommitRange(commitRange string) (<-chan *Commit, error)
+	MergeBase(oid1, oid2 *Oid) (*Oid, error)
+	AheadBehind(local, upstream *Oid) (ahead, behind int, err error)
+	CommitContainment(commit *Commit, cancelCh <-chan bool) *CommitContainment
+	SignatureVerification(oid *Oid) (verification *SignatureVerification, verified bool)
+	VerifySignature(oid *Oid) *SignatureVerification
+	Notes(oid *Oid) ([]*CommitNote, error)
 	AddCommitFilter(Ref, *CommitFilter) error
 	RemoveCommitFilter(Ref) error
 	DiffCommit(commit *Commit) (*Diff, error)
+	DiffCommits(from, to *Commit) (*Diff, error)
 	DiffFile(statusType StatusType, path string) (*Diff, error)
 	DiffStage(statusType StatusType) (*Diff, error)
+	CommitFiles(commit *Commit) ([]string, error)
 	LoadStatus() (err error)
 	Status() *Status
 	LoadRemotes() error
@@ -100,6 +110,7 @@ type commitSet interface {
 type filteredCommitSet struct {
 	commits      []*Commit
 	loading      bool
+	initialising bool
 	child        commitSet
 	commitFilter *CommitFilter
 	lock         sync.Mutex
@@ -126,6 +137,8 @@ func (filteredCommitSet *filteredCommitSet) initialiseFromCommitSet() {
 			filteredCommitSet.addCommitIfFilterMatches(commit)
 		}
 	}
+
+	filteredCommitSet.initialising = false
 }
 
 // CommitSet returns the child commit set of this filter
@@ -246,6 +259,7 @@ func (filteredCommitSet *filteredCommitSet) CommitSetState() CommitSetState {
 
 		commitSetState.commitNum = uint(len(filteredCommitSet.commits))
 		commitSetState.filterState.filtersApplied++
+		commitSetState.filterState.initialising = commitSetState.filterState.initialising || filteredCommitSet.initialising
 
 		return commitSetState
 	}
@@ -298,6 +312,7 @@ type CommitSetState struct {
 type CommitSetFilterState struct {
 	unfilteredCommitNum uint
 	filtersApplied      uint
+	initialising        bool
 }
 
 type trackingBranchState struct {
@@ -612,7 +627,11 @@ func (refSet *refSet) determineTrackingBranchesToUpdate(remoteToLocalOld, remote
 func (refSet *refSet) notifyRefStateListenersRefsChanged(addedRefs, removedRefs []Ref, updatedRefs []*UpdatedRef) {
 	refStateListeners := append([]RefStateListener(nil), refSet.refStateListeners...)
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		log.Debugf("Notifying RefStateListeners Refs Changed - new: %v, removed: %v, updated: %v",
 			len(addedRefs), len(removedRefs), len(updatedRefs))
 
@@ -625,7 +644,11 @@ func (refSet *refSet) notifyRefStateListenersRefsChanged(addedRefs, removedRefs
 func (refSet *refSet) notifyRefStateListenersHeadChanged(oldHead, newHead Ref) {
 	refStateListeners := append([]RefStateListener(nil), refSet.refStateListeners...)
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		log.Debugf("Notifying RefStateListeners HEAD changed %v:%v -> %v:%v",
 			oldHead.Name(), oldHead.Oid(), newHead.Name(), newHead.Oid())
 
@@ -638,7 +661,11 @@ func (refSet *refSet) notifyRefStateListenersHeadChanged(oldHead, newHead Ref) {
 func (refSet *refSet) notifyRefStateListenersTrackingBranchesUpdated(trackingBranches []*LocalBranch) {
 	refStateListeners := append([]RefStateListener(nil), refSet.refStateListeners...)
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		log.Debugf("Notifying RefStateListeners %v tracking branches have changed", len(trackingBranches))
 
 		for _, refStateListener := range refStateListeners {
@@ -784,6 +811,19 @@ func (refCommitSets *refCommitSets) commitSet(ref Ref) (commitSet commitSet, exi
 	return
 }
 
+func (refCommitSets *refCommitSets) loading() bool {
+	refCommitSets.lock.Lock()
+	defer refCommitSets.lock.Unlock()
+
+	for _, commitSet := range refCommitSets.commits {
+		if commitSet.CommitSetState().loading {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (refCommitSets *refCommitSets) setCommitSet(ref Ref, commitSet commitSet) {
 	refCommitSets.lock.Lock()
 	defer refCommitSets.lock.Unlock()
@@ -801,9 +841,13 @@ func (refCommitSets *refCommitSets) addCommitFilter(ref Ref, commitFilter *Commi
 	}
 
 	filteredCommitSet := newFilteredCommitSet(commitSet, commitFilter)
+	filteredCommitSet.initialising = true
 	refCommitSets.commits[ref.Name()] = filteredCommitSet
+	pendingBackgroundWork.Start()
 
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		beforeState := commitSet.CommitSetState()
 		filteredCommitSet.initialiseFromCommitSet()
 
@@ -818,6 +862,15 @@ func (refCommitSets *refCommitSets) addCommitFilter(ref Ref, commitFilter *Commi
 			}
 		}
 
+		// Only notify listeners once the outermost filter has been populated,
+		// as further filters may have been applied while this one was initialising
+		refCommitSets.lock.Lock()
+		outermostFilter := refCommitSets.commits[ref.Name()] == filteredCommitSet
+		refCommitSets.lock.Unlock()
+
+		if outermostFilter {
+			refCommitSets.notifyCommitSetListenersCommitSetUpdated(ref)
+		}
 	}()
 
 	refCommitSets.channels.ReportStatus("Applying commit filter...")
@@ -888,7 +941,11 @@ func (refCommitSets *refCommitSets) notifyCommitSetListenersCommitSetLoaded(ref
 
 	commitSetListeners := append([]CommitSetListener(nil), refCommitSets.commitSetListeners...)
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		log.Debugf("Notifying CommitSetListeners commits for ref %v have loaded", ref.Name())
 
 		for _, listener := range commitSetListeners {
@@ -903,7 +960,11 @@ func (refCommitSets *refCommitSets) notifyCommitSetListenersCommitSetUpdated(ref
 
 	commitSetListeners := append([]CommitSetListener(nil), refCommitSets.commitSetListeners...)
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		log.Debugf("Notifying CommitSetListeners commits for ref %v have updated", ref.Name())
 
 		for _, listener := range commitSetListeners {
@@ -939,7 +1000,11 @@ func (statusManager *statusManager) loadStatus() (err error) {
 		statusManager.status = newStatus
 
 		statusListeners := append([]StatusListener(nil), statusManager.statusListeners...)
+		pendingBackgroundWork.Start()
+
 		go func() {
+			defer pendingBackgroundWork.Complete()
+
 			for _, statusListener := range statusListeners {
 				statusListener.OnStatusChanged(newStatus)
 			}
@@ -1012,17 +1077,18 @@ func (remoteSet *remoteSet) getRemotes() []string {
 
 // RepositoryData implements RepoData and stores all loaded repository data
 type RepositoryData struct {
-	channels       Channels
-	repoDataLoader *RepoDataLoader
-	head           Ref
-	refSet         *refSet
-	commitRefSet   *commitRefSet
-	refCommitSets  *refCommitSets
-	statusManager  *statusManager
-	refUpdateCh    chan *UpdatedRef
-	variables      *GRVVariables
-	remoteSet      *remoteSet
-	waitGroup      sync.WaitGroup
+	channels          Channels
+	repoDataLoader    *RepoDataLoader
+	head              Ref
+	refSet            *refSet
+	commitRefSet      *commitRefSet
+	refCommitSets     *refCommitSets
+	statusManager     *statusManager
+	refUpdateCh       chan *UpdatedRef
+	variables         *GRVVariables
+	remoteSet         *remoteSet
+	signatureVerifier *signatureVerifier
+	waitGroup         sync.WaitGroup
 }
 
 // NewRepositoryData creates a new instance
@@ -1039,6 +1105,8 @@ func NewRepositoryData(repoDataLoader *RepoDataLoader, channels Channels, variab
 	}
 
 	repoData.refSet = newRefSet(repoData)
+	repoData.signatureVerifier = newSignatureVerifier(repoDataLoader, channels)
+	repoDataLoader.config.AddOnChangeListener(CfSignatureVerifyCommand, repoData.signatureVerifier)
 
 	return repoData
 }
@@ -1085,6 +1153,11 @@ func (repoData *RepositoryData) UserEditor() (string, error) {
 	return repoData.repoDataLoader.UserEditor()
 }
 
+// UserSignature returns the name and email git is configured to use
+func (repoData *RepositoryData) UserSignature() (string, error) {
+	return repoData.repoDataLoader.UserSignature()
+}
+
 // Reload cached repository data
 func (repoData *RepositoryData) Reload(reloadResult ReloadResult) {
 	notifyResult := func(err error) {
@@ -1093,7 +1166,11 @@ func (repoData *RepositoryData) Reload(reloadResult ReloadResult) {
 		}
 	}
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		if repoData.refSet.startRefUpdate() {
 			err := repoData.loadRefs(nil)
 			repoData.refSet.endRefUpdate()
@@ -1147,7 +1224,10 @@ func (repoData *RepositoryData) LoadRefs(onRefsLoaded OnRefsLoaded) {
 		return
 	}
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
 		defer refSet.endRefUpdate()
 		if err := repoData.loadRefs(onRefsLoaded); err != nil {
 			repoData.channels.ReportError(err)
@@ -1219,8 +1299,10 @@ func (repoData *RepositoryData) LoadCommits(ref Ref) (err error) {
 	commitSet := newBaseFilteredCommitSet()
 	commitSet.SetLoading(true)
 	repoData.refCommitSets.setCommitSet(ref, commitSet)
+	pendingBackgroundWork.Start()
 
 	go func() {
+		defer pendingBackgroundWork.Complete()
 		log.Debugf("Receiving commits from RepoDataLoader for ref %v at %v", ref.Name(), ref.Oid())
 
 		for commit := range commitCh {
@@ -1271,6 +1353,15 @@ func (repoData *RepositoryData) Branches() (localBranches []Branch, remoteBranch
 	return repoData.refSet.branches()
 }
 
+// Loading returns true if refs or commits are currently being loaded
+func (repoData *RepositoryData) Loading() bool {
+	if _, _, loading := repoData.refSet.branches(); loading {
+		return true
+	}
+
+	return repoData.refCommitSets.loading()
+}
+
 // Tags returns all loaded tags
 func (repoData *RepositoryData) Tags() (tags []*Tag, loading bool) {
 	return repoData.refSet.tags()
@@ -1377,6 +1468,49 @@ func (repoData *RepositoryData) CommitParents(oid *Oid) (parentCommits []*Commit
 	return
 }
 
+// CommitRange returns a stream of the commits in the provided range of the form rev..rev.
+// The commits are not cached as they are not associated with a ref
+func (repoData *RepositoryData) CommitRange(commitRange string) (<-chan *Commit, error) {
+	return repoData.repoDataLoader.CommitRange(commitRange)
+}
+
+// MergeBase returns the best common ancestor of the two provided commits
+func (repoData *RepositoryData) MergeBase(oid1, oid2 *Oid) (*Oid, error) {
+	return repoData.repoDataLoader.MergeBase(oid1, oid2)
+}
+
+// AheadBehind returns the number of commits reachable from local but not upstream and vice versa
+func (repoData *RepositoryData) AheadBehind(local, upstream *Oid) (ahead, behind int, err error) {
+	return repoData.repoDataLoader.AheadBehind(local, upstream)
+}
+
+// CommitContainment determines which of the loaded branches and tags contain the provided commit.
+// This walks the commit graph for each ref and so should not be called on the UI thread.
+// Nil is returned if cancelCh is closed before all refs have been checked
+func (repoData *RepositoryData) CommitContainment(commit *Commit, cancelCh <-chan bool) *CommitContainment {
+	localBranches, remoteBranches, _ := repoData.refSet.branches()
+	tags, _ := repoData.refSet.tags()
+
+	return newCommitContainment(repoData.repoDataLoader, commit, localBranches, remoteBranches, tags, cancelCh)
+}
+
+// SignatureVerification returns the cached signature verification for the commit or tag with the provided oid.
+// If the signature has not been verified yet then it is verified in the background and verified is false
+func (repoData *RepositoryData) SignatureVerification(oid *Oid) (verification *SignatureVerification, verified bool) {
+	return repoData.signatureVerifier.verification(oid)
+}
+
+// VerifySignature returns the signature verification for the commit or tag with the provided oid,
+// verifying the signature if necessary
+func (repoData *RepositoryData) VerifySignature(oid *Oid) *SignatureVerification {
+	return repoData.signatureVerifier.verify(oid)
+}
+
+// Notes returns the notes attached to the provided oid under the configured notes refs
+func (repoData *RepositoryData) Notes(oid *Oid) ([]*CommitNote, error) {
+	return repoData.repoDataLoader.Notes(oid)
+}
+
 // AddCommitFilter adds the filter to the specified ref
 func (repoData *RepositoryData) AddCommitFilter(ref Ref, commitFilter *CommitFilter) error {
 	return repoData.refCommitSets.addCommitFilter(ref, commitFilter)
@@ -1393,6 +1527,11 @@ func (repoData *RepositoryData) DiffCommit(commit *Commit) (*Diff, error) {
 	return repoData.repoDataLoader.DiffCommit(commit)
 }
 
+// DiffCommits loads the cumulative diff between the two provided commits
+func (repoData *RepositoryData) DiffCommits(from, to *Commit) (*Diff, error) {
+	return repoData.repoDataLoader.DiffCommits(from, to)
+}
+
 // DiffFile Generates a diff for the provided file
 // If statusType is StStaged then the diff is between HEAD and the index
 // If statusType is StUnstaged then the diff is between index and the working directory
@@ -1405,6 +1544,11 @@ func (repoData *RepositoryData) DiffStage(statusType StatusType) (*Diff, error)
 	return repoData.repoDataLoader.DiffStage(statusType)
 }
 
+// CommitFiles returns the paths of all files in the tree of the provided commit
+func (repoData *RepositoryData) CommitFiles(commit *Commit) ([]string, error) {
+	return repoData.repoDataLoader.CommitFiles(commit)
+}
+
 // LoadStatus loads the current git status
 func (repoData *RepositoryData) LoadStatus() (err error) {
 	return repoData.statusManager.loadStatus()
@@ -1468,9 +1612,12 @@ func (repoData *RepositoryData) addUpdatedRefsToProcessingQueue(updatedRefs []*U
 	}
 
 	for _, updatedRef := range updatedRefs {
+		pendingBackgroundWork.Start()
+
 		select {
 		case refUpdateCh <- updatedRef:
 		default:
+			pendingBackgroundWork.Complete()
 			log.Errorf("Unable process UpdatedRef %v", updatedRef)
 		}
 	}
@@ -1481,39 +1628,49 @@ func (repoData *RepositoryData) processUpdatedRefs() {
 	log.Info("Starting UpdatedRef processor")
 
 	for updatedRef := range repoData.refUpdateCh {
-		oldRef := updatedRef.OldRef
-		newRef := updatedRef.NewRef
-
-		log.Debugf("Processing ref update for %v", updatedRef)
+		repoData.processUpdatedRef(updatedRef)
 
-		commitSet, exists := repoData.refCommitSets.commitSet(oldRef)
-		if !exists {
-			log.Debugf("No commitSet for oid %v", oldRef.Oid())
-			continue
+		if repoData.channels.Exit() {
+			return
 		}
+	}
+}
 
-		commitCh, err := repoData.repoDataLoader.Commits(newRef.Oid())
-		if err != nil {
-			log.Errorf("Unable to load commits for range %v: %v", newRef.Name(), err)
-			continue
-		}
-		log.Debugf("Reading commits for oid %v", newRef.Oid())
+func (repoData *RepositoryData) processUpdatedRef(updatedRef *UpdatedRef) {
+	defer pendingBackgroundWork.Complete()
 
-		var commits []*Commit
-		for commit := range commitCh {
-			commits = append(commits, commit)
+	oldRef := updatedRef.OldRef
+	newRef := updatedRef.NewRef
 
-			if repoData.channels.Exit() {
-				return
-			}
-		}
+	log.Debugf("Processing ref update for %v", updatedRef)
 
-		log.Debugf("Updating ref %v with %v commits", newRef.Name(), len(commits))
-		commitSet.Update(commits)
-		repoData.refCommitSets.setCommitSet(newRef, commitSet)
-		repoData.refCommitSets.notifyCommitSetListenersCommitSetUpdated(newRef)
-		repoData.channels.UpdateDisplay()
+	commitSet, exists := repoData.refCommitSets.commitSet(oldRef)
+	if !exists {
+		log.Debugf("No commitSet for oid %v", oldRef.Oid())
+		return
 	}
+
+	commitCh, err := repoData.repoDataLoader.Commits(newRef.Oid())
+	if err != nil {
+		log.Errorf("Unable to load commits for range %v: %v", newRef.Name(), err)
+		return
+	}
+	log.Debugf("Reading commits for oid %v", newRef.Oid())
+
+	var commits []*Commit
+	for commit := range commitCh {
+		commits = append(commits, commit)
+
+		if repoData.channels.Exit() {
+			return
+		}
+	}
+
+	log.Debugf("Updating ref %v with %v commits", newRef.Name(), len(commits))
+	commitSet.Update(commits)
+	repoData.refCommitSets.setCommitSet(newRef, commitSet)
+	repoData.refCommitSets.notifyCommitSetListenersCommitSetUpdated(newRef)
+	repoData.channels.UpdateDisplay()
 }
 
 func (repoData *RepositoryData) updateTrackingBranches(trackingBranchStates []*trackingBranchState) (trackingBranches []*LocalBranch) {
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"reflect"
@@ -12,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -41,6 +43,9 @@ var dateTimeCommitLimit = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\s+\d{2}:\d{2}:\
 var dateTimeZoneCommitLimit = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\s+\d{2}:\d{2}:\d{2}(\+|-)\d{4}$`)
 var oidCommitLimit = regexp.MustCompile(`^[[:xdigit:]]+$`)
 
+var tagSignatureStartRegex = regexp.MustCompile(`(?m)^-----BEGIN [A-Z ]*SIGNATURE-----$`)
+var signerRegex = regexp.MustCompile(`(?i)signature (?:from "([^"]+)"|for (\S+))`)
+
 type commitLimitPredicate func(*git.Commit) bool
 
 var noCommitLimitPredicate = func(*git.Commit) bool {
@@ -264,10 +269,18 @@ func (remoteBranch *RemoteBranch) Equal(other Ref) bool {
 
 // Tag contains data for a tag reference
 type Tag struct {
-	oid       *Oid
-	name      string
-	shorthand string
-	isRemote  bool
+	oid        *Oid
+	name       string
+	shorthand  string
+	isRemote   bool
+	annotation *TagAnnotation
+}
+
+// TagAnnotation contains the data stored in an annotated tag object
+type TagAnnotation struct {
+	tagger  *git.Signature
+	message string
+	target  *Oid
 }
 
 // Oid pointed to by this tag
@@ -285,6 +298,21 @@ func (tag *Tag) Shorthand() string {
 	return tag.shorthand
 }
 
+// IsAnnotated returns true if this tag refers to an annotated tag object
+func (tag *Tag) IsAnnotated() bool {
+	return tag.annotation != nil
+}
+
+// TargetOid returns the oid of the object this tag is attached to.
+// For annotated tags this is the target of the tag object
+func (tag *Tag) TargetOid() *Oid {
+	if tag.annotation != nil {
+		return tag.annotation.target
+	}
+
+	return tag.oid
+}
+
 // Equal returns true if the other ref is a tag equal to this one
 func (tag *Tag) Equal(other Ref) bool {
 	if other == nil {
@@ -355,6 +383,12 @@ type Commit struct {
 	commit *git.Commit
 }
 
+// CommitNote contains a note attached to a commit under a notes ref
+type CommitNote struct {
+	ref     string
+	message string
+}
+
 // Diff contains data for a generated diff
 type Diff struct {
 	diffText bytes.Buffer
@@ -962,9 +996,10 @@ func (repoDataLoader *RepoDataLoader) loadTags() (tags []*Tag, err error) {
 			oid := repoDataLoader.cache.getOid(ref.Target())
 
 			newTag := &Tag{
-				oid:       oid,
-				name:      ref.Name(),
-				shorthand: ref.Shorthand(),
+				oid:        oid,
+				name:       ref.Name(),
+				shorthand:  ref.Shorthand(),
+				annotation: repoDataLoader.loadTagAnnotation(ref),
 			}
 
 			tags = append(tags, newTag)
@@ -975,6 +1010,30 @@ func (repoDataLoader *RepoDataLoader) loadTags() (tags []*Tag, err error) {
 	return
 }
 
+// loadTagAnnotation loads the tag object the provided tag reference points to.
+// nil is returned for lightweight tags
+func (repoDataLoader *RepoDataLoader) loadTagAnnotation(ref *git.Reference) (annotation *TagAnnotation) {
+	object, err := repoDataLoader.repo.Lookup(ref.Target())
+	if err != nil {
+		log.Errorf("Unable to load object for tag %v: %v", ref.Name(), err)
+		return
+	} else if object.Type() != git.ObjectTag {
+		return
+	}
+
+	rawTag, err := object.AsTag()
+	if err != nil {
+		log.Errorf("Unable to load annotated tag %v: %v", ref.Name(), err)
+		return
+	}
+
+	return &TagAnnotation{
+		tagger:  rawTag.Tagger(),
+		message: rawTag.Message(),
+		target:  repoDataLoader.cache.getOid(rawTag.TargetId()),
+	}
+}
+
 // Commits loads all commits for the provided ref and returns a channel from which the loaded commits can be read
 func (repoDataLoader *RepoDataLoader) Commits(oid *Oid) (<-chan *Commit, error) {
 	revWalk, err := repoDataLoader.repo.Walk()
@@ -1125,6 +1184,25 @@ func (repoDataLoader *RepoDataLoader) AheadBehind(local, upstream *Oid) (ahead,
 	return repoDataLoader.repo.AheadBehind(local.oid, upstream.oid)
 }
 
+// CommitFiles returns the paths of all files in the tree of the provided commit
+func (repoDataLoader *RepoDataLoader) CommitFiles(commit *Commit) (paths []string, err error) {
+	tree, err := commit.commit.Tree()
+	if err != nil {
+		return
+	}
+	defer tree.Free()
+
+	err = tree.Walk(func(parentPath string, entry *git.TreeEntry) int {
+		if entry.Type == git.ObjectBlob {
+			paths = append(paths, parentPath+entry.Name)
+		}
+
+		return 0
+	})
+
+	return
+}
+
 // DiffCommit loads a diff between the commit with the specified oid and its parent
 // If the commit has more than one parent no diff is returned
 func (repoDataLoader *RepoDataLoader) DiffCommit(commit *Commit) (diff *Diff, err error) {
@@ -1171,6 +1249,43 @@ func (repoDataLoader *RepoDataLoader) DiffCommit(commit *Commit) (diff *Diff, er
 	return
 }
 
+// DiffCommits returns the cumulative diff between the trees of the two provided commits
+func (repoDataLoader *RepoDataLoader) DiffCommits(from, to *Commit) (diff *Diff, err error) {
+	if repoDataLoader.diffErrorPresent {
+		return repoDataLoader.generateCommitsDiffUsingCLI(from, to)
+	}
+
+	var fromTree, toTree *git.Tree
+	if fromTree, err = from.commit.Tree(); err != nil {
+		return
+	}
+	defer fromTree.Free()
+
+	if toTree, err = to.commit.Tree(); err != nil {
+		return
+	}
+	defer toTree.Free()
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	commitsDiff, err := repoDataLoader.repo.DiffTreeToTree(fromTree, toTree, &options)
+	if err != nil {
+		return
+	}
+	defer commitsDiff.Free()
+
+	if diff, err = repoDataLoader.generateDiff(commitsDiff); err != nil && diffErrorRegex.MatchString(err.Error()) {
+		log.Infof("Falling back to git cli after encountering error: %v", err)
+		repoDataLoader.diffErrorPresent = true
+		return repoDataLoader.generateCommitsDiffUsingCLI(from, to)
+	}
+
+	return
+}
+
 // DiffStage returns a diff for all files in the provided stage
 func (repoDataLoader *RepoDataLoader) DiffStage(statusType StatusType) (diff *Diff, err error) {
 	if repoDataLoader.diffErrorPresent {
@@ -1375,6 +1490,7 @@ const (
 	dtCommit diffType = iota
 	dtStage
 	dtFile
+	dtCommits
 )
 
 func (repoDataLoader *RepoDataLoader) generateCommitDiffUsingCLI(commit *Commit) (diff *Diff, err error) {
@@ -1383,6 +1499,12 @@ func (repoDataLoader *RepoDataLoader) generateCommitDiffUsingCLI(commit *Commit)
 	return repoDataLoader.runGitCLIDiff(gitCommand, dtCommit)
 }
 
+func (repoDataLoader *RepoDataLoader) generateCommitsDiffUsingCLI(from, to *Commit) (diff *Diff, err error) {
+	log.Debugf("Attempting to load diff using cli between commits %v and %v", from.oid.String(), to.oid.String())
+	gitCommand := []string{"diff", "--encoding=UTF8", "--patch-with-stat", "--no-color", from.oid.String(), to.oid.String()}
+	return repoDataLoader.runGitCLIDiff(gitCommand, dtCommits)
+}
+
 func (repoDataLoader *RepoDataLoader) generateFileDiffUsingCLI(statusType StatusType, path string) (diff *Diff, err error) {
 	log.Debugf("Attempting to load diff using cli for StatusType: %v and file: %v", StatusTypeDisplayName(statusType), path)
 
@@ -1534,6 +1656,153 @@ func (repoDataLoader *RepoDataLoader) UserEditor() (editor string, err error) {
 	return
 }
 
+// UserSignature returns the name and email git is configured to use in the format "Name <email>"
+func (repoDataLoader *RepoDataLoader) UserSignature() (signature string, err error) {
+	config, err := repoDataLoader.repo.Config()
+	if err != nil {
+		err = fmt.Errorf("Unable to retrieve git config: %v", err)
+		return
+	}
+
+	name, _ := config.LookupString("user.name")
+	email, _ := config.LookupString("user.email")
+
+	if name == "" || email == "" {
+		err = fmt.Errorf("Both user.name and user.email must be configured")
+		return
+	}
+
+	signature = fmt.Sprintf("%v <%v>", name, email)
+
+	return
+}
+
+// Signature extracts the signature and the data it signs from the commit or annotated tag with the provided oid.
+// An empty signature is returned if the object is not signed
+func (repoDataLoader *RepoDataLoader) Signature(oid *Oid) (signature, signedData string, err error) {
+	object, err := repoDataLoader.repo.Lookup(oid.oid)
+	if err != nil {
+		return
+	}
+
+	switch object.Type() {
+	case git.ObjectCommit:
+		var commit *git.Commit
+		if commit, err = object.AsCommit(); err != nil {
+			return
+		}
+
+		if signature, signedData, err = commit.ExtractSignature(); err != nil {
+			if gitError, isGitError := err.(*git.GitError); isGitError && gitError.Code == git.ErrNotFound {
+				err = nil
+			}
+		}
+	case git.ObjectTag:
+		var odb *git.Odb
+		if odb, err = repoDataLoader.repo.Odb(); err != nil {
+			return
+		}
+
+		var odbObject *git.OdbObject
+		if odbObject, err = odb.Read(oid.oid); err != nil {
+			return
+		}
+		defer odbObject.Free()
+
+		data := string(odbObject.Data())
+
+		if location := tagSignatureStartRegex.FindStringIndex(data); location != nil {
+			signedData = data[:location[0]]
+			signature = data[location[0]:]
+		}
+	}
+
+	return
+}
+
+// VerifySignature runs the configured verification command to check the provided signature.
+// The command is passed the path of a file containing the signature followed by "-" and reads the signed data from stdin
+func (repoDataLoader *RepoDataLoader) VerifySignature(signature, signedData string) (verification *SignatureVerification) {
+	verification = &SignatureVerification{status: SignatureUnknown}
+
+	verifyCommand := repoDataLoader.config.GetString(CfSignatureVerifyCommand)
+	if verifyCommand == "" {
+		return
+	}
+
+	signatureFile, err := ioutil.TempFile("", "grv-signature-")
+	if err != nil {
+		log.Errorf("Unable to create signature file: %v", err)
+		return
+	}
+	defer os.Remove(signatureFile.Name())
+
+	_, err = signatureFile.WriteString(signature)
+	if closeErr := signatureFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		log.Errorf("Unable to write signature file: %v", err)
+		return
+	}
+
+	cmd := exec.Command("/bin/sh", "-c", verifyCommand+` "$@"`, verifyCommand, signatureFile.Name(), "-")
+	cmd.Env, cmd.Dir = repoDataLoader.GenerateGitCommandEnvironment()
+	cmd.Stdin = strings.NewReader(signedData)
+
+	output, err := cmd.CombinedOutput()
+
+	if matches := signerRegex.FindStringSubmatch(string(output)); matches != nil {
+		verification.signer = matches[1] + matches[2]
+	}
+
+	if err == nil {
+		verification.status = SignatureGood
+	} else if exitError, isExitError := err.(*exec.ExitError); isExitError && exitError.Sys().(syscall.WaitStatus).ExitStatus() == 1 {
+		verification.status = SignatureBad
+	} else {
+		log.Debugf("Unable to verify signature using %v: %v", verifyCommand, err)
+	}
+
+	return
+}
+
+// NotesRefs returns the configured notes refs in the order they were specified
+func NotesRefs(config Config) (notesRefs []string) {
+	for _, notesRef := range strings.Split(config.GetString(CfNotesRefs), ",") {
+		if notesRef = strings.TrimSpace(notesRef); notesRef != "" {
+			notesRefs = append(notesRefs, notesRef)
+		}
+	}
+
+	return
+}
+
+// Notes loads the notes attached to the provided oid from each of the configured notes refs
+func (repoDataLoader *RepoDataLoader) Notes(oid *Oid) (notes []*CommitNote, err error) {
+	for _, notesRef := range NotesRefs(repoDataLoader.config) {
+		note, noteErr := repoDataLoader.repo.Notes.Read(notesRef, oid.oid)
+		if noteErr != nil {
+			if gitError, isGitError := noteErr.(*git.GitError); isGitError && gitError.Code == git.ErrNotFound {
+				continue
+			}
+
+			err = fmt.Errorf("Unable to load note for %v from %v: %v", oid, notesRef, noteErr)
+			return
+		}
+
+		notes = append(notes, &CommitNote{
+			ref:     notesRef,
+			message: note.Message(),
+		})
+
+		note.Free()
+	}
+
+	return
+}
+
 // GenerateGitCommandEnvironment populates git environment variables for
 // the current repository
 func (repoDataLoader *RepoDataLoader) GenerateGitCommandEnvironment() (env []string, rootDir string) {
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	ssSessionDir    = "sessions"
+	ssFileExtension = ".json"
+	ssVersion       = 1
+)
+
+// SessionState contains the layout of all tabs and the state of their views
+type SessionState struct {
+	Version        int                 `json:"version"`
+	RepositoryPath string              `json:"repositoryPath"`
+	ActiveTabIndex uint                `json:"activeTabIndex"`
+	Tabs           []*SessionViewState `json:"tabs"`
+}
+
+// SessionViewState contains the persisted state of a view.
+// Container views store their layout and child views whereas
+// window views store state specific to their type
+type SessionViewState struct {
+	View            string                  `json:"view"`
+	Title           string                  `json:"title,omitempty"`
+	Orientation     ContainerOrientation    `json:"orientation"`
+	ActiveViewIndex uint                    `json:"activeViewIndex"`
+	FullScreen      bool                    `json:"fullScreen"`
+	ChildViews      []*SessionViewState     `json:"childViews,omitempty"`
+	CommitView      *CommitViewSessionState `json:"commitView,omitempty"`
+	DiffView        *DiffViewSessionState   `json:"diffView,omitempty"`
+}
+
+// CommitViewSessionState contains the persisted state of a commit view
+type CommitViewSessionState struct {
+	Ref         string   `json:"ref"`
+	Filters     []string `json:"filters,omitempty"`
+	SelectedOid string   `json:"selectedOid,omitempty"`
+}
+
+// DiffViewSessionState contains the persisted state of a diff view
+type DiffViewSessionState struct {
+	Diff           string `json:"diff,omitempty"`
+	ActiveRowIndex uint   `json:"activeRowIndex"`
+}
+
+// SessionStateProvider is a view that is able to save and restore its state
+type SessionStateProvider interface {
+	SessionState() *SessionViewState
+	RestoreSessionState(*SessionViewState) error
+}
+
+// viewID returns the ViewID this state was generated from
+func (sessionViewState *SessionViewState) viewID() (viewID ViewID, exists bool) {
+	viewID, exists = viewIDNames[sessionViewState.View]
+	return
+}
+
+// isContainer returns true if this state was generated by a ContainerView
+func (sessionViewState *SessionViewState) isContainer() bool {
+	viewID, exists := sessionViewState.viewID()
+	return exists && isContainerViewID(viewID)
+}
+
+func isContainerViewID(viewID ViewID) bool {
+	switch viewID {
+	case ViewContainer, ViewHistory, ViewStatus, ViewSummary:
+		return true
+	}
+
+	return false
+}
+
+// isRestorableWindowViewID returns true if the WindowViewFactory is able to create a view with the provided ID
+func isRestorableWindowViewID(viewID ViewID) bool {
+	switch viewID {
+	case ViewRef, ViewCommit, ViewDiff, ViewGitStatus, ViewGRVVariable, ViewRemote:
+		return true
+	}
+
+	return false
+}
+
+// GenerateViewSessionState generates the session state of the provided view.
+// nil is returned if the view cannot be restored
+func GenerateViewSessionState(baseView BaseView) *SessionViewState {
+	if container, ok := baseView.(*WindowViewContainer); ok {
+		if child := container.Child(); child != nil {
+			return GenerateViewSessionState(child)
+		}
+
+		return nil
+	}
+
+	// Compare views are created on demand for a pair of refs and are not restored
+	if baseView.ViewID() == ViewCompare {
+		return nil
+	}
+
+	if provider, ok := baseView.(SessionStateProvider); ok {
+		return provider.SessionState()
+	}
+
+	if viewID := baseView.ViewID(); isRestorableWindowViewID(viewID) {
+		return &SessionViewState{View: ViewName(viewID)}
+	}
+
+	return nil
+}
+
+// SessionStore reads and writes the session state of a repository
+type SessionStore struct {
+	repoPath string
+	filePath string
+}
+
+// NewSessionStore creates a new instance which stores the session
+// state for the provided repository in the grv config directory
+func NewSessionStore(configDir, repoPath string) *SessionStore {
+	hash := sha1.Sum([]byte(repoPath))
+
+	return &SessionStore{
+		repoPath: repoPath,
+		filePath: filepath.Join(configDir, ssSessionDir, hex.EncodeToString(hash[:])+ssFileExtension),
+	}
+}
+
+// FilePath returns the path of the session state file
+func (sessionStore *SessionStore) FilePath() string {
+	return sessionStore.filePath
+}
+
+// Load reads the session state from disk.
+// A nil session state is returned if none has been stored
+func (sessionStore *SessionStore) Load() (sessionState *SessionState, err error) {
+	data, err := ioutil.ReadFile(sessionStore.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Infof("No session state found at %v", sessionStore.filePath)
+			err = nil
+		}
+
+		return
+	}
+
+	sessionState = &SessionState{}
+	if err = json.Unmarshal(data, sessionState); err != nil {
+		err = fmt.Errorf("Invalid session state file %v: %v", sessionStore.filePath, err)
+		sessionState = nil
+		return
+	}
+
+	if sessionState.Version != ssVersion {
+		log.Infof("Ignoring session state with unsupported version %v", sessionState.Version)
+		sessionState = nil
+	} else if sessionState.RepositoryPath != sessionStore.repoPath {
+		log.Infof("Ignoring session state for repository %v", sessionState.RepositoryPath)
+		sessionState = nil
+	}
+
+	return
+}
+
+// Save writes the session state to disk
+func (sessionStore *SessionStore) Save(sessionState *SessionState) (err error) {
+	sessionState.Version = ssVersion
+	sessionState.RepositoryPath = sessionStore.repoPath
+
+	data, err := json.MarshalIndent(sessionState, "", "\t")
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(filepath.Dir(sessionStore.filePath), 0755); err != nil {
+		return
+	}
+
+	tempFilePath := sessionStore.filePath + ".tmp"
+	if err = ioutil.WriteFile(tempFilePath, data, 0644); err != nil {
+		return
+	}
+
+	if err = os.Rename(tempFilePath, sessionStore.filePath); err != nil {
+		return
+	}
+
+	log.Infof("Saved session state to %v", sessionStore.filePath)
+
+	return
+}
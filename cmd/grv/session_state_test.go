@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSessionStateIsRestoredAfterBeingSaved(t *testing.T) {
+	configDir, err := ioutil.TempDir("", "grv-session")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(configDir)
+
+	sessionStore := NewSessionStore(configDir, "/repo/.git/")
+
+	sessionState := &SessionState{
+		ActiveTabIndex: 1,
+		Tabs: []*SessionViewState{
+			{
+				View:  cfHistoryView,
+				Title: "History View",
+			},
+			{
+				View:        cfContainerView,
+				Title:       "Custom",
+				Orientation: CoHorizontal,
+				ChildViews: []*SessionViewState{
+					{
+						View: cfCommitView,
+						CommitView: &CommitViewSessionState{
+							Ref:         "refs/heads/master",
+							Filters:     []string{`authorname="John Smith"`},
+							SelectedOid: "a3b1c9fe",
+						},
+					},
+					{
+						View: cfDiffView,
+						DiffView: &DiffViewSessionState{
+							Diff:           "a3b1c9fe",
+							ActiveRowIndex: 12,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err = sessionStore.Save(sessionState); err != nil {
+		t.Fatalf("Unable to save session state: %v", err)
+	}
+
+	restoredSessionState, err := sessionStore.Load()
+	if err != nil {
+		t.Fatalf("Unable to load session state: %v", err)
+	}
+
+	if !reflect.DeepEqual(sessionState, restoredSessionState) {
+		t.Errorf("Restored session state does not match saved session state. Expected: %v, Actual: %v", sessionState, restoredSessionState)
+	}
+}
+
+func TestSessionStateIsNotLoadedWhenNoneExists(t *testing.T) {
+	configDir, err := ioutil.TempDir("", "grv-session")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(configDir)
+
+	sessionState, err := NewSessionStore(configDir, "/repo/.git/").Load()
+	if err != nil {
+		t.Errorf("Expected no error but received: %v", err)
+	}
+
+	if sessionState != nil {
+		t.Errorf("Expected no session state but received: %v", sessionState)
+	}
+}
+
+func TestSessionStateIsStoredPerRepository(t *testing.T) {
+	firstSessionStore := NewSessionStore("/config", "/first/.git/")
+	secondSessionStore := NewSessionStore("/config", "/second/.git/")
+
+	if firstSessionStore.FilePath() == secondSessionStore.FilePath() {
+		t.Errorf("Expected different session files for different repositories but both were: %v", firstSessionStore.FilePath())
+	}
+}
+
+func TestChildViewStateIsRestoredToViewOfSameType(t *testing.T) {
+	channels := &MockChannels{}
+	config := &MockConfig{}
+
+	compareView := NewContainerView(channels, config)
+	compareView.SetViewID(ViewCompare)
+	childContainerView := NewContainerView(channels, config)
+
+	containerView := NewContainerView(channels, config)
+	containerView.AddChildViews(compareView, childContainerView)
+
+	sessionViewState := &SessionViewState{
+		View:            ViewName(ViewContainer),
+		Orientation:     CoVertical,
+		ActiveViewIndex: 0,
+		ChildViews: []*SessionViewState{
+			{
+				View:        ViewName(ViewContainer),
+				Orientation: CoHorizontal,
+			},
+		},
+	}
+
+	if err := containerView.RestoreSessionState(sessionViewState); err != nil {
+		t.Fatalf("RestoreSessionState failed with error: %v", err)
+	}
+
+	if compareView.orientation != CoVertical {
+		t.Errorf("Expected compare view without session state to retain its orientation")
+	}
+
+	if childContainerView.orientation != CoHorizontal {
+		t.Errorf("Expected child container view orientation to be restored")
+	}
+
+	if containerView.activeViewIndex != 1 {
+		t.Errorf("Expected active view to be the child container view. Expected: 1, Actual: %v", containerView.activeViewIndex)
+	}
+}
+
+type pendingSelectionRepoData struct {
+	RepoData
+	commitSetState       CommitSetState
+	commits              []*Commit
+	requestedCommitIndex int
+}
+
+func (repoData *pendingSelectionRepoData) CommitSetState(ref Ref) CommitSetState {
+	commitSetState := repoData.commitSetState
+	commitSetState.commitNum = uint(len(repoData.commits))
+	return commitSetState
+}
+
+func (repoData *pendingSelectionRepoData) Commits(ref Ref, startIndex, count uint) (<-chan *Commit, error) {
+	commitCh := make(chan *Commit, len(repoData.commits))
+	for _, commit := range repoData.commits {
+		commitCh <- commit
+	}
+	close(commitCh)
+
+	return commitCh, nil
+}
+
+func (repoData *pendingSelectionRepoData) CommitByIndex(ref Ref, index uint) (*Commit, error) {
+	repoData.requestedCommitIndex = int(index)
+	return nil, fmt.Errorf("Commit rendering is not supported")
+}
+
+func TestRestoredCommitIsSelectedWhenLoadedAfterFilteredCommitsUpdated(t *testing.T) {
+	ref := &LocalBranch{abstractBranch: &abstractBranch{oid: testOid(testC4), name: "refs/heads/master", shorthand: "master"}}
+	repoData := &pendingSelectionRepoData{
+		commitSetState: CommitSetState{
+			loading:     true,
+			filterState: &CommitSetFilterState{filtersApplied: 1},
+		},
+		commits:              []*Commit{{oid: testOid(testC4)}},
+		requestedCommitIndex: -1,
+	}
+
+	channels := &MockChannels{}
+	channels.On("ReportStatus", mock.Anything, mock.Anything).Return()
+	channels.On("UpdateDisplay").Return()
+
+	commitView := &CommitView{
+		channels:  channels,
+		repoData:  repoData,
+		activeRef: ref,
+		refViewData: map[string]*referenceViewData{
+			ref.Name(): {viewPos: NewViewPosition()},
+		},
+		pendingSelectedOid: testC2,
+	}
+
+	commitView.OnCommitsUpdated(ref)
+
+	if commitView.pendingSelectedOid != testC2 {
+		t.Fatalf("Expected commit %v to remain pending selection while commits are loading", testC2)
+	}
+
+	repoData.commits = append(repoData.commits, &Commit{oid: testOid(testC3)}, &Commit{oid: testOid(testC2)})
+	repoData.commitSetState.loading = false
+	commitView.OnCommitsLoaded(ref)
+
+	if commitView.pendingSelectedOid != "" {
+		t.Errorf("Expected no commit to be pending selection but found %v", commitView.pendingSelectedOid)
+	}
+
+	if repoData.requestedCommitIndex != 2 {
+		t.Errorf("Expected commit at index 2 to be selected but index %v was selected", repoData.requestedCommitIndex)
+	}
+}
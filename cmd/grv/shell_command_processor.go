@@ -100,7 +100,7 @@ func NewShellCommandProcessor(channels Channels, variables GRVVariableGetter, co
 func (processor *ShellCommandProcessor) Execute() {
 	processor.lock.Lock()
 
-	go func() {
+	pendingBackgroundWork.Go(func() {
 		defer processor.lock.Unlock()
 
 		log.Debugf("Processing command: %v", string(processor.command))
@@ -109,7 +109,7 @@ func (processor *ShellCommandProcessor) Execute() {
 		command := processor.replaceReferences()
 		log.Debugf("Executing command: %v", command)
 		processor.executeCommand(command)
-	}()
+	})
 }
 
 func (processor *ShellCommandProcessor) findReferences() {
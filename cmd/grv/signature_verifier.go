@@ -0,0 +1,162 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	svRequestChannelSize = 256
+)
+
+// SignatureStatus describes the result of verifying the signature of a commit or tag
+type SignatureStatus int
+
+// The set of signature statuses
+const (
+	SignatureNone SignatureStatus = iota
+	SignatureGood
+	SignatureBad
+	SignatureUnknown
+)
+
+var signatureStatusNames = map[SignatureStatus]string{
+	SignatureNone:    "none",
+	SignatureGood:    "good",
+	SignatureBad:     "bad",
+	SignatureUnknown: "unknown",
+}
+
+// String returns the name of the signature status
+func (signatureStatus SignatureStatus) String() string {
+	return signatureStatusNames[signatureStatus]
+}
+
+// SignatureVerification contains the result of verifying the signature of a commit or tag
+type SignatureVerification struct {
+	status SignatureStatus
+	signer string
+}
+
+// Status returns the result of verifying the signature
+func (verification *SignatureVerification) Status() SignatureStatus {
+	return verification.status
+}
+
+// Signer returns the signer reported by the verification command (if any)
+func (verification *SignatureVerification) Signer() string {
+	return verification.signer
+}
+
+// signatureVerifier verifies signatures on demand and caches the results.
+// Verifications requested without waiting are performed by a background go routine
+// which is started when the first such request is made
+type signatureVerifier struct {
+	repoDataLoader *RepoDataLoader
+	channels       Channels
+	verifications  map[string]*SignatureVerification
+	pending        map[string]bool
+	requestCh      chan *Oid
+	startOnce      sync.Once
+	lock           sync.Mutex
+}
+
+func newSignatureVerifier(repoDataLoader *RepoDataLoader, channels Channels) *signatureVerifier {
+	return &signatureVerifier{
+		repoDataLoader: repoDataLoader,
+		channels:       channels,
+		verifications:  map[string]*SignatureVerification{},
+		pending:        map[string]bool{},
+		requestCh:      make(chan *Oid, svRequestChannelSize),
+	}
+}
+
+// verification returns the cached verification for the provided oid. If no verification
+// is cached then a verification is requested in the background and verified is false
+func (signatureVerifier *signatureVerifier) verification(oid *Oid) (verification *SignatureVerification, verified bool) {
+	signatureVerifier.lock.Lock()
+	defer signatureVerifier.lock.Unlock()
+
+	if verification, verified = signatureVerifier.verifications[oid.String()]; verified || signatureVerifier.pending[oid.String()] {
+		return
+	}
+
+	signatureVerifier.startOnce.Do(func() {
+		go signatureVerifier.processRequests()
+	})
+
+	pendingBackgroundWork.Start()
+
+	select {
+	case signatureVerifier.requestCh <- oid:
+		signatureVerifier.pending[oid.String()] = true
+	default:
+		pendingBackgroundWork.Complete()
+		log.Debugf("Signature verification request queue is full. Dropping request for %v", oid)
+	}
+
+	return
+}
+
+// verify returns the verification for the provided oid, verifying the signature if it is not cached
+func (signatureVerifier *signatureVerifier) verify(oid *Oid) *SignatureVerification {
+	signatureVerifier.lock.Lock()
+	verification, verified := signatureVerifier.verifications[oid.String()]
+	signatureVerifier.lock.Unlock()
+
+	if verified {
+		return verification
+	}
+
+	verification = signatureVerifier.verifySignature(oid)
+
+	signatureVerifier.lock.Lock()
+	defer signatureVerifier.lock.Unlock()
+
+	signatureVerifier.verifications[oid.String()] = verification
+
+	return verification
+}
+
+func (signatureVerifier *signatureVerifier) verifySignature(oid *Oid) *SignatureVerification {
+	signature, signedData, err := signatureVerifier.repoDataLoader.Signature(oid)
+	if err != nil {
+		log.Errorf("Unable to extract signature for %v: %v", oid, err)
+		return &SignatureVerification{status: SignatureUnknown}
+	} else if signature == "" {
+		return &SignatureVerification{status: SignatureNone}
+	}
+
+	return signatureVerifier.repoDataLoader.VerifySignature(signature, signedData)
+}
+
+func (signatureVerifier *signatureVerifier) processRequests() {
+	for oid := range signatureVerifier.requestCh {
+		if signatureVerifier.channels.Exit() {
+			return
+		}
+
+		verification := signatureVerifier.verify(oid)
+		log.Debugf("Signature status for %v: %v", oid, verification.status)
+
+		signatureVerifier.lock.Lock()
+		delete(signatureVerifier.pending, oid.String())
+		signatureVerifier.lock.Unlock()
+
+		if verification.status != SignatureNone {
+			signatureVerifier.channels.UpdateDisplay()
+		}
+
+		pendingBackgroundWork.Complete()
+	}
+}
+
+// onConfigVariableChange discards cached verifications when the verification command changes
+func (signatureVerifier *signatureVerifier) onConfigVariableChange(configVariable ConfigVariable) {
+	signatureVerifier.lock.Lock()
+	defer signatureVerifier.lock.Unlock()
+
+	signatureVerifier.verifications = map[string]*SignatureVerification{}
+	signatureVerifier.channels.UpdateDisplay()
+}
@@ -17,6 +17,7 @@ const (
 	FilterPromptText        = "query: "
 	BranchNamePromptText    = "branch name: "
 	TagNamePromptText       = "tag name: "
+	FuzzyFinderPromptText   = "find: "
 )
 
 type promptType int
@@ -29,6 +30,7 @@ const (
 	ptQuestion
 	ptBranchName
 	ptTagName
+	ptFuzzyFinder
 )
 
 // StatusBarView manages the display of the status bar
@@ -85,6 +87,8 @@ func (statusBarView *StatusBarView) HandleAction(action Action) (err error) {
 		statusBarView.showRefNamePrompt(action, ptTagName, TagNamePromptText)
 	case ActionCustomPrompt:
 		statusBarView.showCustomPrompt(action)
+	case ActionFuzzyFinderPrompt:
+		statusBarView.showFuzzyFinderPrompt(action)
 	case ActionShowStatus:
 		statusBarView.lock.Lock()
 		defer statusBarView.lock.Unlock()
@@ -145,6 +149,38 @@ func (statusBarView *StatusBarView) showFilterPrompt(action Action) {
 	statusBarView.promptType = ptNone
 }
 
+func (statusBarView *StatusBarView) showFuzzyFinderPrompt(action Action) {
+	var promptListener PromptListener
+
+	for _, arg := range action.Args {
+		if fuzzyFinderPromptArgs, ok := arg.(ActionFuzzyFinderPromptArgs); ok {
+			promptListener = fuzzyFinderPromptArgs.promptListener
+			break
+		}
+	}
+
+	if promptListener == nil {
+		log.Errorf("Expected to find ActionFuzzyFinderPromptArgs arg but found none")
+		return
+	}
+
+	statusBarView.promptType = ptFuzzyFinder
+	input, cancelled := statusBarView.showCancellablePrompt(&PromptArgs{
+		Prompt:   FuzzyFinderPromptText,
+		Listener: promptListener,
+	}, action)
+
+	if cancelled {
+		statusBarView.channels.DoAction(Action{ActionType: ActionRemoveView})
+	} else {
+		// Input may have been provided without the prompt being displayed
+		promptListener.OnPromptInputChanged(input)
+		statusBarView.channels.DoAction(Action{ActionType: ActionSelect})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
 func (statusBarView *StatusBarView) showQuestionPrompt(action Action) {
 	if len(action.Args) == 0 {
 		log.Errorf("Expected to find ActionQuestionPromptArgs arg but found none")
@@ -246,18 +282,33 @@ func (statusBarView *StatusBarView) showCustomPrompt(action Action) {
 }
 
 func (statusBarView *StatusBarView) showPrompt(promptArgs *PromptArgs, action Action) string {
+	input, _ := statusBarView.showCancellablePrompt(promptArgs, action)
+	return input
+}
+
+func (statusBarView *StatusBarView) showCancellablePrompt(promptArgs *PromptArgs, action Action) (input string, cancelled bool) {
+	var inputRecorder func(string, bool)
+
 	for _, arg := range action.Args {
 		if actionPromptArgs, ok := arg.(ActionPromptArgs); ok {
 			if actionPromptArgs.terminated {
-				return actionPromptArgs.keys
+				return actionPromptArgs.keys, false
 			}
 
 			promptArgs.InitialBufferText = actionPromptArgs.keys
+			inputRecorder = actionPromptArgs.inputRecorder
 			break
 		}
 	}
 
-	return Prompt(promptArgs)
+	input = Prompt(promptArgs)
+	cancelled = PromptCancelled()
+
+	if inputRecorder != nil {
+		inputRecorder(input, cancelled)
+	}
+
+	return
 }
 
 // OnStateChange updates the active state of this view
@@ -315,7 +366,11 @@ func (statusBarView *StatusBarView) RenderHelpBar(lineBuilder *LineBuilder) (err
 
 	switch statusBarView.promptType {
 	case ptCommand:
-		message = "Enter a command"
+		if completionMatches := PromptCompletionMatches(); len(completionMatches) > 0 {
+			message = strings.Join(completionMatches, "  ")
+		} else {
+			message = "Enter a command"
+		}
 	case ptSearch:
 		message = "Enter a regex pattern"
 	case ptFilter:
@@ -326,6 +381,8 @@ func (statusBarView *StatusBarView) RenderHelpBar(lineBuilder *LineBuilder) (err
 		message = "Enter the new branch name"
 	case ptTagName:
 		message = "Enter the new tag name"
+	case ptFuzzyFinder:
+		message = "Enter a query"
 	}
 
 	if message != "" {
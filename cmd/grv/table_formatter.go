@@ -39,6 +39,7 @@ type CellRendererListener interface {
 type TableFormatter struct {
 	config                Config
 	maxColWidths          []uint
+	fixedColWidths        []uint
 	headers               []TableHeader
 	gridLines             bool
 	borderColWidth        uint
@@ -50,6 +51,7 @@ type TableFormatter struct {
 func NewTableFormatter(cols uint, config Config) *TableFormatter {
 	return &TableFormatter{
 		maxColWidths:          make([]uint, cols),
+		fixedColWidths:        make([]uint, cols),
 		cellRendererListeners: make(map[uint]CellRendererListener),
 		borderColWidth:        1,
 		config:                config,
@@ -74,6 +76,19 @@ func (tableFormatter *TableFormatter) SetCellRendererListener(colIndex uint, cel
 	return
 }
 
+// SetColumnWidth fixes the width of a column. Cell text exceeding the width is truncated.
+// A width of 0 restores the default behaviour of sizing the column to fit its content
+func (tableFormatter *TableFormatter) SetColumnWidth(colIndex, width uint) (err error) {
+	if colIndex >= tableFormatter.cols() {
+		return fmt.Errorf("Cannot set width of column with index: %v", colIndex)
+	}
+
+	tableFormatter.fixedColWidths[colIndex] = width
+	tableFormatter.maxColWidths[colIndex] = width
+
+	return
+}
+
 // Rows returns the number of rows in the table formatter
 func (tableFormatter *TableFormatter) Rows() uint {
 	return uint(len(tableFormatter.cells))
@@ -424,6 +439,10 @@ func (tableFormatter *TableFormatter) PadCells(border bool) (err error) {
 			width := tableFormatter.cellTextWidth(uint(rowIndex), uint(colIndex), column)
 			maxColWidth := tableFormatter.maxColWidths[colIndex]
 
+			if width > maxColWidth {
+				width = tableFormatter.truncateCell(uint(rowIndex), uint(colIndex), column, maxColWidth)
+			}
+
 			if width < maxColWidth {
 				if err = tableFormatter.AppendToCell(uint(rowIndex), uint(colIndex), strings.Repeat(" ", int(maxColWidth-width))); err != nil {
 					return
@@ -450,6 +469,11 @@ func (tableFormatter *TableFormatter) determineMaxColWidths(border bool) {
 			column += tableFormatter.separatorWidth()
 		}
 
+		if tableFormatter.fixedColWidths[colIndex] > 0 {
+			tableFormatter.maxColWidths[colIndex] = tableFormatter.fixedColWidths[colIndex]
+			continue
+		}
+
 		if tableFormatter.hasHeaders() {
 			width := tableFormatter.textWidth(tableFormatter.headers[colIndex].text, column)
 
@@ -481,6 +505,28 @@ func (tableFormatter *TableFormatter) cellTextWidth(rowIndex, colIndex uint, col
 	return
 }
 
+func (tableFormatter *TableFormatter) truncateCell(rowIndex, colIndex, column, maxWidth uint) (width uint) {
+	tableCell := &tableFormatter.cells[rowIndex][colIndex]
+
+	for entryIndex := range tableCell.textEntries {
+		textEntry := &tableCell.textEntries[entryIndex]
+
+		for byteIndex, codePoint := range textEntry.text {
+			codePointWidth := tableFormatter.textWidth(string(codePoint), column+width)
+
+			if width+codePointWidth > maxWidth {
+				textEntry.text = textEntry.text[:byteIndex]
+				tableCell.textEntries = tableCell.textEntries[:entryIndex+1]
+				return
+			}
+
+			width += codePointWidth
+		}
+	}
+
+	return
+}
+
 func (tableFormatter *TableFormatter) textWidth(text string, column uint) (width uint) {
 	for _, codePoint := range text {
 		renderedCodePoints := DetermineRenderedCodePoint(codePoint, column, tableFormatter.config)
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+type terminalWindowSize struct {
+	rows   uint16
+	cols   uint16
+	xPixel uint16
+	yPixel uint16
+}
+
+// TerminalDimension returns the size of the terminal attached to the provided file descriptor.
+// This can be determined before the UI has been initialised
+func TerminalDimension(fd uintptr) (viewDimension ViewDimension, err error) {
+	var windowSize terminalWindowSize
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&windowSize))); errno != 0 {
+		err = errno
+		return
+	}
+
+	viewDimension = ViewDimension{
+		rows: uint(windowSize.rows),
+		cols: uint(windowSize.cols),
+	}
+
+	return
+}
@@ -28,9 +28,12 @@ const (
 
 	CmpCommitviewTitle
 	CmpCommitviewFooter
+	CmpCommitviewOid
 	CmpCommitviewShortOid
 	CmpCommitviewDate
 	CmpCommitviewAuthor
+	CmpCommitviewCommitter
+	CmpCommitviewCommitterDate
 	CmpCommitviewSummary
 	CmpCommitviewTag
 	CmpCommitviewLocalBranch
@@ -44,6 +47,9 @@ const (
 	CmpCommitviewGraphBranch5
 	CmpCommitviewGraphBranch6
 	CmpCommitviewGraphBranch7
+	CmpCommitviewSignatureGood
+	CmpCommitviewSignatureBad
+	CmpCommitviewSignatureUnknown
 
 	CmpDiffviewTitle
 	CmpDiffviewFooter
@@ -52,7 +58,10 @@ const (
 	CmpDiffviewDifflineDiffCommitAuthorDate
 	CmpDiffviewDifflineDiffCommitCommitter
 	CmpDiffviewDifflineDiffCommitCommitterDate
+	CmpDiffviewDifflineDiffCommitSignature
 	CmpDiffviewDifflineDiffCommitMessage
+	CmpDiffviewDifflineDiffCommitNote
+	CmpDiffviewDifflineDiffTag
 	CmpDiffviewDifflineDiffStatsFile
 	CmpDiffviewDifflineGitDiffHeader
 	CmpDiffviewDifflineGitDiffExtendedHeader
@@ -136,6 +145,45 @@ const (
 	CmpSummaryViewUnstagedFile
 	CmpSummaryViewNoModifiedFiles
 
+	CmpKeyHintViewTitle
+	CmpKeyHintViewKey
+	CmpKeyHintViewDescription
+
+	CmpMacroViewTitle
+	CmpMacroViewRegister
+	CmpMacroViewKeys
+	CmpMacroViewFooter
+
+	CmpFuzzyFinderViewTitle
+	CmpFuzzyFinderViewType
+	CmpFuzzyFinderViewCandidate
+	CmpFuzzyFinderViewMatch
+	CmpFuzzyFinderViewFooter
+
+	CmpCommitMessageViewTitle
+	CmpCommitMessageViewSummary
+	CmpCommitMessageViewSummaryOverflow
+	CmpCommitMessageViewBody
+	CmpCommitMessageViewTrailer
+	CmpCommitMessageViewFooter
+
+	CmpCommitRangeViewTitle
+	CmpCommitRangeViewHeader
+	CmpCommitRangeViewShortOid
+	CmpCommitRangeViewDate
+	CmpCommitRangeViewAuthor
+	CmpCommitRangeViewSummary
+	CmpCommitRangeViewFooter
+
+	CmpCommitInfoViewTitle
+	CmpCommitInfoViewHeader
+	CmpCommitInfoViewLabel
+	CmpCommitInfoViewValue
+	CmpCommitInfoViewLocalBranch
+	CmpCommitInfoViewRemoteBranch
+	CmpCommitInfoViewTag
+	CmpCommitInfoViewFooter
+
 	CmpCount
 )
 
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	tfThemesDir          = "/themes"
+	tfThemeFileExtension = ".grvtheme"
+)
+
+var isThemeNameCharacters = regexp.MustCompile(`^[[:alnum:]][[:alnum:]_.-]*$`).MatchString
+
+// isValidThemeName returns true if the theme name can be used as a file name in the themes directory
+// and written as a single word in a theme command
+func isValidThemeName(themeName string) bool {
+	return isThemeNameCharacters(themeName) && !strings.Contains(themeName, "..")
+}
+
+// ThemesDir returns the directory theme files are loaded from
+func (config *Configuration) ThemesDir() string {
+	if config.grvConfigDir == "" {
+		return ""
+	}
+
+	return config.grvConfigDir + tfThemesDir
+}
+
+// LoadThemeFiles loads all theme files in the themes directory of the config directory
+func (config *Configuration) LoadThemeFiles() (errs []error) {
+	themesDir := config.ThemesDir()
+	if themesDir == "" {
+		return
+	}
+
+	fileInfos, err := ioutil.ReadDir(themesDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Errorf("Unable to read themes directory %v: %v", themesDir, err)
+		}
+
+		return
+	}
+
+	var filePaths []string
+	for _, fileInfo := range fileInfos {
+		if !fileInfo.IsDir() && filepath.Ext(fileInfo.Name()) == tfThemeFileExtension {
+			filePaths = append(filePaths, filepath.Join(themesDir, fileInfo.Name()))
+		}
+	}
+
+	sort.Strings(filePaths)
+
+	for _, filePath := range filePaths {
+		errs = append(errs, config.LoadThemeFile(filePath)...)
+	}
+
+	return
+}
+
+// LoadThemeFile loads the theme file at the provided file path
+func (config *Configuration) LoadThemeFile(filePath string) []error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		log.Errorf("Unable to open theme file %v for reading: %v", filePath, err)
+		return []error{err}
+	}
+
+	defer file.Close()
+
+	return config.LoadThemeReader(file, filePath)
+}
+
+// LoadThemeReader loads theme commands from the provided reader.
+// Theme files may only contain theme commands
+func (config *Configuration) LoadThemeReader(reader io.Reader, inputSource string) (errs []error) {
+	log.Infof("Loading theme file %v", inputSource)
+
+	config.loadedFiles[filepath.Clean(inputSource)] = true
+	parser := NewConfigParser(reader, inputSource)
+
+	for {
+		command, eof, err := parser.Parse()
+
+		switch {
+		case err != nil:
+			errs = append(errs, err)
+		case eof:
+			return
+		case command != nil:
+			switch command := command.(type) {
+			case *ThemeCommand:
+				err = config.processThemeCommand(command, inputSource)
+			default:
+				err = fmt.Errorf("%v: Only %v commands are permitted in theme files", inputSource, themeCommand)
+			}
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+}
+
+// ExportTheme writes the active theme to a theme file containing a theme definition with the provided name.
+// If no file path is provided then the theme is written to the themes directory.
+// The exported theme can be activated using the provided name without reloading config
+func (config *Configuration) ExportTheme(themeName, filePath string) (err error) {
+	if !isValidThemeName(themeName) {
+		return fmt.Errorf("Invalid theme name: %v. Theme names must start with a letter or digit "+
+			"and may only contain letters, digits, _, - and single dots", themeName)
+	}
+
+	if filePath == "" {
+		themesDir := config.ThemesDir()
+		if themesDir == "" {
+			return fmt.Errorf("Unable to determine config directory")
+		}
+
+		if err = os.MkdirAll(themesDir, 0755); err != nil {
+			return
+		}
+
+		filePath = filepath.Join(themesDir, themeName+tfThemeFileExtension)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	theme := config.GetTheme()
+	writer := bufio.NewWriter(file)
+
+	if err = WriteTheme(writer, themeName, theme); err != nil {
+		return
+	}
+
+	if err = writer.Flush(); err != nil {
+		return
+	}
+
+	exportedTheme := NewTheme()
+	for themeComponentID, themeComponent := range theme.GetAllComponents() {
+		*exportedTheme.CreateOrGetComponent(themeComponentID) = themeComponent
+	}
+
+	config.themes[themeName] = exportedTheme
+
+	log.Infof("Exported theme %v to %v", themeName, filePath)
+
+	return
+}
+
+// WriteTheme writes the provided theme as a sequence of theme commands defining a theme with the provided name
+func WriteTheme(writer io.Writer, themeName string, theme Theme) (err error) {
+	componentNames := ThemeComponentNames()
+	componentNameWidth := 0
+
+	for _, componentName := range componentNames {
+		componentNameWidth = MaxInt(componentNameWidth, len(componentName))
+	}
+
+	for _, componentName := range componentNames {
+		themeComponent := theme.GetComponent(themeComponents[componentName])
+
+		if _, err = fmt.Fprintf(writer, "%v --name %v --component %-*v --bgcolor %v --fgcolor %v\n",
+			themeCommand, themeName, componentNameWidth, componentName,
+			themeColorConfigValue(themeComponent.bgcolor), themeColorConfigValue(themeComponent.fgcolor)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// themeColorConfigValue returns the value used to specify the provided color in a theme command
+func themeColorConfigValue(themeColor ThemeColor) string {
+	switch themeColor := themeColor.(type) {
+	case *SystemColor:
+		for systemColorName, systemColorValue := range systemColorValues {
+			if systemColorValue == themeColor.systemColorValue {
+				return systemColorName
+			}
+		}
+	case *ColorNumber:
+		return fmt.Sprintf("%v", themeColor.number)
+	case *RGBColor:
+		return fmt.Sprintf("%02x%02x%02x", themeColor.red, themeColor.green, themeColor.blue)
+	}
+
+	return "None"
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWrittenThemeCanBeLoadedFromThemeFile(t *testing.T) {
+	theme := NewSolarizedTheme()
+	rgbComponent := theme.CreateOrGetComponent(CmpCommitviewAuthor)
+	rgbComponent.fgcolor = NewRGBColor(0xf1, 0x4a, 0x08)
+
+	var buffer bytes.Buffer
+	if err := WriteTheme(&buffer, "mytheme", theme); err != nil {
+		t.Fatalf("WriteTheme failed: %v", err)
+	}
+
+	config := NewConfiguration(&MockKeyBindings{}, &MockChannels{}, &MockGRVVariableSetter{}, &MockInputConsumer{})
+
+	if errs := config.LoadThemeReader(&buffer, "mytheme.grvtheme"); len(errs) > 0 {
+		t.Fatalf("LoadThemeReader failed with errors: %v", errs)
+	}
+
+	loadedTheme, exists := config.themes["mytheme"]
+	if !exists {
+		t.Fatalf("Expected theme mytheme to be defined")
+	}
+
+	for themeComponentID, themeComponent := range theme.GetAllComponents() {
+		checkThemeComponent(themeComponent, loadedTheme.GetComponent(themeComponentID), t)
+	}
+}
+
+func TestThemeFilesCanOnlyContainThemeCommands(t *testing.T) {
+	config := NewConfiguration(&MockKeyBindings{}, &MockChannels{}, &MockGRVVariableSetter{}, &MockInputConsumer{})
+
+	themeFile := strings.Join([]string{
+		"theme --name mytheme --component CommitView.Date --bgcolor None --fgcolor Red",
+		"set mouse true",
+		"!rm -rf ~",
+	}, "\n")
+
+	if errs := config.LoadThemeReader(strings.NewReader(themeFile), "mytheme.grvtheme"); len(errs) != 2 {
+		t.Errorf("Expected 2 errors but found: %v", errs)
+	}
+
+	if config.GetBool(CfMouse) {
+		t.Errorf("Expected set command to be ignored in theme file")
+	}
+
+	if _, exists := config.themes["mytheme"]; !exists {
+		t.Errorf("Expected theme mytheme to be defined")
+	}
+}
+
+func TestThemeIsNotExportedWithInvalidName(t *testing.T) {
+	configDir, err := ioutil.TempDir("", "grv-theme-file")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(configDir)
+
+	config := NewConfiguration(&MockKeyBindings{}, &MockChannels{}, &MockGRVVariableSetter{}, &MockInputConsumer{})
+	config.grvConfigDir = configDir
+
+	for _, themeName := range []string{"", "../mytheme", "my/theme", "my theme", "..", ".mytheme", "mytheme\n!rm"} {
+		if err = config.ExportTheme(themeName, ""); err == nil {
+			t.Errorf("Expected theme with name %q not to be exported", themeName)
+		}
+
+		if _, exists := config.themes[themeName]; exists {
+			t.Errorf("Expected theme with name %q not to be defined", themeName)
+		}
+	}
+
+	if fileInfos, err := ioutil.ReadDir(configDir); err != nil || len(fileInfos) > 0 {
+		t.Errorf("Expected config directory to be empty but found %v files: %v", len(fileInfos), err)
+	}
+
+	if err = config.ExportTheme("my-theme.v2", ""); err != nil {
+		t.Errorf("Expected theme with valid name to be exported but received error: %v", err)
+	}
+}
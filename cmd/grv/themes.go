@@ -36,6 +36,10 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
+			CmpCommitviewOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpCommitviewShortOid: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorYellow),
@@ -48,6 +52,14 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorGreen),
 			},
+			CmpCommitviewCommitter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitviewCommitterDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
 			CmpCommitviewSummary: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
@@ -100,6 +112,18 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorWhite),
 			},
+			CmpCommitviewSignatureGood: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitviewSignatureBad: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitviewSignatureUnknown: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpDiffviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
@@ -128,10 +152,22 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorYellow),
 			},
+			CmpDiffviewDifflineDiffCommitSignature: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
 			CmpDiffviewDifflineDiffCommitMessage: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpDiffviewDifflineDiffCommitNote: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpDiffviewDifflineDiffTag: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpDiffviewDifflineDiffStatsFile: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
@@ -464,6 +500,138 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
 			},
+			CmpKeyHintViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpKeyHintViewKey: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpKeyHintViewDescription: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpMacroViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpMacroViewRegister: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpMacroViewKeys: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpMacroViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpFuzzyFinderViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpFuzzyFinderViewType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpFuzzyFinderViewCandidate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpFuzzyFinderViewMatch: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpFuzzyFinderViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitMessageViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitMessageViewSummary: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitMessageViewSummaryOverflow: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitMessageViewBody: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpCommitMessageViewTrailer: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitMessageViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitRangeViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitRangeViewHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitRangeViewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitRangeViewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitRangeViewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitRangeViewSummary: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpCommitRangeViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitInfoViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitInfoViewHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitInfoViewLabel: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitInfoViewValue: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpCommitInfoViewLocalBranch: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitInfoViewRemoteBranch: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitInfoViewTag: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitInfoViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
 		},
 	}
 }
@@ -524,6 +692,10 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(solarizedCyan),
 			},
+			CmpCommitviewOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedYellow),
+			},
 			CmpCommitviewShortOid: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(solarizedYellow),
@@ -536,6 +708,14 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(solarizedGreen),
 			},
+			CmpCommitviewCommitter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedGreen),
+			},
+			CmpCommitviewCommitterDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedBlue),
+			},
 			CmpCommitviewSummary: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
@@ -588,6 +768,18 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(solarizedWhite),
 			},
+			CmpCommitviewSignatureGood: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedGreen),
+			},
+			CmpCommitviewSignatureBad: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedRed),
+			},
+			CmpCommitviewSignatureUnknown: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedYellow),
+			},
 			CmpDiffviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(solarizedCyan),
@@ -616,10 +808,22 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(solarizedYellow),
 			},
+			CmpDiffviewDifflineDiffCommitSignature: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedBlue),
+			},
 			CmpDiffviewDifflineDiffCommitMessage: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpDiffviewDifflineDiffCommitNote: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpDiffviewDifflineDiffTag: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedYellow),
+			},
 			CmpDiffviewDifflineDiffStatsFile: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(solarizedBlue),
@@ -952,6 +1156,323 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(solarizedBrightMagenta),
 			},
+			CmpKeyHintViewTitle: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpKeyHintViewKey: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedBlue),
+			},
+			CmpKeyHintViewDescription: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedWhite),
+			},
+			CmpMacroViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpMacroViewRegister: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedMagenta),
+			},
+			CmpMacroViewKeys: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedYellow),
+			},
+			CmpMacroViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpFuzzyFinderViewTitle: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpFuzzyFinderViewType: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedBlue),
+			},
+			CmpFuzzyFinderViewCandidate: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedWhite),
+			},
+			CmpFuzzyFinderViewMatch: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedYellow),
+			},
+			CmpFuzzyFinderViewFooter: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpCommitMessageViewTitle: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpCommitMessageViewSummary: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedYellow),
+			},
+			CmpCommitMessageViewSummaryOverflow: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedRed),
+			},
+			CmpCommitMessageViewBody: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedWhite),
+			},
+			CmpCommitMessageViewTrailer: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedGreen),
+			},
+			CmpCommitMessageViewFooter: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpCommitRangeViewTitle: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpCommitRangeViewHeader: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedMagenta),
+			},
+			CmpCommitRangeViewShortOid: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedYellow),
+			},
+			CmpCommitRangeViewDate: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedBlue),
+			},
+			CmpCommitRangeViewAuthor: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedGreen),
+			},
+			CmpCommitRangeViewSummary: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedWhite),
+			},
+			CmpCommitRangeViewFooter: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpCommitInfoViewTitle: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpCommitInfoViewHeader: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedYellow),
+			},
+			CmpCommitInfoViewLabel: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedBlue),
+			},
+			CmpCommitInfoViewValue: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedWhite),
+			},
+			CmpCommitInfoViewLocalBranch: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
+			CmpCommitInfoViewRemoteBranch: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedMagenta),
+			},
+			CmpCommitInfoViewTag: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedRed),
+			},
+			CmpCommitInfoViewFooter: {
+				bgcolor: NewColorNumber(solarizedBlack),
+				fgcolor: NewColorNumber(solarizedCyan),
+			},
 		},
 	}
 }
+
+// The solarized light theme uses the same accent colors as the solarized theme with the base colors inverted
+var solarizedLightBaseColors = map[int16]int16{
+	solarizedBrightBlack:  solarizedBrightWhite,
+	solarizedBlack:        solarizedWhite,
+	solarizedBrightGreen:  solarizedBrightCyan,
+	solarizedBrightYellow: solarizedBrightBlue,
+	solarizedBrightBlue:   solarizedBrightYellow,
+	solarizedBrightCyan:   solarizedBrightGreen,
+	solarizedWhite:        solarizedBlack,
+	solarizedBrightWhite:  solarizedBrightBlack,
+}
+
+// NewSolarizedLightTheme creates the light variant of the solarized theme
+func NewSolarizedLightTheme() MutableTheme {
+	theme := NewSolarizedTheme()
+
+	invertBaseColor := func(themeColor ThemeColor) ThemeColor {
+		if colorNumber, ok := themeColor.(*ColorNumber); ok {
+			if lightColorNumber, isBaseColor := solarizedLightBaseColors[colorNumber.number]; isBaseColor {
+				return NewColorNumber(lightColorNumber)
+			}
+		}
+
+		return themeColor
+	}
+
+	for themeComponentID := CmpNone + 1; themeComponentID < CmpCount; themeComponentID++ {
+		themeComponent := theme.CreateOrGetComponent(themeComponentID)
+		themeComponent.bgcolor = invertBaseColor(themeComponent.bgcolor)
+		themeComponent.fgcolor = invertBaseColor(themeComponent.fgcolor)
+	}
+
+	return theme
+}
+
+const (
+	highContrastBlack       = 16
+	highContrastWhite       = 231
+	highContrastBrightColor = 8
+)
+
+// NewHighContrastTheme creates a theme based on the classic theme which uses bright
+// colors on a black background. Highlighted components use black text on a bright background
+func NewHighContrastTheme() MutableTheme {
+	theme := NewClassicTheme()
+
+	brightColor := func(themeColor ThemeColor, defaultColorNumber int16) ThemeColor {
+		if systemColor, ok := themeColor.(*SystemColor); ok && systemColor.systemColorValue != ColorNone {
+			return NewColorNumber(highContrastBrightColor + int16(systemColor.systemColorValue-ColorBlack))
+		}
+
+		return NewColorNumber(defaultColorNumber)
+	}
+
+	for themeComponentID := CmpNone + 1; themeComponentID < CmpCount; themeComponentID++ {
+		themeComponent := theme.CreateOrGetComponent(themeComponentID)
+
+		if systemColor, ok := themeComponent.bgcolor.(*SystemColor); ok && systemColor.systemColorValue == ColorNone {
+			themeComponent.bgcolor = NewColorNumber(highContrastBlack)
+			themeComponent.fgcolor = brightColor(themeComponent.fgcolor, highContrastWhite)
+		} else {
+			themeComponent.bgcolor = brightColor(themeComponent.bgcolor, highContrastBlack)
+			themeComponent.fgcolor = NewColorNumber(highContrastBlack)
+		}
+	}
+
+	return theme
+}
+
+// Text attributes used to distinguish theme components in the monochrome theme.
+// Components not listed are displayed using the default terminal attributes
+var monochromeStyles = map[ThemeComponentID]ThemeStyleType{
+	CmpAllviewSearchMatch:           TstBold | TstUnderline,
+	CmpAllviewActiveViewSelectedRow: TstBold,
+
+	CmpMainviewActiveView: TstBold,
+	CmpMainviewNormalView: TstReverse,
+
+	CmpRefviewTitle:                TstBold,
+	CmpRefviewLocalBranchesHeader:  TstBold | TstUnderline,
+	CmpRefviewRemoteBranchesHeader: TstBold | TstUnderline,
+	CmpRefviewTagsHeader:           TstBold | TstUnderline,
+	CmpRefviewHead:                 TstBold,
+
+	CmpCommitviewTitle:            TstBold,
+	CmpCommitviewTag:              TstBold,
+	CmpCommitviewLocalBranch:      TstBold,
+	CmpCommitviewRemoteBranch:     TstBold,
+	CmpCommitviewGraphMergeCommit: TstBold,
+	CmpCommitviewSignatureBad:     TstBold | TstReverse,
+
+	CmpDiffviewTitle:                           TstBold,
+	CmpDiffviewDifflineGitDiffHeader:           TstBold,
+	CmpDiffviewDifflineUnifiedDiffHeader:       TstBold,
+	CmpDiffviewDifflineHunkStart:               TstUnderline,
+	CmpDiffviewDifflineLineAdded:               TstBold,
+	CmpDiffviewDifflineLineRemoved:             TstUnderline,
+	CmpDiffviewFancyDiffLineFile:               TstBold,
+	CmpDiffviewFancyDifflineLineAdded:          TstBold,
+	CmpDiffviewFancyDifflineLineRemoved:        TstUnderline,
+	CmpDiffviewFancyDifflineLineAddedChange:    TstBold | TstReverse,
+	CmpDiffviewFancyDifflineLineRemovedChange:  TstUnderline | TstReverse,
+	CmpDiffviewFancyDifflineEmptyLineAdded:     TstReverse,
+	CmpDiffviewFancyDifflineEmptyLineRemoved:   TstReverse,
+	CmpDiffviewFancyDifflineTrailingWhitespace: TstReverse,
+
+	CmpGitStatusStagedTitle:     TstBold | TstUnderline,
+	CmpGitStatusUnstagedTitle:   TstBold | TstUnderline,
+	CmpGitStatusUntrackedTitle:  TstBold | TstUnderline,
+	CmpGitStatusConflictedTitle: TstBold | TstUnderline,
+	CmpGitStatusStagedFile:      TstBold,
+	CmpGitStatusConflictedFile:  TstBold | TstUnderline,
+
+	CmpHelpViewTitle:                      TstBold,
+	CmpHelpViewIndexTitle:                 TstBold,
+	CmpHelpViewSectionTitle:               TstBold,
+	CmpHelpViewSectionSubTitle:            TstBold | TstUnderline,
+	CmpHelpViewSectionCodeBlock:           TstBold,
+	CmpHelpViewSectionTableHeader:         TstBold | TstUnderline,
+	CmpHelpViewSectionTableRowHighlighted: TstReverse,
+
+	CmpHelpbarviewSpecial: TstBold,
+
+	CmpErrorViewTitle:  TstBold,
+	CmpErrorViewErrors: TstBold,
+
+	CmpContextMenuTitle:      TstBold,
+	CmpContextMenuKeyMapping: TstBold,
+
+	CmpCommandOutputTitle:   TstBold,
+	CmpCommandOutputCommand: TstBold,
+	CmpCommandOutputError:   TstBold | TstUnderline,
+	CmpCommandOutputSuccess: TstBold,
+
+	CmpMessageBoxTitle:          TstBold,
+	CmpMessageBoxSelectedButton: TstReverse,
+
+	CmpGRVVariableViewTitle:    TstBold,
+	CmpGRVVariableViewVariable: TstBold,
+
+	CmpRemoteViewTitle: TstBold,
+
+	CmpSummaryViewHeader:       TstBold | TstUnderline,
+	CmpSummaryViewBranchAhead:  TstBold,
+	CmpSummaryViewBranchBehind: TstUnderline,
+	CmpSummaryViewStagedFile:   TstBold,
+	CmpSummaryViewUnstagedFile: TstUnderline,
+
+	CmpKeyHintViewTitle: TstBold,
+	CmpKeyHintViewKey:   TstBold,
+
+	CmpMacroViewTitle:    TstBold,
+	CmpMacroViewRegister: TstBold,
+
+	CmpFuzzyFinderViewTitle: TstBold,
+	CmpFuzzyFinderViewMatch: TstBold | TstUnderline,
+
+	CmpCommitMessageViewTitle:           TstBold,
+	CmpCommitMessageViewSummary:         TstBold,
+	CmpCommitMessageViewSummaryOverflow: TstReverse,
+	CmpCommitMessageViewTrailer:         TstUnderline,
+
+	CmpCommitRangeViewTitle:  TstBold,
+	CmpCommitRangeViewHeader: TstBold | TstUnderline,
+
+	CmpCommitInfoViewTitle:        TstBold,
+	CmpCommitInfoViewHeader:       TstBold | TstUnderline,
+	CmpCommitInfoViewLocalBranch:  TstBold,
+	CmpCommitInfoViewRemoteBranch: TstBold,
+	CmpCommitInfoViewTag:          TstBold,
+}
+
+// NewMonochromeTheme creates a theme which uses the default terminal colors for all components.
+// Components are distinguished using text attributes such as bold, underline and reverse
+func NewMonochromeTheme() MutableTheme {
+	theme := NewTheme()
+
+	for themeComponentID := CmpNone + 1; themeComponentID < CmpCount; themeComponentID++ {
+		themeComponent := theme.CreateOrGetComponent(themeComponentID)
+		themeComponent.style.styleTypes = monochromeStyles[themeComponentID]
+	}
+
+	return theme
+}
@@ -20,4 +20,23 @@ func testThemeHasAllThemeComponentsSet(themeName string, theme Theme, t *testing
 func TestThemesHaveAllThemeComponentsSet(t *testing.T) {
 	testThemeHasAllThemeComponentsSet("solarized", NewSolarizedTheme(), t)
 	testThemeHasAllThemeComponentsSet("classic", NewClassicTheme(), t)
+	testThemeHasAllThemeComponentsSet("solarized-light", NewSolarizedLightTheme(), t)
+	testThemeHasAllThemeComponentsSet("high-contrast", NewHighContrastTheme(), t)
+	testThemeHasAllThemeComponentsSet("monochrome", NewMonochromeTheme(), t)
+}
+
+func TestMonochromeThemeUsesNoColors(t *testing.T) {
+	theme := NewMonochromeTheme()
+
+	for _, themeComponent := range theme.GetAllComponents() {
+		checkThemeComponent(ThemeComponent{
+			bgcolor: NewSystemColor(ColorNone),
+			fgcolor: NewSystemColor(ColorNone),
+			style:   themeComponent.style,
+		}, themeComponent, t)
+	}
+
+	if theme.GetComponent(CmpDiffviewDifflineLineAdded).style == theme.GetComponent(CmpDiffviewDifflineLineRemoved).style {
+		t.Errorf("Expected added and removed lines to be styled differently")
+	}
 }
@@ -99,6 +99,14 @@ var convert256To16Color = []int16{
 	8, 8, 8, 8, 7, 7, 7, 7, 7, 7, 15, 15, 15, 15, 15, 15,
 }
 
+// The red, green and blue components of the 16 basic colors in the xterm palette
+var basicColorComponents = [][3]byte{
+	{0x00, 0x00, 0x00}, {0xcd, 0x00, 0x00}, {0x00, 0xcd, 0x00}, {0xcd, 0xcd, 0x00},
+	{0x00, 0x00, 0xee}, {0xcd, 0x00, 0xcd}, {0x00, 0xcd, 0xcd}, {0xe5, 0xe5, 0xe5},
+	{0x7f, 0x7f, 0x7f}, {0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+	{0x5c, 0x5c, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff}, {0xff, 0xff, 0xff},
+}
+
 var color256Components = []byte{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
 
 var color256GreyComponents = []byte{
@@ -219,6 +227,7 @@ func (ui *NCursesUI) Initialise() (err error) {
 	}
 
 	ui.config.AddOnChangeListener(CfTheme, ui)
+	ui.config.AddOnChangeListener(CfMonochrome, ui)
 	ui.config.AddOnChangeListener(CfMouse, ui)
 
 	read, write, err := os.Pipe()
@@ -644,7 +653,7 @@ func (ui *NCursesUI) onConfigVariableChange(configVariable ConfigVariable) {
 	defer ui.lock.Unlock()
 
 	switch configVariable {
-	case CfTheme:
+	case CfTheme, CfMonochrome:
 		theme := ui.config.GetTheme()
 		ui.initialiseColorPairsFromTheme(theme)
 	case CfMouse:
@@ -725,6 +734,10 @@ func (ui *NCursesUI) getNCursesColor(themeColor ThemeColor) (colorNumber int16)
 	case *ColorNumber:
 		colorNumber = themeColor.number
 	case *RGBColor:
+		if ui.maxColors < 256 {
+			return getNearestBasicColorNumber(themeColor, ui.maxColors)
+		}
+
 		redIndex := getColorComponentIndex(themeColor.red, color256Components)
 		greenIndex := getColorComponentIndex(themeColor.green, color256Components)
 		blueIndex := getColorComponentIndex(themeColor.blue, color256Components)
@@ -761,6 +774,29 @@ func (ui *NCursesUI) getNCursesColor(themeColor ThemeColor) (colorNumber int16)
 	return
 }
 
+// getNearestBasicColorNumber maps an RGB color directly to the closest of the 16 basic colors,
+// or the closest of the first 8 when the terminal supports fewer than 16 colors
+func getNearestBasicColorNumber(rgbColor *RGBColor, maxColors int) (colorNumber int16) {
+	basicColorNum := len(basicColorComponents)
+	if maxColors < basicColorNum {
+		basicColorNum /= 2
+	}
+
+	minDistance := -1
+
+	for basicColorNumber, components := range basicColorComponents[:basicColorNum] {
+		distance := colorDistanceSquared(rgbColor.red, rgbColor.green, rgbColor.blue,
+			components[0], components[1], components[2])
+
+		if minDistance == -1 || distance < minDistance {
+			minDistance = distance
+			colorNumber = int16(basicColorNumber)
+		}
+	}
+
+	return
+}
+
 func getColorComponentIndex(value byte, components []byte) int {
 	low := 0
 	high := len(components) - 1
@@ -789,9 +825,9 @@ func getColorComponentIndex(value byte, components []byte) int {
 }
 
 func colorDistanceSquared(r1, g1, b1, r2, g2, b2 byte) int {
-	return (int(r1) - int(r2)) * (int(r1) - int(r2)) *
-		(int(g1) - int(g2)) * (int(g1) - int(g2)) *
-		(int(b1) - int(b2)) * (int(b1) - int(b2))
+	return (int(r1)-int(r2))*(int(r1)-int(r2)) +
+		(int(g1)-int(g2))*(int(g1)-int(g2)) +
+		(int(b1)-int(b2))*(int(b1)-int(b2))
 }
 
 func fdZero(set *syscall.FdSet) {
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRGBColorsAreMappedToNearestBasicColor(t *testing.T) {
+	colorTests := []struct {
+		rgbColor            *RGBColor
+		maxColors           int
+		expectedColorNumber int16
+	}{
+		{rgbColor: &RGBColor{red: 0x00, green: 0x00, blue: 0x00}, maxColors: 16, expectedColorNumber: 0},
+		{rgbColor: &RGBColor{red: 0xdc, green: 0x32, blue: 0x2f}, maxColors: 16, expectedColorNumber: 1},
+		{rgbColor: &RGBColor{red: 0xff, green: 0x10, blue: 0x10}, maxColors: 16, expectedColorNumber: 9},
+		{rgbColor: &RGBColor{red: 0xff, green: 0x10, blue: 0x10}, maxColors: 8, expectedColorNumber: 1},
+		{rgbColor: &RGBColor{red: 0x85, green: 0x99, blue: 0x00}, maxColors: 8, expectedColorNumber: 3},
+		{rgbColor: &RGBColor{red: 0xfa, green: 0xfa, blue: 0xf0}, maxColors: 16, expectedColorNumber: 15},
+		{rgbColor: &RGBColor{red: 0xfa, green: 0xfa, blue: 0xf0}, maxColors: 8, expectedColorNumber: 7},
+	}
+
+	for _, colorTest := range colorTests {
+		if colorNumber := getNearestBasicColorNumber(colorTest.rgbColor, colorTest.maxColors); colorNumber != colorTest.expectedColorNumber {
+			t.Errorf("Color number does not match expected value for %v with %v colors. Expected: %v, Actual: %v",
+				colorTest.rgbColor, colorTest.maxColors, colorTest.expectedColorNumber, colorNumber)
+		}
+	}
+}
@@ -118,3 +118,37 @@ func TimeWithLocation(oldTime time.Time, location *time.Location) time.Time {
 		oldTime.Nanosecond(),
 		location)
 }
+
+// RelativeTime returns a human readable description of how long before now the provided time occurred
+func RelativeTime(dateTime, now time.Time) string {
+	seconds := int64(now.Sub(dateTime).Seconds())
+
+	if seconds < 0 {
+		return "in the future"
+	}
+
+	var units = []struct {
+		name    string
+		seconds int64
+	}{
+		{name: "year", seconds: 365 * 24 * 60 * 60},
+		{name: "month", seconds: 30 * 24 * 60 * 60},
+		{name: "week", seconds: 7 * 24 * 60 * 60},
+		{name: "day", seconds: 24 * 60 * 60},
+		{name: "hour", seconds: 60 * 60},
+		{name: "minute", seconds: 60},
+		{name: "second", seconds: 1},
+	}
+
+	for _, unit := range units {
+		if count := seconds / unit.seconds; count > 0 {
+			if count == 1 {
+				return fmt.Sprintf("1 %v ago", unit.name)
+			}
+
+			return fmt.Sprintf("%v %vs ago", count, unit.name)
+		}
+	}
+
+	return "just now"
+}
@@ -293,3 +293,49 @@ func TestTimeWithLocation(t *testing.T) {
 		}
 	}
 }
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2018, 07, 12, 20, 25, 45, 0, time.UTC)
+
+	var tests = []struct {
+		arg            time.Time
+		expectedResult string
+	}{
+		{
+			arg:            now,
+			expectedResult: "just now",
+		},
+		{
+			arg:            now.Add(time.Second * 30),
+			expectedResult: "in the future",
+		},
+		{
+			arg:            now.Add(-time.Second * 59),
+			expectedResult: "59 seconds ago",
+		},
+		{
+			arg:            now.Add(-time.Minute),
+			expectedResult: "1 minute ago",
+		},
+		{
+			arg:            now.Add(-time.Hour * 5),
+			expectedResult: "5 hours ago",
+		},
+		{
+			arg:            now.Add(-time.Hour * 24 * 15),
+			expectedResult: "2 weeks ago",
+		},
+		{
+			arg:            now.Add(-time.Hour * 24 * 400),
+			expectedResult: "1 year ago",
+		},
+	}
+
+	for _, test := range tests {
+		actualResult := RelativeTime(test.arg, now)
+
+		if actualResult != test.expectedResult {
+			t.Errorf("RelativeTime return value does not match expected value. Expected: %v, Actual: %v", test.expectedResult, actualResult)
+		}
+	}
+}
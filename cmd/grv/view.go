@@ -11,6 +11,7 @@ import (
 const (
 	viewMinActiveViewRows = 6
 	viewHelpViewTitle     = "Help View"
+	viewMacroViewTitle    = "Macros"
 )
 
 // ViewID is an ID assigned to each view in grv
@@ -40,6 +41,13 @@ const (
 	ViewGRVVariable
 	ViewRemote
 	ViewGitSummary
+	ViewKeyHint
+	ViewMacro
+	ViewFuzzyFinder
+	ViewCommitMessage
+	ViewCompare
+	ViewCommitRange
+	ViewCommitInfo
 
 	ViewCount // i.e. Number of views
 )
@@ -168,6 +176,16 @@ func (activeView *activeView) orElse(defaultChildView BaseView) (childView BaseV
 	return defaultChildView
 }
 
+// Views which accept text input receive keys that are not bound in the view itself as text
+var textInputViews = map[ViewID]bool{
+	ViewCommitMessage: true,
+}
+
+// IsTextInputView returns true if the provided view accepts text input
+func IsTextInputView(viewID ViewID) bool {
+	return textInputViews[viewID]
+}
+
 var popupViewPassThroughActions = map[ActionType]bool{
 	ActionShowStatus:              true,
 	ActionCreateContextMenu:       true,
@@ -187,32 +205,39 @@ type View struct {
 	variables         GRVVariableSetter
 	repoData          RepoData
 	repoController    RepoController
+	macros            MacroProvider
 	promptActive      bool
 	errorView         *ErrorView
 	errorViewWin      *Window
+	keyHintView       *KeyHintView
+	keyHintViewWin    *Window
 	activeViewWin     *Window
 	emptyViewWin      *Window
 	errors            []error
 	windowViewFactory *WindowViewFactory
 	tabTitles         []string
 	activeViewDim     ViewDimension
+	sessionState      *SessionState
 	lock              sync.Mutex
 }
 
 // NewView creates a new instance
-func NewView(repoData RepoData, repoController RepoController, channels Channels, config ConfigSetter, variables GRVVariableSetter) (view *View) {
+func NewView(repoData RepoData, repoController RepoController, channels Channels, config ConfigSetter, variables GRVVariableSetter, macros MacroProvider) (view *View) {
 	view = &View{
 		channels:          channels,
 		config:            config,
 		variables:         variables,
 		repoData:          repoData,
 		repoController:    repoController,
+		macros:            macros,
 		windowViewFactory: NewWindowViewFactory(repoData, repoController, channels, config, variables),
 	}
 
 	view.grvStatusView = NewGRVStatusView(view, repoData, channels, config)
 	view.errorView = NewErrorView()
 	view.errorViewWin = NewWindow("errorView", config)
+	view.keyHintView = NewKeyHintView()
+	view.keyHintViewWin = NewWindow("keyHintView", config)
 	view.activeViewWin = NewWindow("activeView", config)
 	view.emptyViewWin = NewWindow("emptyView", config)
 
@@ -221,6 +246,17 @@ func NewView(repoData RepoData, repoController RepoController, channels Channels
 
 // Initialise sets up all child views
 func (view *View) Initialise() (err error) {
+	if view.sessionState != nil {
+		if err = view.restoreSessionState(view.sessionState); err == nil {
+			view.OnStateChange(ViewStateActive)
+			return
+		}
+
+		log.Errorf("Unable to restore session: %v", err)
+		view.channels.ReportError(fmt.Errorf("Unable to restore session: %v", err))
+		err = nil
+	}
+
 	if defaultViewGenerator := view.config.GetString(CfDefaultView); defaultViewGenerator != "" {
 		if errs := view.config.Evaluate(defaultViewGenerator); len(errs) > 0 {
 			log.Errorf("Errors when executing default view command %v", defaultViewGenerator)
@@ -244,6 +280,174 @@ func (view *View) Initialise() (err error) {
 	return
 }
 
+// SetSessionState sets the session state to restore when the view is initialised
+func (view *View) SetSessionState(sessionState *SessionState) {
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	view.sessionState = sessionState
+}
+
+// SessionState returns the layout and view state of all tabs
+func (view *View) SessionState() *SessionState {
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	sessionState := &SessionState{
+		ActiveTabIndex: view.activeViewPos,
+	}
+
+	for tabIndex, tab := range view.views {
+		tabState := GenerateViewSessionState(tab)
+		if tabState == nil {
+			log.Debugf("Unable to generate session state for tab %v", tab.Title())
+
+			if uint(tabIndex) < view.activeViewPos {
+				sessionState.ActiveTabIndex--
+			}
+
+			continue
+		}
+
+		sessionState.Tabs = append(sessionState.Tabs, tabState)
+	}
+
+	if sessionState.ActiveTabIndex >= uint(len(sessionState.Tabs)) {
+		sessionState.ActiveTabIndex = 0
+	}
+
+	return sessionState
+}
+
+func (view *View) restoreSessionState(sessionState *SessionState) (err error) {
+	var tabs []WindowViewCollection
+
+	for _, tabState := range sessionState.Tabs {
+		var tab *ContainerView
+		if tab, err = view.createSessionTab(tabState); err != nil {
+			for _, tab := range tabs {
+				tab.Dispose()
+			}
+
+			return
+		}
+
+		tabs = append(tabs, tab)
+	}
+
+	if len(tabs) == 0 {
+		return fmt.Errorf("Session contains no tabs")
+	}
+
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	view.views = tabs
+
+	if sessionState.ActiveTabIndex < uint(len(tabs)) {
+		view.activeViewPos = sessionState.ActiveTabIndex
+	}
+
+	log.Infof("Restored %v tab(s) from session", len(tabs))
+
+	return
+}
+
+func (view *View) createSessionTab(tabState *SessionViewState) (tab *ContainerView, err error) {
+	viewID, _ := tabState.viewID()
+
+	switch viewID {
+	case ViewHistory:
+		tab = NewHistoryView(view.repoData, view.repoController, view.channels, view.config, view.variables)
+	case ViewStatus:
+		tab = NewStatusView(view.repoData, view.repoController, view.channels, view.config, view.variables)
+	default:
+		var windowViews []WindowView
+		if tab, err = view.createSessionContainerView(tabState, &windowViews); err != nil {
+			return
+		}
+
+		registerSessionViewListeners(windowViews)
+	}
+
+	if err = tab.Initialise(); err != nil {
+		return
+	}
+
+	if err = tab.RestoreSessionState(tabState); err != nil {
+		tab.Dispose()
+		tab = nil
+	}
+
+	return
+}
+
+func (view *View) createSessionContainerView(containerState *SessionViewState, windowViews *[]WindowView) (containerView *ContainerView, err error) {
+	if !containerState.isContainer() {
+		return nil, fmt.Errorf("Expected container view but found %v", containerState.View)
+	}
+
+	containerView = NewContainerView(view.channels, view.config)
+	containerView.SetTitle(containerState.Title)
+	containerView.SetOrientation(containerState.Orientation)
+
+	for _, childState := range containerState.ChildViews {
+		if childState.isContainer() {
+			var childContainerView *ContainerView
+			if childContainerView, err = view.createSessionContainerView(childState, windowViews); err != nil {
+				return
+			}
+
+			containerView.AddChildViews(childContainerView)
+			continue
+		}
+
+		viewID, exists := childState.viewID()
+		if !exists {
+			return nil, fmt.Errorf("Unknown view %v", childState.View)
+		}
+
+		var windowView WindowView
+		if windowView, err = view.windowViewFactory.CreateWindowView(viewID); err != nil {
+			return
+		}
+
+		containerView.AddChildViews(windowView)
+		*windowViews = append(*windowViews, windowView)
+	}
+
+	return
+}
+
+// registerSessionViewListeners connects restored views in the same way
+// they are connected when created from the ref, commit or status view
+func registerSessionViewListeners(windowViews []WindowView) {
+	var refView *RefView
+	var diffViewSource WindowView
+
+	for _, windowView := range windowViews {
+		switch childView := windowView.(type) {
+		case *RefView:
+			refView = childView
+		case *CommitView:
+			if refView != nil {
+				refView.RegisterRefListener(childView)
+			}
+
+			diffViewSource = childView
+		case *GitStatusView:
+			diffViewSource = childView
+		case *DiffView:
+			switch source := diffViewSource.(type) {
+			case *CommitView:
+				source.RegisterCommitViewListener(childView)
+			case *GitStatusView:
+				source.RegisterGitStatusFileSelectedListener(childView)
+			}
+		}
+	}
+}
+
 // Dispose of any resources held by the view
 func (view *View) Dispose() {
 	view.lock.Lock()
@@ -334,6 +538,17 @@ func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error
 
 	wins = append(wins, popupViewWins...)
 
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	if view.keyHintView.HasHints() {
+		if err = view.renderKeyHintView(viewDimension); err != nil {
+			return
+		}
+
+		wins = append(wins, view.keyHintViewWin)
+	}
+
 	return
 }
 
@@ -447,6 +662,21 @@ func (view *View) renderPopupViews(availableViewDimension ViewDimension) (wins [
 	return
 }
 
+func (view *View) renderKeyHintView(availableViewDimension ViewDimension) (err error) {
+	maxRows := availableViewDimension.rows - 3
+	viewDimension := ViewDimension{
+		rows: MinUInt(view.keyHintView.DisplayRowsRequired(availableViewDimension.cols), maxRows),
+		cols: availableViewDimension.cols,
+	}
+
+	win := view.keyHintViewWin
+	win.Resize(viewDimension)
+	win.SetPosition(availableViewDimension.rows-2-viewDimension.rows, 0)
+	win.Clear()
+
+	return view.keyHintView.Render(win)
+}
+
 func (view *View) renderEmptyView(viewDimension ViewDimension) (wins []*Window, err error) {
 	win := view.emptyViewWin
 	win.SetPosition(0, 0)
@@ -506,6 +736,8 @@ func (view *View) HandleEvent(event Event) (err error) {
 		for _, removedView := range event.Args {
 			if baseView, ok := removedView.(BaseView); ok {
 				baseView.Dispose()
+			} else if removedPopupView, ok := removedView.(popupView); ok {
+				removedPopupView.windowView().Dispose()
 			}
 		}
 	}
@@ -522,7 +754,9 @@ func (view *View) HandleAction(action Action) (err error) {
 		return view.handlePopupViewAction(action)
 	}
 
-	if IsPromptAction(action.ActionType) {
+	if action.ActionType == ActionFuzzyFinderPrompt {
+		return view.showFuzzyFinder(action)
+	} else if IsPromptAction(action.ActionType) {
 		return view.prompt(action)
 	}
 
@@ -606,11 +840,31 @@ func (view *View) HandleAction(action Action) (err error) {
 		defer view.lock.Unlock()
 
 		return view.createMessageBoxView(action)
+	case ActionCreateCommitMessageView:
+		view.lock.Lock()
+		defer view.lock.Unlock()
+
+		return view.createCommitMessageView(action)
+	case ActionCreateCommitInfoView:
+		view.lock.Lock()
+		defer view.lock.Unlock()
+
+		return view.createCommitInfoView(action)
 	case ActionShowHelpView:
 		view.lock.Lock()
 		defer view.lock.Unlock()
 
 		return view.showHelpView(action)
+	case ActionShowMacroView:
+		view.lock.Lock()
+		defer view.lock.Unlock()
+
+		return view.showMacroView()
+	case ActionShowCompareView:
+		view.lock.Lock()
+		defer view.lock.Unlock()
+
+		return view.showCompareView(action)
 	}
 
 	return view.ActiveView().HandleAction(action)
@@ -713,6 +967,26 @@ func (view *View) SetErrors(errors []error) {
 	view.errors = errors
 }
 
+// ShowKeyHints displays the key bindings which complete the pending prefix
+func (view *View) ShowKeyHints(prefix string, completions []KeyBindingCompletion) {
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	view.keyHintView.SetCompletions(prefix, completions)
+	view.channels.UpdateDisplay()
+}
+
+// HideKeyHints removes any key hints currently displayed
+func (view *View) HideKeyHints() {
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	if view.keyHintView.HasHints() {
+		view.keyHintView.Clear()
+		view.channels.UpdateDisplay()
+	}
+}
+
 // Title returns the title of this view
 func (view *View) Title() string {
 	return "Main View"
@@ -1006,6 +1280,31 @@ func (view *View) createContextMenuView(action Action) (err error) {
 	return
 }
 
+func (view *View) showFuzzyFinder(action Action) (err error) {
+	fuzzyFinderView := NewFuzzyFinderView(view.repoData, view.channels, view.config, view.variables)
+
+	view.lock.Lock()
+	view.addPopupView(&fixedSizePopupView{
+		abstractPopupView: &abstractPopupView{
+			view: fuzzyFinderView,
+			win:  NewWindow(fmt.Sprintf("popupView-%v", len(view.popupViews)), view.config),
+		},
+		viewDimension: ViewDimension{
+			rows: ffViewRows,
+			cols: ffViewCols,
+		},
+	})
+	view.lock.Unlock()
+
+	log.Debugf("Created fuzzy finder")
+
+	action.Args = append(action.Args, ActionFuzzyFinderPromptArgs{
+		promptListener: fuzzyFinderView,
+	})
+
+	return view.prompt(action)
+}
+
 func (view *View) createCommandOutputView(action Action) (err error) {
 	if len(action.Args) == 0 {
 		return fmt.Errorf("Expected ActionCreateCommandOutputViewArgs argument")
@@ -1060,6 +1359,60 @@ func (view *View) createMessageBoxView(action Action) (err error) {
 	return
 }
 
+func (view *View) createCommitMessageView(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected ActionCreateCommitMessageViewArgs argument")
+	}
+
+	arg, ok := action.Args[0].(ActionCreateCommitMessageViewArgs)
+	if !ok {
+		return fmt.Errorf("Expected ActionCreateCommitMessageViewArgs argument but got %T", action.Args[0])
+	}
+
+	view.addPopupView(&fixedSizePopupView{
+		abstractPopupView: &abstractPopupView{
+			view: NewCommitMessageView(arg.config, view.channels, view.config, view.variables),
+			win:  NewWindow(fmt.Sprintf("popupView-%v", len(view.popupViews)), view.config),
+		},
+		viewDimension: ViewDimension{
+			rows: cmvViewRows,
+			cols: cmvViewCols,
+		},
+	})
+
+	log.Debugf("Created commit message view")
+
+	return
+}
+
+func (view *View) createCommitInfoView(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected ActionCreateCommitInfoViewArgs argument")
+	}
+
+	arg, ok := action.Args[0].(ActionCreateCommitInfoViewArgs)
+	if !ok {
+		return fmt.Errorf("Expected ActionCreateCommitInfoViewArgs argument but got %T", action.Args[0])
+	}
+
+	commitInfoView := NewCommitInfoView(view.repoData, arg.commit, view.channels, view.config, view.variables)
+	if err = commitInfoView.Initialise(); err != nil {
+		return
+	}
+
+	view.addPopupView(&fixedSizePopupView{
+		abstractPopupView: &abstractPopupView{
+			view: commitInfoView,
+			win:  NewWindow(fmt.Sprintf("popupView-%v", len(view.popupViews)), view.config),
+		},
+		viewDimension: arg.viewDimension,
+	})
+
+	log.Debugf("Created commit info view for commit %v", arg.commit.oid)
+
+	return
+}
+
 func (view *View) addPopupView(popupView popupView) {
 	if !view.popupViewsActive() {
 		view.onStateChange(ViewStateInactiveAndVisible)
@@ -1144,6 +1497,28 @@ func (view *View) processMouseEventForPopupView(action Action) (handled bool, er
 	return
 }
 
+func (view *View) showMacroView() (err error) {
+	for childViewIndex, childView := range view.views {
+		if childView.Title() == viewMacroViewTitle {
+			view.onStateChange(ViewStateInvisible)
+			view.activeViewPos = uint(childViewIndex)
+			view.onStateChange(ViewStateActive)
+			view.channels.UpdateDisplay()
+			return
+		}
+	}
+
+	macroView := NewMacroView(view.macros, view.channels, view.config, view.variables)
+	if err = macroView.Initialise(); err != nil {
+		return
+	}
+
+	view.addTab(viewMacroViewTitle).AddChildViews(macroView)
+	view.channels.UpdateDisplay()
+
+	return
+}
+
 func (view *View) showHelpView(action Action) (err error) {
 	var helpView *HelpView
 
@@ -1176,3 +1551,48 @@ func (view *View) showHelpView(action Action) (err error) {
 
 	return
 }
+
+// showCompareView selects the compare tab for the two refs provided as arguments, creating it if necessary
+func (view *View) showCompareView(action Action) (err error) {
+	if len(action.Args) < 2 {
+		return fmt.Errorf("Expected two ref names to compare but received %v argument(s)", len(action.Args))
+	}
+
+	refs := make([]Ref, 2)
+
+	for argIndex := range refs {
+		refName, ok := action.Args[argIndex].(string)
+		if !ok {
+			return fmt.Errorf("Expected ref name argument to be of type string but found type %T", action.Args[argIndex])
+		}
+
+		if refName == "HEAD" {
+			refs[argIndex] = view.repoData.Head()
+		} else if refs[argIndex], err = view.repoData.Ref(refName); err != nil {
+			return
+		}
+	}
+
+	title := CompareViewTitle(refs[0], refs[1])
+
+	for childViewIndex, childView := range view.views {
+		if childView.Title() == title {
+			view.onStateChange(ViewStateInvisible)
+			view.activeViewPos = uint(childViewIndex)
+			view.onStateChange(ViewStateActive)
+			view.channels.UpdateDisplay()
+			return
+		}
+	}
+
+	compareView := NewCompareView(view.repoData, view.channels, view.config, view.variables, refs[0], refs[1])
+	if err = compareView.Initialise(); err != nil {
+		return
+	}
+
+	view.addTab(title).AddChildViews(compareView)
+	view.onStateChange(ViewStateActive)
+	view.channels.UpdateDisplay()
+
+	return
+}
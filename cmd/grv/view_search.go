@@ -59,9 +59,9 @@ func (viewSearch *ViewSearch) HandleAction(action Action) (handled bool, err err
 	case ActionSearch, ActionReverseSearch:
 		err = viewSearch.doSearch(action)
 	case ActionSearchFindNext:
-		err = viewSearch.findNextMatch()
+		err = viewSearch.findNextMatch(action)
 	case ActionSearchFindPrev:
-		err = viewSearch.findPrevMatch()
+		err = viewSearch.findPrevMatch(action)
 	case ActionClearSearch:
 		err = viewSearch.clearSearch()
 	default:
@@ -79,10 +79,10 @@ func (viewSearch *ViewSearch) doSearch(action Action) (err error) {
 
 	viewSearch.search = search
 
-	return viewSearch.findNextMatch()
+	return viewSearch.findNextMatch(action)
 }
 
-func (viewSearch *ViewSearch) findNextMatch() (err error) {
+func (viewSearch *ViewSearch) findNextMatch(action Action) (err error) {
 	active, pattern, _ := viewSearch.searchActive()
 	if !active {
 		return
@@ -90,12 +90,16 @@ func (viewSearch *ViewSearch) findNextMatch() (err error) {
 
 	viewSearch.channels.ReportStatus("Searching...")
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		viewPos := viewSearch.searchableView.ViewPos()
 		log.Debugf("Searching for next occurrence of pattern %v starting from row index :%v",
 			pattern, viewPos.ActiveRowIndex())
 
-		matchLineIndex, found := viewSearch.search.FindNext(viewPos.ActiveRowIndex())
+		matchLineIndex, found := viewSearch.repeatFind(action, viewPos.ActiveRowIndex(), viewSearch.search.FindNext)
 
 		viewSearch.lock.Lock()
 		viewSearch.lastSearchFoundMatch = found
@@ -112,7 +116,7 @@ func (viewSearch *ViewSearch) findNextMatch() (err error) {
 	return
 }
 
-func (viewSearch *ViewSearch) findPrevMatch() (err error) {
+func (viewSearch *ViewSearch) findPrevMatch(action Action) (err error) {
 	active, pattern, _ := viewSearch.searchActive()
 	if !active {
 		return
@@ -120,12 +124,16 @@ func (viewSearch *ViewSearch) findPrevMatch() (err error) {
 
 	viewSearch.channels.ReportStatus("Searching...")
 
+	pendingBackgroundWork.Start()
+
 	go func() {
+		defer pendingBackgroundWork.Complete()
+
 		viewPos := viewSearch.searchableView.ViewPos()
 		log.Debugf("Searching for previous occurrence of pattern %v starting from row index :%v",
 			pattern, viewPos.ActiveRowIndex())
 
-		matchLineIndex, found := viewSearch.search.FindPrev(viewPos.ActiveRowIndex())
+		matchLineIndex, found := viewSearch.repeatFind(action, viewPos.ActiveRowIndex(), viewSearch.search.FindPrev)
 
 		viewSearch.lock.Lock()
 		viewSearch.lastSearchFoundMatch = found
@@ -143,6 +151,24 @@ func (viewSearch *ViewSearch) findPrevMatch() (err error) {
 	return
 }
 
+// repeatFind finds the match the number of matches away from the start row specified by the action count.
+// The furthest match found is returned if there are fewer matches than the count
+func (viewSearch *ViewSearch) repeatFind(action Action, startRowIndex uint, find func(uint) (uint, bool)) (matchLineIndex uint, found bool) {
+	rowIndex := startRowIndex
+
+	for count, _ := GetActionCount(action); count > 0; count-- {
+		nextMatchLineIndex, nextFound := find(rowIndex)
+		if !nextFound || (found && nextMatchLineIndex == matchLineIndex) {
+			break
+		}
+
+		matchLineIndex, found = nextMatchLineIndex, true
+		rowIndex = nextMatchLineIndex
+	}
+
+	return
+}
+
 func (viewSearch *ViewSearch) clearSearch() (err error) {
 	if active, pattern, _ := viewSearch.searchActive(); active {
 		viewSearch.channels.ReportStatus("Cleared search")
@@ -23,6 +23,11 @@ func (container *WindowViewContainer) SetChild(child WindowView) {
 	container.child = child
 }
 
+// Child returns the underlying child view
+func (container *WindowViewContainer) Child() WindowView {
+	return container.child
+}
+
 // Initialise is forwarded onto the child view
 func (container *WindowViewContainer) Initialise() (err error) {
 	if container.child != nil {